@@ -18,6 +18,20 @@ import (
 const (
 	jwtExpiry         = 15 * time.Minute
 	refreshTokenBytes = 32
+	resetTokenBytes   = 32
+	verifyTokenBytes  = 32
+	challengeBytes    = 32
+	ceremonyBytes     = 32
+	// PasswordResetExpiry is how long a password reset link can be used for.
+	PasswordResetExpiry = time.Hour
+	// EmailVerificationExpiry is how long an email verification link can be
+	// used for.
+	EmailVerificationExpiry = 48 * time.Hour
+	// LoginChallengeExpiry is how long a user has to enter their second
+	// factor after their password.
+	LoginChallengeExpiry = 5 * time.Minute
+	// CeremonyExpiry is how long a passkey ceremony can take to finish.
+	CeremonyExpiry = 5 * time.Minute
 )
 
 type CustomClaims struct {
@@ -94,15 +108,63 @@ func GetBearerToken(headers http.Header) (string, error) {
 }
 
 func MakeRefreshToken() (string, error) {
-	refreshTokenBase := make([]byte, refreshTokenBytes)
-	_, err := rand.Read(refreshTokenBase)
+	return makeToken(refreshTokenBytes)
+}
+
+func HashRefreshToken(refreshToken string) string {
+	return hashToken(refreshToken)
+}
+
+// MakeResetToken makes a single-use password reset token. Like refresh tokens,
+// only its hash is stored.
+func MakeResetToken() (string, error) {
+	return makeToken(resetTokenBytes)
+}
+
+func HashResetToken(resetToken string) string {
+	return hashToken(resetToken)
+}
+
+// MakeVerificationToken makes a single-use email verification token. Only its
+// hash is stored.
+func MakeVerificationToken() (string, error) {
+	return makeToken(verifyTokenBytes)
+}
+
+func HashVerificationToken(verificationToken string) string {
+	return hashToken(verificationToken)
+}
+
+// MakeLoginChallenge makes a single-use token standing for a login that's
+// waiting on a second factor. Only its hash is stored.
+func MakeLoginChallenge() (string, error) {
+	return makeToken(challengeBytes)
+}
+
+func HashLoginChallenge(challenge string) string {
+	return hashToken(challenge)
+}
+
+// MakeCeremonyToken makes a single-use token naming the stored state of a
+// passkey registration or login. Only its hash is stored.
+func MakeCeremonyToken() (string, error) {
+	return makeToken(ceremonyBytes)
+}
+
+func HashCeremonyToken(token string) string {
+	return hashToken(token)
+}
+
+func makeToken(n int) (string, error) {
+	tokenBase := make([]byte, n)
+	_, err := rand.Read(tokenBase)
 	if err != nil {
 		return "", err
 	}
-	return hex.EncodeToString(refreshTokenBase), nil
+	return hex.EncodeToString(tokenBase), nil
 }
 
-func HashRefreshToken(refreshToken string) string {
-	hashedRefreshToken := sha256.Sum256([]byte(refreshToken))
-	return hex.EncodeToString(hashedRefreshToken[:])
+func hashToken(token string) string {
+	hashedToken := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashedToken[:])
 }
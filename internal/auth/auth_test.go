@@ -148,3 +148,27 @@ func TestGetBearerToken(t *testing.T) {
 		})
 	}
 }
+
+func TestResetToken(t *testing.T) {
+	first, err := MakeResetToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := MakeResetToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(first) != resetTokenBytes*2 {
+		t.Errorf("expected %d hex characters, got %d", resetTokenBytes*2, len(first))
+	}
+	if first == second {
+		t.Error("expected tokens to differ")
+	}
+	if HashResetToken(first) != HashResetToken(first) {
+		t.Error("expected hashing to be deterministic")
+	}
+	if HashResetToken(first) == first {
+		t.Error("expected hash to differ from token")
+	}
+}
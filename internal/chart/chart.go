@@ -0,0 +1,276 @@
+// Package chart lays out line and bar charts over time for rendering as
+// inline SVG: the positions of every point, bar, axis tick and goal line
+// inside a fixed viewBox
+package chart
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The viewBox every chart is laid out in. The plot area is inset to leave room
+// for the axis labels.
+const (
+	Width  = 600.0
+	Height = 240.0
+
+	padLeft   = 56.0
+	padRight  = 16.0
+	padTop    = 16.0
+	padBottom = 28.0
+
+	maxBarWidth = 24.0
+	xTickCount  = 5
+	yTickCount  = 5
+)
+
+// Point is one value at a moment in time. Label is its tooltip.
+type Point struct {
+	At    time.Time
+	Value float64
+	Label string
+}
+
+// Series is a named line of points.
+type Series struct {
+	Name   string
+	Points []Point
+	// Markers draws a dot with a tooltip at every point.
+	Markers bool
+}
+
+// Goal is a horizontal reference line, such as a target weight.
+type Goal struct {
+	Value float64
+	Label string
+}
+
+// Spec describes a chart to lay out. Format renders values on the y axis.
+type Spec struct {
+	ID     string
+	Title  string
+	Desc   string
+	Goals  []Goal
+	Format func(float64) string
+}
+
+// Tick is a labelled position along an axis.
+type Tick struct {
+	Pos   float64
+	Label string
+}
+
+// Marker is a point drawn on a line, with its tooltip.
+type Marker struct {
+	X, Y  float64
+	Title string
+}
+
+// Line is a series laid out in the viewBox.
+type Line struct {
+	Name    string
+	Points  string
+	Markers []Marker
+}
+
+// Bar is a single bar, from its top left corner.
+type Bar struct {
+	X, Y, W, H float64
+	Title      string
+}
+
+// GoalLine is a goal laid out across the plot.
+type GoalLine struct {
+	Y     float64
+	Label string
+}
+
+// Chart is a laid out chart. Top, Bottom, Left and Right bound the plot.
+type Chart struct {
+	ID, Title, Desc          string
+	Top, Bottom, Left, Right float64
+	XTicks                   []Tick
+	YTicks                   []Tick
+	Lines                    []Line
+	Bars                     []Bar
+	Goals                    []GoalLine
+}
+
+// Empty reports whether the chart has nothing to draw.
+func (c Chart) Empty() bool {
+	return len(c.Lines) == 0 && len(c.Bars) == 0
+}
+
+// NewLine lays out series as lines against a shared date and value axis.
+// Series without points are skipped.
+func NewLine(spec Spec, series ...Series) Chart {
+	var all []Point
+	for _, s := range series {
+		all = append(all, s.Points...)
+	}
+	c, sx, sy := layout(spec, all, false)
+	if len(all) == 0 {
+		return c
+	}
+
+	for _, s := range series {
+		if len(s.Points) == 0 {
+			continue
+		}
+		line := Line{Name: s.Name}
+		coords := make([]string, len(s.Points))
+		for i, p := range s.Points {
+			x, y := sx(p.At), sy(p.Value)
+			coords[i] = Num(x) + "," + Num(y)
+			if s.Markers {
+				line.Markers = append(line.Markers, Marker{X: x, Y: y, Title: p.Label})
+			}
+		}
+		line.Points = strings.Join(coords, " ")
+		c.Lines = append(c.Lines, line)
+	}
+	return c
+}
+
+// NewBar lays out one bar per point, centred on its date and rising from
+// zero.
+func NewBar(spec Spec, points []Point) Chart {
+	c, sx, sy := layout(spec, points, true)
+	if len(points) == 0 {
+		return c
+	}
+
+	w := math.Min(maxBarWidth, (c.Right-c.Left)/float64(len(points))*0.6)
+	for _, p := range points {
+		top, base := sy(p.Value), sy(0)
+		c.Bars = append(c.Bars, Bar{
+			X:     sx(p.At) - w/2,
+			Y:     math.Min(top, base),
+			W:     w,
+			H:     math.Abs(base - top),
+			Title: p.Label,
+		})
+	}
+	return c
+}
+
+// Num formats a coordinate for an SVG attribute.
+func Num(f float64) string {
+	return strconv.FormatFloat(f, 'f', 1, 64)
+}
+
+// layout sets up the plot area and axes for points and returns the scales
+// placing dates and values in the viewBox. Bar charts always include zero and
+// keep half a bar's room at either end.
+func layout(spec Spec, points []Point, bars bool) (Chart, func(time.Time) float64, func(float64) float64) {
+	c := Chart{
+		ID:     spec.ID,
+		Title:  spec.Title,
+		Desc:   spec.Desc,
+		Top:    padTop,
+		Bottom: Height - padBottom,
+		Left:   padLeft,
+		Right:  Width - padRight,
+	}
+	if len(points) == 0 {
+		return c, nil, nil
+	}
+	format := spec.Format
+	if format == nil {
+		format = func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	}
+
+	first, last := points[0].At, points[0].At
+	lo, hi := points[0].Value, points[0].Value
+	for _, p := range points {
+		if p.At.Before(first) {
+			first = p.At
+		}
+		if p.At.After(last) {
+			last = p.At
+		}
+		lo, hi = math.Min(lo, p.Value), math.Max(hi, p.Value)
+	}
+	for _, g := range spec.Goals {
+		lo, hi = math.Min(lo, g.Value), math.Max(hi, g.Value)
+	}
+	if bars {
+		lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	}
+	lo, hi, step := niceRange(lo, hi)
+
+	left, right := c.Left, c.Right
+	if bars {
+		inset := math.Min(maxBarWidth, (right-left)/float64(len(points))*0.6)
+		left, right = left+inset, right-inset
+	}
+	span := last.Sub(first)
+	sx := func(t time.Time) float64 {
+		if span == 0 {
+			return (left + right) / 2
+		}
+		return left + float64(t.Sub(first))/float64(span)*(right-left)
+	}
+	sy := func(v float64) float64 {
+		return c.Bottom - (v-lo)/(hi-lo)*(c.Bottom-c.Top)
+	}
+
+	for i := range int(math.Round((hi-lo)/step)) + 1 {
+		// Rounded so repeated steps of 0.1 don't label 0.30000000000000004
+		v := math.Round((lo+float64(i)*step)*1e6) / 1e6
+		c.YTicks = append(c.YTicks, Tick{Pos: sy(v), Label: format(v)})
+	}
+	c.XTicks = dateTicks(first, last, sx)
+	for _, g := range spec.Goals {
+		c.Goals = append(c.Goals, GoalLine{Y: sy(g.Value), Label: g.Label})
+	}
+	return c, sx, sy
+}
+
+// niceRange widens lo and hi to round numbers a step apart, so the y axis is
+// labelled at values like 175, 180 and 185 rather than 176.3.
+func niceRange(lo, hi float64) (float64, float64, float64) {
+	if hi == lo {
+		pad := math.Max(math.Abs(hi)*0.05, 1)
+		lo, hi = lo-pad, hi+pad
+	}
+	raw := (hi - lo) / float64(yTickCount-1)
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * mag
+	for _, m := range []float64{1, 2, 2.5, 5} {
+		if m*mag >= raw {
+			step = m * mag
+			break
+		}
+	}
+	// Nudged so 18.2 / 0.2 landing just under 91 doesn't floor to 90
+	const eps = 1e-9
+	return math.Floor(lo/step+eps) * step, math.Ceil(hi/step-eps) * step, step
+}
+
+// dateTicks labels up to xTickCount evenly spaced dates between first and
+// last. Spans over a year show the month and year.
+func dateTicks(first, last time.Time, sx func(time.Time) float64) []Tick {
+	layout := "Jan 2"
+	if last.Sub(first) > 365*24*time.Hour {
+		layout = "Jan 2006"
+	}
+	if !last.After(first) {
+		return []Tick{{Pos: sx(first), Label: first.Format(layout)}}
+	}
+
+	ticks := make([]Tick, 0, xTickCount)
+	seen := map[string]bool{}
+	for i := range xTickCount {
+		t := first.Add(last.Sub(first) * time.Duration(i) / (xTickCount - 1))
+		label := t.Format(layout)
+		if seen[label] {
+			continue
+		}
+		seen[label] = true
+		ticks = append(ticks, Tick{Pos: sx(t), Label: label})
+	}
+	return ticks
+}
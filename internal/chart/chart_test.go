@@ -0,0 +1,166 @@
+package chart
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func days(n int) time.Time {
+	return start.AddDate(0, 0, n)
+}
+
+func TestNiceRange(t *testing.T) {
+	tests := map[string]struct {
+		lo, hi                   float64
+		wantLo, wantHi, wantStep float64
+	}{
+		"body weight": {lo: 176.3, hi: 184.9, wantLo: 175, wantHi: 185, wantStep: 2.5},
+		"percent":     {lo: 18.2, hi: 18.9, wantLo: 18.2, wantHi: 19, wantStep: 0.2},
+		"volume":      {lo: 0, hi: 12450, wantLo: 0, wantHi: 15000, wantStep: 5000},
+		"flat":        {lo: 180, hi: 180, wantLo: 170, wantHi: 190, wantStep: 5},
+		"zero":        {lo: 0, hi: 0, wantLo: -1, wantHi: 1, wantStep: 0.5},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			lo, hi, step := niceRange(tc.lo, tc.hi)
+			if !near(lo, tc.wantLo) || !near(hi, tc.wantHi) || !near(step, tc.wantStep) {
+				t.Errorf("expected %v-%v by %v, got %v-%v by %v", tc.wantLo, tc.wantHi, tc.wantStep, lo, hi, step)
+			}
+		})
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNewLine(t *testing.T) {
+	entries := Series{Name: "entries", Markers: true, Points: []Point{
+		{At: days(0), Value: 180, Label: "Jan 1: 180"},
+		{At: days(10), Value: 190, Label: "Jan 11: 190"},
+	}}
+
+	tests := map[string]struct {
+		series      []Series
+		goals       []Goal
+		wantEmpty   bool
+		wantPoints  string
+		wantMarkers int
+		wantXTicks  []string
+		wantGoalY   float64
+	}{
+		"empty": {series: []Series{{Name: "entries"}}, wantEmpty: true},
+		"two points": {
+			series:      []Series{entries},
+			wantPoints:  "56.0,212.0 584.0,16.0",
+			wantMarkers: 2,
+			wantXTicks:  []string{"Jan 1", "Jan 3", "Jan 6", "Jan 8", "Jan 11"},
+		},
+		"goal widens range": {
+			series:      []Series{entries},
+			goals:       []Goal{{Value: 170, Label: "Goal"}},
+			wantPoints:  "56.0,114.0 584.0,16.0",
+			wantMarkers: 2,
+			wantXTicks:  []string{"Jan 1", "Jan 3", "Jan 6", "Jan 8", "Jan 11"},
+			wantGoalY:   212,
+		},
+		"single point": {
+			series:      []Series{{Name: "entries", Markers: true, Points: entries.Points[:1]}},
+			wantPoints:  "320.0,114.0",
+			wantMarkers: 1,
+			wantXTicks:  []string{"Jan 1"},
+		},
+		"no markers": {
+			series:     []Series{{Name: "average", Points: entries.Points}},
+			wantPoints: "56.0,212.0 584.0,16.0",
+			wantXTicks: []string{"Jan 1", "Jan 3", "Jan 6", "Jan 8", "Jan 11"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := NewLine(Spec{ID: "chart", Title: "Body weight", Goals: tc.goals}, tc.series...)
+			if c.Empty() != tc.wantEmpty {
+				t.Fatalf("expected empty %v, got %v", tc.wantEmpty, c.Empty())
+			}
+			if tc.wantEmpty {
+				return
+			}
+			if c.Lines[0].Points != tc.wantPoints {
+				t.Errorf("expected points %q, got %q", tc.wantPoints, c.Lines[0].Points)
+			}
+			if len(c.Lines[0].Markers) != tc.wantMarkers {
+				t.Errorf("expected %d markers, got %d", tc.wantMarkers, len(c.Lines[0].Markers))
+			}
+			if len(c.XTicks) != len(tc.wantXTicks) {
+				t.Fatalf("expected x ticks %v, got %+v", tc.wantXTicks, c.XTicks)
+			}
+			for i, tick := range c.XTicks {
+				if tick.Label != tc.wantXTicks[i] {
+					t.Errorf("expected x tick %q, got %q", tc.wantXTicks[i], tick.Label)
+				}
+			}
+			if len(tc.goals) > 0 && !near(c.Goals[0].Y, tc.wantGoalY) {
+				t.Errorf("expected goal at %v, got %v", tc.wantGoalY, c.Goals[0].Y)
+			}
+		})
+	}
+}
+
+func TestNewBar(t *testing.T) {
+	points := []Point{
+		{At: days(0), Value: 1000, Label: "Jan 1"},
+		{At: days(7), Value: 2000, Label: "Jan 8"},
+	}
+
+	c := NewBar(Spec{ID: "volume", Title: "Volume"}, points)
+	if len(c.Bars) != 2 {
+		t.Fatalf("expected 2 bars, got %d", len(c.Bars))
+	}
+	if c.YTicks[0].Label != "0" {
+		t.Errorf("expected the axis to start at 0, got %q", c.YTicks[0].Label)
+	}
+	first, second := c.Bars[0], c.Bars[1]
+	if first.W != maxBarWidth || second.W != maxBarWidth {
+		t.Errorf("expected bars %v wide, got %v and %v", maxBarWidth, first.W, second.W)
+	}
+	if first.X+first.W/2 <= c.Left || second.X+second.W/2 >= c.Right {
+		t.Errorf("expected bars inside the plot, got %+v", c.Bars)
+	}
+	if !near(first.Y+first.H, c.Bottom) || !near(second.H, 2*first.H) {
+		t.Errorf("expected bars rising from zero in proportion, got %+v", c.Bars)
+	}
+	if first.Title != "Jan 1" {
+		t.Errorf("expected tooltip %q, got %q", "Jan 1", first.Title)
+	}
+}
+
+func TestDateTicks(t *testing.T) {
+	sx := func(time.Time) float64 { return 0 }
+	tests := map[string]struct {
+		first, last time.Time
+		want        []string
+	}{
+		"same day": {first: days(0), last: days(0), want: []string{"Jan 1"}},
+		"two days": {first: days(0), last: days(1), want: []string{"Jan 1", "Jan 2"}},
+		"years":    {first: days(0), last: days(800), want: []string{"Jan 2025", "Jul 2025", "Feb 2026", "Aug 2026", "Mar 2027"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := dateTicks(tc.first, tc.last, sx)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %+v", tc.want, got)
+			}
+			for i, tick := range got {
+				if tick.Label != tc.want[i] {
+					t.Errorf("expected %q, got %q", tc.want[i], tick.Label)
+				}
+			}
+		})
+	}
+}
@@ -8,7 +8,10 @@ import (
 
 type contextKey string
 
-const UserIDKey contextKey = "userID"
+const (
+	UserIDKey  contextKey = "userID"
+	IsAdminKey contextKey = "isAdmin"
+)
 
 // UserID extracts the authenticated user's ID from the request context.
 // Returns false if the key is missing or not a valid UUID, which indicates
@@ -17,3 +20,10 @@ func UserID(ctx context.Context) (uuid.UUID, bool) {
 	id, ok := ctx.Value(UserIDKey).(uuid.UUID)
 	return id, ok
 }
+
+// IsAdmin reports whether the authenticated user carries the admin claim.
+// Missing or malformed values are treated as non-admin.
+func IsAdmin(ctx context.Context) bool {
+	isAdmin, ok := ctx.Value(IsAdminKey).(bool)
+	return ok && isAdmin
+}
@@ -47,3 +47,35 @@ func TestUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestIsAdmin(t *testing.T) {
+	tests := map[string]struct {
+		ctx  context.Context
+		want bool
+	}{
+		"admin": {
+			ctx:  context.WithValue(t.Context(), IsAdminKey, true),
+			want: true,
+		},
+		"not admin": {
+			ctx:  context.WithValue(t.Context(), IsAdminKey, false),
+			want: false,
+		},
+		"invalid type": {
+			ctx:  context.WithValue(t.Context(), IsAdminKey, "true"),
+			want: false,
+		},
+		"missing": {
+			ctx:  context.Background(),
+			want: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := IsAdmin(tc.ctx); got != tc.want {
+				t.Fatalf("expected: %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
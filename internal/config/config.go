@@ -3,14 +3,22 @@ package config
 import (
 	"database/sql"
 	"log/slog"
+	"time"
 
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/mail"
 )
 
 type OAuthProvider struct {
+	// Name is shown on the sign in button
+	Name         string
 	ClientID     string
 	ClientSecret string
 	RedirectURL  string
+	// Issuer is the OpenID Connect issuer endpoints are discovered from.
+	// Providers without one, like GitHub, have their endpoints built in.
+	Issuer string
 }
 
 type Config struct {
@@ -19,8 +27,17 @@ type Config struct {
 	Logger      *slog.Logger
 	TokenSecret string
 	OAuth       map[string]OAuthProvider
+	RestTimer   time.Duration
+	Mailer      mail.Mailer
+	// BaseURL is where the app is served, for links sent outside it
+	BaseURL string
+	// RequireVerifiedEmail withholds session tokens from accounts that
+	// haven't verified their email
+	RequireVerifiedEmail bool
+	// WebAuthn runs passkey ceremonies for BaseURL's origin
+	WebAuthn *webauthn.WebAuthn
 }
 
-func New(db *database.Queries, rawDB *sql.DB, logger *slog.Logger, tokenSecret string, oauth map[string]OAuthProvider) *Config {
-	return &Config{DB: db, RawDB: rawDB, Logger: logger, TokenSecret: tokenSecret, OAuth: oauth}
+func New(db *database.Queries, rawDB *sql.DB, logger *slog.Logger, tokenSecret string, oauth map[string]OAuthProvider, restTimer time.Duration, mailer mail.Mailer, baseURL string, requireVerifiedEmail bool, webAuthn *webauthn.WebAuthn) *Config {
+	return &Config{DB: db, RawDB: rawDB, Logger: logger, TokenSecret: tokenSecret, OAuth: oauth, RestTimer: restTimer, Mailer: mailer, BaseURL: baseURL, RequireVerifiedEmail: requireVerifiedEmail, WebAuthn: webAuthn}
 }
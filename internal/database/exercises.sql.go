@@ -13,6 +13,65 @@ import (
 	"github.com/lib/pq"
 )
 
+const createCustomExercise = `-- name: CreateCustomExercise :one
+INSERT INTO exercises (
+    id,
+    created_at,
+    updated_at,
+    name,
+    description,
+    primary_muscle_group,
+    secondary_muscle_group,
+    user_id,
+    tracking_type
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
+`
+
+type CreateCustomExerciseParams struct {
+	Name                 string
+	Description          sql.NullString
+	PrimaryMuscleGroup   sql.NullString
+	SecondaryMuscleGroup sql.NullString
+	UserID               uuid.NullUUID
+	TrackingType         string
+}
+
+func (q *Queries) CreateCustomExercise(ctx context.Context, arg CreateCustomExerciseParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, createCustomExercise,
+		arg.Name,
+		arg.Description,
+		arg.PrimaryMuscleGroup,
+		arg.SecondaryMuscleGroup,
+		arg.UserID,
+		arg.TrackingType,
+	)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PrimaryMuscleGroup,
+		&i.SecondaryMuscleGroup,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
+	)
+	return i, err
+}
+
 const createExercise = `-- name: CreateExercise :one
 INSERT INTO exercises (
     id,
@@ -21,7 +80,9 @@ INSERT INTO exercises (
     name,
     description,
     primary_muscle_group,
-    secondary_muscle_group
+    secondary_muscle_group,
+    video_url,
+    tracking_type
 ) VALUES (
     gen_random_uuid(),
     now(),
@@ -29,8 +90,10 @@ INSERT INTO exercises (
     $1,
     $2,
     $3,
-    $4
-) RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url
+    $4,
+    $5,
+    $6
+) RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
 `
 
 type CreateExerciseParams struct {
@@ -38,6 +101,8 @@ type CreateExerciseParams struct {
 	Description          sql.NullString
 	PrimaryMuscleGroup   sql.NullString
 	SecondaryMuscleGroup sql.NullString
+	VideoUrl             sql.NullString
+	TrackingType         string
 }
 
 func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error) {
@@ -46,6 +111,8 @@ func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams)
 		arg.Description,
 		arg.PrimaryMuscleGroup,
 		arg.SecondaryMuscleGroup,
+		arg.VideoUrl,
+		arg.TrackingType,
 	)
 	var i Exercise
 	err := row.Scan(
@@ -57,6 +124,9 @@ func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
 	)
 	return i, err
 }
@@ -72,7 +142,7 @@ func (q *Queries) DeleteExercise(ctx context.Context, id uuid.UUID) error {
 }
 
 const getAllExercises = `-- name: GetAllExercises :many
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
 `
 
 func (q *Queries) GetAllExercises(ctx context.Context) ([]Exercise, error) {
@@ -93,6 +163,9 @@ func (q *Queries) GetAllExercises(ctx context.Context) ([]Exercise, error) {
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
 		); err != nil {
 			return nil, err
 		}
@@ -110,9 +183,9 @@ func (q *Queries) GetAllExercises(ctx context.Context) ([]Exercise, error) {
 const getAllMuscleGroups = `-- name: GetAllMuscleGroups :many
 SELECT DISTINCT muscle_group
 FROM (
-    SELECT primary_muscle_group AS muscle_group FROM exercises
+    SELECT primary_muscle_group AS muscle_group FROM exercises WHERE user_id IS NULL
     UNION
-    SELECT secondary_muscle_group AS muscle_group FROM exercises
+    SELECT secondary_muscle_group AS muscle_group FROM exercises WHERE user_id IS NULL
 ) AS all_groups
 WHERE muscle_group IS NOT NULL
 ORDER BY muscle_group
@@ -141,8 +214,84 @@ func (q *Queries) GetAllMuscleGroups(ctx context.Context) ([]sql.NullString, err
 	return items, nil
 }
 
+const getAvailableExerciseByID = `-- name: GetAvailableExerciseByID :one
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE
+    id = $1
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $2::uuid)
+`
+
+type GetAvailableExerciseByIDParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetAvailableExerciseByID(ctx context.Context, arg GetAvailableExerciseByIDParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getAvailableExerciseByID, arg.ID, arg.UserID)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PrimaryMuscleGroup,
+		&i.SecondaryMuscleGroup,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
+	)
+	return i, err
+}
+
+const getCatalogExercises = `-- name: GetCatalogExercises :many
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE
+    concat(name, ' ', primary_muscle_group, ' ', secondary_muscle_group)
+    ILIKE '%' || $1::text || '%'
+    AND user_id IS NULL
+ORDER BY retired_at IS NOT NULL, name
+`
+
+func (q *Queries) GetCatalogExercises(ctx context.Context, word string) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getCatalogExercises, word)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Exercise
+	for rows.Next() {
+		var i Exercise
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.PrimaryMuscleGroup,
+			&i.SecondaryMuscleGroup,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getExerciseByID = `-- name: GetExerciseByID :one
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
 WHERE id = $1
 `
 
@@ -158,19 +307,29 @@ func (q *Queries) GetExerciseByID(ctx context.Context, id uuid.UUID) (Exercise,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
 	)
 	return i, err
 }
 
 const getExerciseByKeyword = `-- name: GetExerciseByKeyword :many
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
 WHERE
     concat(name, ' ', primary_muscle_group, ' ', secondary_muscle_group)
     ILIKE '%' || $1::text || '%'
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $2::uuid)
 `
 
-func (q *Queries) GetExerciseByKeyword(ctx context.Context, word string) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, getExerciseByKeyword, word)
+type GetExerciseByKeywordParams struct {
+	Word   string
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetExerciseByKeyword(ctx context.Context, arg GetExerciseByKeywordParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getExerciseByKeyword, arg.Word, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +346,9 @@ func (q *Queries) GetExerciseByKeyword(ctx context.Context, word string) ([]Exer
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
 		); err != nil {
 			return nil, err
 		}
@@ -202,12 +364,22 @@ func (q *Queries) GetExerciseByKeyword(ctx context.Context, word string) ([]Exer
 }
 
 const getExerciseByName = `-- name: GetExerciseByName :one
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
-WHERE name = $1
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE
+    name = $1
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $2::uuid)
+ORDER BY user_id NULLS LAST
+LIMIT 1
 `
 
-func (q *Queries) GetExerciseByName(ctx context.Context, name string) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, getExerciseByName, name)
+type GetExerciseByNameParams struct {
+	Name   string
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetExerciseByName(ctx context.Context, arg GetExerciseByNameParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, getExerciseByName, arg.Name, arg.UserID)
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
@@ -218,17 +390,29 @@ func (q *Queries) GetExerciseByName(ctx context.Context, name string) (Exercise,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
 	)
 	return i, err
 }
 
 const getExercisesByPrimaryMG = `-- name: GetExercisesByPrimaryMG :many
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
-WHERE primary_muscle_group = $1
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE
+    primary_muscle_group = $1
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $2::uuid)
+ORDER BY name
 `
 
-func (q *Queries) GetExercisesByPrimaryMG(ctx context.Context, primaryMuscleGroup sql.NullString) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, getExercisesByPrimaryMG, primaryMuscleGroup)
+type GetExercisesByPrimaryMGParams struct {
+	PrimaryMuscleGroup sql.NullString
+	UserID             uuid.UUID
+}
+
+func (q *Queries) GetExercisesByPrimaryMG(ctx context.Context, arg GetExercisesByPrimaryMGParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getExercisesByPrimaryMG, arg.PrimaryMuscleGroup, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -245,6 +429,9 @@ func (q *Queries) GetExercisesByPrimaryMG(ctx context.Context, primaryMuscleGrou
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
 		); err != nil {
 			return nil, err
 		}
@@ -260,12 +447,21 @@ func (q *Queries) GetExercisesByPrimaryMG(ctx context.Context, primaryMuscleGrou
 }
 
 const getExercisesBySecondaryMG = `-- name: GetExercisesBySecondaryMG :many
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
-WHERE secondary_muscle_group = $1
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE
+    secondary_muscle_group = $1
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $2::uuid)
+ORDER BY name
 `
 
-func (q *Queries) GetExercisesBySecondaryMG(ctx context.Context, secondaryMuscleGroup sql.NullString) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, getExercisesBySecondaryMG, secondaryMuscleGroup)
+type GetExercisesBySecondaryMGParams struct {
+	SecondaryMuscleGroup sql.NullString
+	UserID               uuid.UUID
+}
+
+func (q *Queries) GetExercisesBySecondaryMG(ctx context.Context, arg GetExercisesBySecondaryMGParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getExercisesBySecondaryMG, arg.SecondaryMuscleGroup, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -282,6 +478,9 @@ func (q *Queries) GetExercisesBySecondaryMG(ctx context.Context, secondaryMuscle
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
 		); err != nil {
 			return nil, err
 		}
@@ -299,7 +498,10 @@ func (q *Queries) GetExercisesBySecondaryMG(ctx context.Context, secondaryMuscle
 const getMuscleGroupsWithCount = `-- name: GetMuscleGroupsWithCount :many
 SELECT primary_muscle_group, COUNT(*)::int AS exercise_count
 FROM exercises
-WHERE primary_muscle_group IS NOT NULL
+WHERE
+    primary_muscle_group IS NOT NULL
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $1)
 GROUP BY primary_muscle_group
 ORDER BY primary_muscle_group
 `
@@ -309,8 +511,8 @@ type GetMuscleGroupsWithCountRow struct {
 	ExerciseCount      int32
 }
 
-func (q *Queries) GetMuscleGroupsWithCount(ctx context.Context) ([]GetMuscleGroupsWithCountRow, error) {
-	rows, err := q.db.QueryContext(ctx, getMuscleGroupsWithCount)
+func (q *Queries) GetMuscleGroupsWithCount(ctx context.Context, userID uuid.NullUUID) ([]GetMuscleGroupsWithCountRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMuscleGroupsWithCount, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -333,9 +535,11 @@ func (q *Queries) GetMuscleGroupsWithCount(ctx context.Context) ([]GetMuscleGrou
 }
 
 const getRandomExerciseExcluding = `-- name: GetRandomExerciseExcluding :one
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
 WHERE primary_muscle_group = $1
   AND id != ALL($2::uuid[])
+  AND retired_at IS NULL
+  AND user_id IS NULL
 ORDER BY RANDOM()
 LIMIT 1
 `
@@ -357,13 +561,16 @@ func (q *Queries) GetRandomExerciseExcluding(ctx context.Context, arg GetRandomE
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
 	)
 	return i, err
 }
 
 const getRandomExercisesByMuscleGroup = `-- name: GetRandomExercisesByMuscleGroup :many
-SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url FROM exercises
-WHERE primary_muscle_group = $1
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE primary_muscle_group = $1 AND retired_at IS NULL AND user_id IS NULL
 ORDER BY RANDOM()
 LIMIT $2
 `
@@ -391,6 +598,9 @@ func (q *Queries) GetRandomExercisesByMuscleGroup(ctx context.Context, arg GetRa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
 		); err != nil {
 			return nil, err
 		}
@@ -405,6 +615,165 @@ func (q *Queries) GetRandomExercisesByMuscleGroup(ctx context.Context, arg GetRa
 	return items, nil
 }
 
+const getUserCustomExercises = `-- name: GetUserCustomExercises :many
+SELECT id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type FROM exercises
+WHERE user_id = $1 AND retired_at IS NULL
+ORDER BY name
+`
+
+func (q *Queries) GetUserCustomExercises(ctx context.Context, userID uuid.NullUUID) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, getUserCustomExercises, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Exercise
+	for rows.Next() {
+		var i Exercise
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.PrimaryMuscleGroup,
+			&i.SecondaryMuscleGroup,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.VideoUrl,
+			&i.RetiredAt,
+			&i.UserID,
+			&i.TrackingType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreExercise = `-- name: RestoreExercise :one
+UPDATE exercises
+SET retired_at = NULL, updated_at = now()
+WHERE id = $1 AND user_id IS NULL
+RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
+`
+
+func (q *Queries) RestoreExercise(ctx context.Context, id uuid.UUID) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, restoreExercise, id)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PrimaryMuscleGroup,
+		&i.SecondaryMuscleGroup,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
+	)
+	return i, err
+}
+
+const retireCustomExercise = `-- name: RetireCustomExercise :exec
+UPDATE exercises
+SET retired_at = now(), updated_at = now()
+WHERE id = $1 AND user_id = $2
+`
+
+type RetireCustomExerciseParams struct {
+	ID     uuid.UUID
+	UserID uuid.NullUUID
+}
+
+func (q *Queries) RetireCustomExercise(ctx context.Context, arg RetireCustomExerciseParams) error {
+	_, err := q.db.ExecContext(ctx, retireCustomExercise, arg.ID, arg.UserID)
+	return err
+}
+
+const retireExercise = `-- name: RetireExercise :one
+UPDATE exercises
+SET retired_at = now(), updated_at = now()
+WHERE id = $1 AND user_id IS NULL
+RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
+`
+
+func (q *Queries) RetireExercise(ctx context.Context, id uuid.UUID) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, retireExercise, id)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PrimaryMuscleGroup,
+		&i.SecondaryMuscleGroup,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
+	)
+	return i, err
+}
+
+const updateCustomExercise = `-- name: UpdateCustomExercise :one
+UPDATE exercises
+SET
+    updated_at = now(),
+    name = $1,
+    description = $2,
+    primary_muscle_group = $3,
+    secondary_muscle_group = $4,
+    tracking_type = $5
+WHERE id = $6 AND user_id = $7 AND retired_at IS NULL
+RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
+`
+
+type UpdateCustomExerciseParams struct {
+	Name                 string
+	Description          sql.NullString
+	PrimaryMuscleGroup   sql.NullString
+	SecondaryMuscleGroup sql.NullString
+	TrackingType         string
+	ID                   uuid.UUID
+	UserID               uuid.NullUUID
+}
+
+func (q *Queries) UpdateCustomExercise(ctx context.Context, arg UpdateCustomExerciseParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, updateCustomExercise,
+		arg.Name,
+		arg.Description,
+		arg.PrimaryMuscleGroup,
+		arg.SecondaryMuscleGroup,
+		arg.TrackingType,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Exercise
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.PrimaryMuscleGroup,
+		&i.SecondaryMuscleGroup,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
+	)
+	return i, err
+}
+
 const updateExercise = `-- name: UpdateExercise :one
 UPDATE exercises
 SET
@@ -412,9 +781,11 @@ SET
     name = $1,
     description = $2,
     primary_muscle_group = $3,
-    secondary_muscle_group = $4
-WHERE id = $5
-RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url
+    secondary_muscle_group = $4,
+    video_url = $5,
+    tracking_type = $6
+WHERE id = $7 AND user_id IS NULL
+RETURNING id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, video_url, retired_at, user_id, tracking_type
 `
 
 type UpdateExerciseParams struct {
@@ -422,6 +793,8 @@ type UpdateExerciseParams struct {
 	Description          sql.NullString
 	PrimaryMuscleGroup   sql.NullString
 	SecondaryMuscleGroup sql.NullString
+	VideoUrl             sql.NullString
+	TrackingType         string
 	ID                   uuid.UUID
 }
 
@@ -431,6 +804,8 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 		arg.Description,
 		arg.PrimaryMuscleGroup,
 		arg.SecondaryMuscleGroup,
+		arg.VideoUrl,
+		arg.TrackingType,
 		arg.ID,
 	)
 	var i Exercise
@@ -443,6 +818,9 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.VideoUrl,
+		&i.RetiredAt,
+		&i.UserID,
+		&i.TrackingType,
 	)
 	return i, err
 }
@@ -13,6 +13,26 @@ import (
 	"github.com/google/uuid"
 )
 
+const completeGoal = `-- name: CompleteGoal :exec
+UPDATE goals
+SET
+    updated_at = now(),
+    completion_date = $1,
+    status = 'completed'
+WHERE id = $2 AND user_id = $3 AND status = 'in_progress'
+`
+
+type CompleteGoalParams struct {
+	CompletionDate sql.NullTime
+	ID             uuid.UUID
+	UserID         uuid.UUID
+}
+
+func (q *Queries) CompleteGoal(ctx context.Context, arg CompleteGoalParams) error {
+	_, err := q.db.ExecContext(ctx, completeGoal, arg.CompletionDate, arg.ID, arg.UserID)
+	return err
+}
+
 const createGoal = `-- name: CreateGoal :one
 INSERT INTO goals (
     id,
@@ -22,17 +42,44 @@ INSERT INTO goals (
     description,
     goal_date,
     notes,
-    user_id
-) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, $5)
-RETURNING id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id
+    user_id,
+    target_kind,
+    metric_type_id,
+    exercise_id,
+    target_direction,
+    target_value,
+    start_value
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11
+)
+RETURNING id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason
 `
 
 type CreateGoalParams struct {
-	GoalName    string
-	Description string
-	GoalDate    time.Time
-	Notes       sql.NullString
-	UserID      uuid.UUID
+	GoalName        string
+	Description     string
+	GoalDate        time.Time
+	Notes           sql.NullString
+	UserID          uuid.UUID
+	TargetKind      sql.NullString
+	MetricTypeID    uuid.NullUUID
+	ExerciseID      uuid.NullUUID
+	TargetDirection sql.NullString
+	TargetValue     sql.NullString
+	StartValue      sql.NullString
 }
 
 func (q *Queries) CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, error) {
@@ -42,6 +89,12 @@ func (q *Queries) CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, e
 		arg.GoalDate,
 		arg.Notes,
 		arg.UserID,
+		arg.TargetKind,
+		arg.MetricTypeID,
+		arg.ExerciseID,
+		arg.TargetDirection,
+		arg.TargetValue,
+		arg.StartValue,
 	)
 	var i Goal
 	err := row.Scan(
@@ -55,6 +108,15 @@ func (q *Queries) CreateGoal(ctx context.Context, arg CreateGoalParams) (Goal, e
 		&i.Notes,
 		&i.Status,
 		&i.UserID,
+		&i.TargetKind,
+		&i.MetricTypeID,
+		&i.ExerciseID,
+		&i.TargetDirection,
+		&i.TargetValue,
+		&i.StartValue,
+		&i.PausedAt,
+		&i.AbandonedAt,
+		&i.StatusReason,
 	)
 	return i, err
 }
@@ -69,25 +131,6 @@ func (q *Queries) DeleteAllUserGoals(ctx context.Context, userID uuid.UUID) erro
 	return err
 }
 
-const deleteCompletedGoal = `-- name: DeleteCompletedGoal :one
-WITH deleted AS (
-    DELETE FROM goals WHERE goals.id = $1 AND goals.user_id = $2 RETURNING goals.user_id
-)
-SELECT COUNT(*) FROM goals WHERE goals.user_id = $2 AND goals.status = 'completed'
-`
-
-type DeleteCompletedGoalParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
-}
-
-func (q *Queries) DeleteCompletedGoal(ctx context.Context, arg DeleteCompletedGoalParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, deleteCompletedGoal, arg.ID, arg.UserID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
 const deleteGoal = `-- name: DeleteGoal :exec
 DELETE FROM goals
 WHERE id = $1 AND user_id = $2
@@ -103,27 +146,57 @@ func (q *Queries) DeleteGoal(ctx context.Context, arg DeleteGoalParams) error {
 	return err
 }
 
-const deleteInProgressGoal = `-- name: DeleteInProgressGoal :one
-WITH deleted AS (
-    DELETE FROM goals WHERE goals.id = $1 AND goals.user_id = $2 RETURNING goals.user_id
-)
-SELECT COUNT(*) FROM goals WHERE goals.user_id = $2 AND goals.status = 'in_progress'
+const getAbandonedGoals = `-- name: GetAbandonedGoals :many
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
+WHERE user_id = $1 AND status = 'abandoned'
+ORDER BY abandoned_at DESC
 `
 
-type DeleteInProgressGoalParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
-}
-
-func (q *Queries) DeleteInProgressGoal(ctx context.Context, arg DeleteInProgressGoalParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, deleteInProgressGoal, arg.ID, arg.UserID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) GetAbandonedGoals(ctx context.Context, userID uuid.UUID) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getAbandonedGoals, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GoalName,
+			&i.Description,
+			&i.GoalDate,
+			&i.CompletionDate,
+			&i.Notes,
+			&i.Status,
+			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getAllUserGoals = `-- name: GetAllUserGoals :many
-SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id FROM goals
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
 WHERE user_id = $1
 `
 
@@ -147,6 +220,15 @@ func (q *Queries) GetAllUserGoals(ctx context.Context, userID uuid.UUID) ([]Goal
 			&i.Notes,
 			&i.Status,
 			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
 		); err != nil {
 			return nil, err
 		}
@@ -162,7 +244,7 @@ func (q *Queries) GetAllUserGoals(ctx context.Context, userID uuid.UUID) ([]Goal
 }
 
 const getCompletedGoals = `-- name: GetCompletedGoals :many
-SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id FROM goals
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
 WHERE user_id = $1 AND status = 'completed'
 ORDER BY completion_date DESC
 `
@@ -187,6 +269,15 @@ func (q *Queries) GetCompletedGoals(ctx context.Context, userID uuid.UUID) ([]Go
 			&i.Notes,
 			&i.Status,
 			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
 		); err != nil {
 			return nil, err
 		}
@@ -201,8 +292,45 @@ func (q *Queries) GetCompletedGoals(ctx context.Context, userID uuid.UUID) ([]Go
 	return items, nil
 }
 
+const getGoal = `-- name: GetGoal :one
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
+WHERE id = $1 AND user_id = $2
+`
+
+type GetGoalParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) GetGoal(ctx context.Context, arg GetGoalParams) (Goal, error) {
+	row := q.db.QueryRowContext(ctx, getGoal, arg.ID, arg.UserID)
+	var i Goal
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.GoalName,
+		&i.Description,
+		&i.GoalDate,
+		&i.CompletionDate,
+		&i.Notes,
+		&i.Status,
+		&i.UserID,
+		&i.TargetKind,
+		&i.MetricTypeID,
+		&i.ExerciseID,
+		&i.TargetDirection,
+		&i.TargetValue,
+		&i.StartValue,
+		&i.PausedAt,
+		&i.AbandonedAt,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
 const getInProgressGoals = `-- name: GetInProgressGoals :many
-SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id FROM goals
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
 WHERE user_id = $1 AND status = 'in_progress'
 ORDER BY goal_date ASC
 `
@@ -227,6 +355,118 @@ func (q *Queries) GetInProgressGoals(ctx context.Context, userID uuid.UUID) ([]G
 			&i.Notes,
 			&i.Status,
 			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOverdueGoals = `-- name: GetOverdueGoals :many
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
+WHERE user_id = $1 AND status = 'in_progress' AND goal_date < $2::DATE
+ORDER BY goal_date ASC
+`
+
+type GetOverdueGoalsParams struct {
+	UserID uuid.UUID
+	Today  time.Time
+}
+
+func (q *Queries) GetOverdueGoals(ctx context.Context, arg GetOverdueGoalsParams) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getOverdueGoals, arg.UserID, arg.Today)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GoalName,
+			&i.Description,
+			&i.GoalDate,
+			&i.CompletionDate,
+			&i.Notes,
+			&i.Status,
+			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPausedGoals = `-- name: GetPausedGoals :many
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
+WHERE user_id = $1 AND status = 'paused'
+ORDER BY paused_at DESC
+`
+
+func (q *Queries) GetPausedGoals(ctx context.Context, userID uuid.UUID) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getPausedGoals, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GoalName,
+			&i.Description,
+			&i.GoalDate,
+			&i.CompletionDate,
+			&i.Notes,
+			&i.Status,
+			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
 		); err != nil {
 			return nil, err
 		}
@@ -241,6 +481,88 @@ func (q *Queries) GetInProgressGoals(ctx context.Context, userID uuid.UUID) ([]G
 	return items, nil
 }
 
+const getTrackedGoals = `-- name: GetTrackedGoals :many
+SELECT id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason FROM goals
+WHERE user_id = $1 AND status = 'in_progress' AND target_kind IS NOT NULL
+ORDER BY goal_date ASC
+`
+
+func (q *Queries) GetTrackedGoals(ctx context.Context, userID uuid.UUID) ([]Goal, error) {
+	rows, err := q.db.QueryContext(ctx, getTrackedGoals, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Goal
+	for rows.Next() {
+		var i Goal
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.GoalName,
+			&i.Description,
+			&i.GoalDate,
+			&i.CompletionDate,
+			&i.Notes,
+			&i.Status,
+			&i.UserID,
+			&i.TargetKind,
+			&i.MetricTypeID,
+			&i.ExerciseID,
+			&i.TargetDirection,
+			&i.TargetValue,
+			&i.StartValue,
+			&i.PausedAt,
+			&i.AbandonedAt,
+			&i.StatusReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignGoalExercises = `-- name: ReassignGoalExercises :exec
+UPDATE goals
+SET exercise_id = $1
+WHERE exercise_id = $2
+`
+
+type ReassignGoalExercisesParams struct {
+	TargetID uuid.NullUUID
+	SourceID uuid.NullUUID
+}
+
+func (q *Queries) ReassignGoalExercises(ctx context.Context, arg ReassignGoalExercisesParams) error {
+	_, err := q.db.ExecContext(ctx, reassignGoalExercises, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const setGoalStartValue = `-- name: SetGoalStartValue :exec
+UPDATE goals
+SET start_value = $1
+WHERE id = $2 AND user_id = $3 AND start_value IS NULL
+`
+
+type SetGoalStartValueParams struct {
+	StartValue sql.NullString
+	ID         uuid.UUID
+	UserID     uuid.UUID
+}
+
+func (q *Queries) SetGoalStartValue(ctx context.Context, arg SetGoalStartValueParams) error {
+	_, err := q.db.ExecContext(ctx, setGoalStartValue, arg.StartValue, arg.ID, arg.UserID)
+	return err
+}
+
 const updateGoal = `-- name: UpdateGoal :one
 UPDATE goals
 SET
@@ -250,9 +572,22 @@ SET
     goal_date = $3,
     completion_date = $4,
     notes = $5,
-    status = $6
+    status = $6,
+    target_value = coalesce($9, target_value),
+    -- A goal keeps when it was paused or abandoned until it leaves that status
+    paused_at = CASE
+        WHEN $6 <> 'paused' THEN NULL
+        WHEN status = 'paused' THEN paused_at
+        ELSE now()
+    END,
+    abandoned_at = CASE
+        WHEN $6 <> 'abandoned' THEN NULL
+        WHEN status = 'abandoned' THEN abandoned_at
+        ELSE now()
+    END,
+    status_reason = $10
 WHERE id = $7 AND user_id = $8
-RETURNING id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id
+RETURNING id, created_at, updated_at, goal_name, description, goal_date, completion_date, notes, status, user_id, target_kind, metric_type_id, exercise_id, target_direction, target_value, start_value, paused_at, abandoned_at, status_reason
 `
 
 type UpdateGoalParams struct {
@@ -264,6 +599,8 @@ type UpdateGoalParams struct {
 	Status         string
 	ID             uuid.UUID
 	UserID         uuid.UUID
+	TargetValue    sql.NullString
+	StatusReason   sql.NullString
 }
 
 func (q *Queries) UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, error) {
@@ -276,6 +613,8 @@ func (q *Queries) UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, e
 		arg.Status,
 		arg.ID,
 		arg.UserID,
+		arg.TargetValue,
+		arg.StatusReason,
 	)
 	var i Goal
 	err := row.Scan(
@@ -289,6 +628,15 @@ func (q *Queries) UpdateGoal(ctx context.Context, arg UpdateGoalParams) (Goal, e
 		&i.Notes,
 		&i.Status,
 		&i.UserID,
+		&i.TargetKind,
+		&i.MetricTypeID,
+		&i.ExerciseID,
+		&i.TargetDirection,
+		&i.TargetValue,
+		&i.StartValue,
+		&i.PausedAt,
+		&i.AbandonedAt,
+		&i.StatusReason,
 	)
 	return i, err
 }
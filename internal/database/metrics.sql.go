@@ -7,204 +7,188 @@ package database
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/google/uuid"
 )
 
-const addBodyFatPerc = `-- name: AddBodyFatPerc :one
-INSERT INTO body_fat_percents (
+const addMeasurement = `-- name: AddMeasurement :one
+INSERT INTO measurements (
     id,
     created_at,
     updated_at,
     user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
-RETURNING id, user_id, measurement, created_at, updated_at
+    metric_type_id,
+    value,
+    secondary_value,
+    measured_at
+) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, coalesce($5, now()))
+RETURNING id, user_id, metric_type_id, value, secondary_value, created_at, updated_at, measured_at
 `
 
-type AddBodyFatPercParams struct {
-	UserID      uuid.UUID
-	Measurement string
+type AddMeasurementParams struct {
+	UserID         uuid.UUID
+	MetricTypeID   uuid.UUID
+	Value          string
+	SecondaryValue sql.NullString
+	MeasuredAt     sql.NullTime
 }
 
-func (q *Queries) AddBodyFatPerc(ctx context.Context, arg AddBodyFatPercParams) (BodyFatPercent, error) {
-	row := q.db.QueryRowContext(ctx, addBodyFatPerc, arg.UserID, arg.Measurement)
-	var i BodyFatPercent
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Measurement,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+func (q *Queries) AddMeasurement(ctx context.Context, arg AddMeasurementParams) (Measurement, error) {
+	row := q.db.QueryRowContext(ctx, addMeasurement,
+		arg.UserID,
+		arg.MetricTypeID,
+		arg.Value,
+		arg.SecondaryValue,
+		arg.MeasuredAt,
 	)
-	return i, err
-}
-
-const addBodyWeight = `-- name: AddBodyWeight :one
-INSERT INTO body_weights (
-    id,
-    created_at,
-    updated_at,
-    user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
-RETURNING id, user_id, measurement, created_at, updated_at
-`
-
-type AddBodyWeightParams struct {
-	UserID      uuid.UUID
-	Measurement string
-}
-
-func (q *Queries) AddBodyWeight(ctx context.Context, arg AddBodyWeightParams) (BodyWeight, error) {
-	row := q.db.QueryRowContext(ctx, addBodyWeight, arg.UserID, arg.Measurement)
-	var i BodyWeight
+	var i Measurement
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Measurement,
+		&i.MetricTypeID,
+		&i.Value,
+		&i.SecondaryValue,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MeasuredAt,
 	)
 	return i, err
 }
 
-const addMuscleMass = `-- name: AddMuscleMass :one
-INSERT INTO muscle_masses (
+const createMetricType = `-- name: CreateMetricType :one
+INSERT INTO metric_types (
     id,
-    created_at,
-    updated_at,
     user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
-RETURNING id, user_id, measurement, created_at, updated_at
+    slug,
+    name,
+    kind,
+    unit,
+    decimals,
+    min_value,
+    max_value,
+    created_at,
+    updated_at
+) VALUES (gen_random_uuid(), $1, $2, $3, 'custom', $4, $5, $6, $7, now(), now())
+RETURNING id, user_id, slug, name, kind, unit, decimals, min_value, max_value, secondary_name, sort_order, created_at, updated_at
 `
 
-type AddMuscleMassParams struct {
-	UserID      uuid.UUID
-	Measurement string
+type CreateMetricTypeParams struct {
+	UserID   uuid.NullUUID
+	Slug     string
+	Name     string
+	Unit     string
+	Decimals int32
+	MinValue string
+	MaxValue string
 }
 
-func (q *Queries) AddMuscleMass(ctx context.Context, arg AddMuscleMassParams) (MuscleMass, error) {
-	row := q.db.QueryRowContext(ctx, addMuscleMass, arg.UserID, arg.Measurement)
-	var i MuscleMass
+func (q *Queries) CreateMetricType(ctx context.Context, arg CreateMetricTypeParams) (MetricType, error) {
+	row := q.db.QueryRowContext(ctx, createMetricType,
+		arg.UserID,
+		arg.Slug,
+		arg.Name,
+		arg.Unit,
+		arg.Decimals,
+		arg.MinValue,
+		arg.MaxValue,
+	)
+	var i MetricType
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Measurement,
+		&i.Slug,
+		&i.Name,
+		&i.Kind,
+		&i.Unit,
+		&i.Decimals,
+		&i.MinValue,
+		&i.MaxValue,
+		&i.SecondaryName,
+		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const deleteAllBodyFatPercs = `-- name: DeleteAllBodyFatPercs :exec
-DELETE FROM body_fat_percents
-WHERE user_id = $1
+const deleteAllMeasurements = `-- name: DeleteAllMeasurements :exec
+DELETE FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2
 `
 
-func (q *Queries) DeleteAllBodyFatPercs(ctx context.Context, userID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteAllBodyFatPercs, userID)
-	return err
+type DeleteAllMeasurementsParams struct {
+	UserID       uuid.UUID
+	MetricTypeID uuid.UUID
 }
 
-const deleteAllBodyWeights = `-- name: DeleteAllBodyWeights :exec
-DELETE FROM body_weights
-WHERE user_id = $1
-`
-
-func (q *Queries) DeleteAllBodyWeights(ctx context.Context, userID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteAllBodyWeights, userID)
+func (q *Queries) DeleteAllMeasurements(ctx context.Context, arg DeleteAllMeasurementsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteAllMeasurements, arg.UserID, arg.MetricTypeID)
 	return err
 }
 
-const deleteAllMuscleMasses = `-- name: DeleteAllMuscleMasses :exec
-DELETE FROM muscle_masses
-WHERE user_id = $1
-`
-
-func (q *Queries) DeleteAllMuscleMasses(ctx context.Context, userID uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deleteAllMuscleMasses, userID)
-	return err
-}
-
-const deleteBodyFatPerc = `-- name: DeleteBodyFatPerc :one
+const deleteMeasurement = `-- name: DeleteMeasurement :one
 WITH deleted AS (
-    DELETE FROM body_fat_percents WHERE body_fat_percents.id = $1 AND body_fat_percents.user_id = $2 RETURNING body_fat_percents.user_id
+    DELETE FROM measurements
+    WHERE measurements.id = $1 AND measurements.metric_type_id = $2 AND measurements.user_id = $3
+    RETURNING measurements.user_id
 )
-SELECT COUNT(*) FROM body_fat_percents WHERE body_fat_percents.user_id = $2
+SELECT COUNT(*) FROM measurements
+WHERE measurements.metric_type_id = $2 AND measurements.user_id = $3
 `
 
-type DeleteBodyFatPercParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
+type DeleteMeasurementParams struct {
+	ID           uuid.UUID
+	MetricTypeID uuid.UUID
+	UserID       uuid.UUID
 }
 
-func (q *Queries) DeleteBodyFatPerc(ctx context.Context, arg DeleteBodyFatPercParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, deleteBodyFatPerc, arg.ID, arg.UserID)
+func (q *Queries) DeleteMeasurement(ctx context.Context, arg DeleteMeasurementParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, deleteMeasurement, arg.ID, arg.MetricTypeID, arg.UserID)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
-const deleteBodyWeight = `-- name: DeleteBodyWeight :one
-WITH deleted AS (
-    DELETE FROM body_weights WHERE body_weights.id = $1 AND body_weights.user_id = $2 RETURNING body_weights.user_id
-)
-SELECT COUNT(*) FROM body_weights WHERE body_weights.user_id = $2
+const deleteMetricType = `-- name: DeleteMetricType :exec
+DELETE FROM metric_types
+WHERE slug = $1 AND user_id = $2
 `
 
-type DeleteBodyWeightParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
-}
-
-func (q *Queries) DeleteBodyWeight(ctx context.Context, arg DeleteBodyWeightParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, deleteBodyWeight, arg.ID, arg.UserID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+type DeleteMetricTypeParams struct {
+	Slug   string
+	UserID uuid.NullUUID
 }
 
-const deleteMuscleMass = `-- name: DeleteMuscleMass :one
-WITH deleted AS (
-    DELETE FROM muscle_masses WHERE muscle_masses.id = $1 AND muscle_masses.user_id = $2 RETURNING muscle_masses.user_id
-)
-SELECT COUNT(*) FROM muscle_masses WHERE muscle_masses.user_id = $2
-`
-
-type DeleteMuscleMassParams struct {
-	ID     uuid.UUID
-	UserID uuid.UUID
-}
-
-func (q *Queries) DeleteMuscleMass(ctx context.Context, arg DeleteMuscleMassParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, deleteMuscleMass, arg.ID, arg.UserID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+func (q *Queries) DeleteMetricType(ctx context.Context, arg DeleteMetricTypeParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMetricType, arg.Slug, arg.UserID)
+	return err
 }
 
-const getAllBodyFatPercs = `-- name: GetAllBodyFatPercs :many
-SELECT id, user_id, measurement, created_at, updated_at FROM body_fat_percents
+const getAllMeasurements = `-- name: GetAllMeasurements :many
+SELECT id, user_id, metric_type_id, value, secondary_value, created_at, updated_at, measured_at FROM measurements
 WHERE user_id = $1
+ORDER BY measured_at, created_at
 `
 
-func (q *Queries) GetAllBodyFatPercs(ctx context.Context, userID uuid.UUID) ([]BodyFatPercent, error) {
-	rows, err := q.db.QueryContext(ctx, getAllBodyFatPercs, userID)
+func (q *Queries) GetAllMeasurements(ctx context.Context, userID uuid.UUID) ([]Measurement, error) {
+	rows, err := q.db.QueryContext(ctx, getAllMeasurements, userID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []BodyFatPercent
+	var items []Measurement
 	for rows.Next() {
-		var i BodyFatPercent
+		var i Measurement
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
-			&i.Measurement,
+			&i.MetricTypeID,
+			&i.Value,
+			&i.SecondaryValue,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MeasuredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -219,60 +203,54 @@ func (q *Queries) GetAllBodyFatPercs(ctx context.Context, userID uuid.UUID) ([]B
 	return items, nil
 }
 
-const getAllBodyWeights = `-- name: GetAllBodyWeights :many
-SELECT id, user_id, measurement, created_at, updated_at FROM body_weights
-WHERE user_id = $1
+const getLatestMeasurementValue = `-- name: GetLatestMeasurementValue :one
+SELECT value FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2
+ORDER BY measured_at DESC, created_at DESC
+LIMIT 1
 `
 
-func (q *Queries) GetAllBodyWeights(ctx context.Context, userID uuid.UUID) ([]BodyWeight, error) {
-	rows, err := q.db.QueryContext(ctx, getAllBodyWeights, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []BodyWeight
-	for rows.Next() {
-		var i BodyWeight
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.Measurement,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+type GetLatestMeasurementValueParams struct {
+	UserID       uuid.UUID
+	MetricTypeID uuid.UUID
 }
 
-const getAllMuscleMasses = `-- name: GetAllMuscleMasses :many
-SELECT id, user_id, measurement, created_at, updated_at FROM muscle_masses
-WHERE user_id = $1
+func (q *Queries) GetLatestMeasurementValue(ctx context.Context, arg GetLatestMeasurementValueParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, getLatestMeasurementValue, arg.UserID, arg.MetricTypeID)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
+const getMeasurements = `-- name: GetMeasurements :many
+SELECT id, user_id, metric_type_id, value, secondary_value, created_at, updated_at, measured_at FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2
+ORDER BY measured_at, created_at
 `
 
-func (q *Queries) GetAllMuscleMasses(ctx context.Context, userID uuid.UUID) ([]MuscleMass, error) {
-	rows, err := q.db.QueryContext(ctx, getAllMuscleMasses, userID)
+type GetMeasurementsParams struct {
+	UserID       uuid.UUID
+	MetricTypeID uuid.UUID
+}
+
+func (q *Queries) GetMeasurements(ctx context.Context, arg GetMeasurementsParams) ([]Measurement, error) {
+	rows, err := q.db.QueryContext(ctx, getMeasurements, arg.UserID, arg.MetricTypeID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []MuscleMass
+	var items []Measurement
 	for rows.Next() {
-		var i MuscleMass
+		var i Measurement
 		if err := rows.Scan(
 			&i.ID,
 			&i.UserID,
-			&i.Measurement,
+			&i.MetricTypeID,
+			&i.Value,
+			&i.SecondaryValue,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.MeasuredAt,
 		); err != nil {
 			return nil, err
 		}
@@ -287,86 +265,145 @@ func (q *Queries) GetAllMuscleMasses(ctx context.Context, userID uuid.UUID) ([]M
 	return items, nil
 }
 
-const updateBodyFatPerc = `-- name: UpdateBodyFatPerc :one
-UPDATE body_fat_percents
-SET
-    measurement = $1,
-    updated_at = now()
-WHERE id = $2 AND user_id = $3
-RETURNING id, user_id, measurement, created_at, updated_at
+const getMetricTypeByID = `-- name: GetMetricTypeByID :one
+SELECT id, user_id, slug, name, kind, unit, decimals, min_value, max_value, secondary_name, sort_order, created_at, updated_at FROM metric_types
+WHERE id = $1
 `
 
-type UpdateBodyFatPercParams struct {
-	Measurement string
-	ID          uuid.UUID
-	UserID      uuid.UUID
-}
-
-func (q *Queries) UpdateBodyFatPerc(ctx context.Context, arg UpdateBodyFatPercParams) (BodyFatPercent, error) {
-	row := q.db.QueryRowContext(ctx, updateBodyFatPerc, arg.Measurement, arg.ID, arg.UserID)
-	var i BodyFatPercent
+func (q *Queries) GetMetricTypeByID(ctx context.Context, id uuid.UUID) (MetricType, error) {
+	row := q.db.QueryRowContext(ctx, getMetricTypeByID, id)
+	var i MetricType
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Measurement,
+		&i.Slug,
+		&i.Name,
+		&i.Kind,
+		&i.Unit,
+		&i.Decimals,
+		&i.MinValue,
+		&i.MaxValue,
+		&i.SecondaryName,
+		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const updateBodyWeight = `-- name: UpdateBodyWeight :one
-UPDATE body_weights
-SET
-    measurement = $1,
-    updated_at = now()
-WHERE id = $2 AND user_id = $3
-RETURNING id, user_id, measurement, created_at, updated_at
+const getMetricTypeBySlug = `-- name: GetMetricTypeBySlug :one
+SELECT id, user_id, slug, name, kind, unit, decimals, min_value, max_value, secondary_name, sort_order, created_at, updated_at FROM metric_types
+WHERE slug = $1 AND (user_id IS NULL OR user_id = $2)
 `
 
-type UpdateBodyWeightParams struct {
-	Measurement string
-	ID          uuid.UUID
-	UserID      uuid.UUID
+type GetMetricTypeBySlugParams struct {
+	Slug   string
+	UserID uuid.NullUUID
 }
 
-func (q *Queries) UpdateBodyWeight(ctx context.Context, arg UpdateBodyWeightParams) (BodyWeight, error) {
-	row := q.db.QueryRowContext(ctx, updateBodyWeight, arg.Measurement, arg.ID, arg.UserID)
-	var i BodyWeight
+func (q *Queries) GetMetricTypeBySlug(ctx context.Context, arg GetMetricTypeBySlugParams) (MetricType, error) {
+	row := q.db.QueryRowContext(ctx, getMetricTypeBySlug, arg.Slug, arg.UserID)
+	var i MetricType
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Measurement,
+		&i.Slug,
+		&i.Name,
+		&i.Kind,
+		&i.Unit,
+		&i.Decimals,
+		&i.MinValue,
+		&i.MaxValue,
+		&i.SecondaryName,
+		&i.SortOrder,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const updateMuscleMass = `-- name: UpdateMuscleMass :one
-UPDATE muscle_masses
+const getMetricTypes = `-- name: GetMetricTypes :many
+SELECT id, user_id, slug, name, kind, unit, decimals, min_value, max_value, secondary_name, sort_order, created_at, updated_at FROM metric_types
+WHERE user_id IS NULL OR user_id = $1
+ORDER BY user_id NULLS FIRST, sort_order, name
+`
+
+func (q *Queries) GetMetricTypes(ctx context.Context, userID uuid.NullUUID) ([]MetricType, error) {
+	rows, err := q.db.QueryContext(ctx, getMetricTypes, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MetricType
+	for rows.Next() {
+		var i MetricType
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Slug,
+			&i.Name,
+			&i.Kind,
+			&i.Unit,
+			&i.Decimals,
+			&i.MinValue,
+			&i.MaxValue,
+			&i.SecondaryName,
+			&i.SortOrder,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateMeasurement = `-- name: UpdateMeasurement :one
+UPDATE measurements
 SET
-    measurement = $1,
+    value = $1,
+    secondary_value = $2,
+    measured_at = coalesce($6, measured_at),
     updated_at = now()
-WHERE id = $2 AND user_id = $3
-RETURNING id, user_id, measurement, created_at, updated_at
+WHERE id = $3 AND metric_type_id = $4 AND user_id = $5
+RETURNING id, user_id, metric_type_id, value, secondary_value, created_at, updated_at, measured_at
 `
 
-type UpdateMuscleMassParams struct {
-	Measurement string
-	ID          uuid.UUID
-	UserID      uuid.UUID
+type UpdateMeasurementParams struct {
+	Value          string
+	SecondaryValue sql.NullString
+	ID             uuid.UUID
+	MetricTypeID   uuid.UUID
+	UserID         uuid.UUID
+	MeasuredAt     sql.NullTime
 }
 
-func (q *Queries) UpdateMuscleMass(ctx context.Context, arg UpdateMuscleMassParams) (MuscleMass, error) {
-	row := q.db.QueryRowContext(ctx, updateMuscleMass, arg.Measurement, arg.ID, arg.UserID)
-	var i MuscleMass
+func (q *Queries) UpdateMeasurement(ctx context.Context, arg UpdateMeasurementParams) (Measurement, error) {
+	row := q.db.QueryRowContext(ctx, updateMeasurement,
+		arg.Value,
+		arg.SecondaryValue,
+		arg.ID,
+		arg.MetricTypeID,
+		arg.UserID,
+		arg.MeasuredAt,
+	)
+	var i Measurement
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
-		&i.Measurement,
+		&i.MetricTypeID,
+		&i.Value,
+		&i.SecondaryValue,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.MeasuredAt,
 	)
 	return i, err
 }
@@ -22,20 +22,13 @@ type AuthProvider struct {
 	UpdatedAt      time.Time
 }
 
-type BodyFatPercent struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	Measurement string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-}
-
-type BodyWeight struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	Measurement string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+type EmailVerificationToken struct {
+	Token     string
+	UserID    uuid.UUID
+	Email     string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
 }
 
 type Exercise struct {
@@ -47,29 +40,167 @@ type Exercise struct {
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
 	VideoUrl             sql.NullString
+	RetiredAt            sql.NullTime
+	UserID               uuid.NullUUID
+	TrackingType         string
 }
 
 type Goal struct {
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	GoalName        string
+	Description     string
+	GoalDate        time.Time
+	CompletionDate  sql.NullTime
+	Notes           sql.NullString
+	Status          string
+	UserID          uuid.UUID
+	TargetKind      sql.NullString
+	MetricTypeID    uuid.NullUUID
+	ExerciseID      uuid.NullUUID
+	TargetDirection sql.NullString
+	TargetValue     sql.NullString
+	StartValue      sql.NullString
+	PausedAt        sql.NullTime
+	AbandonedAt     sql.NullTime
+	StatusReason    sql.NullString
+}
+
+type GoalCheckin struct {
+	ID          uuid.UUID
+	GoalID      uuid.UUID
+	UserID      uuid.UUID
+	CheckedInOn time.Time
+	Note        string
+	CreatedAt   time.Time
+}
+
+type GoalMilestone struct {
+	ID          uuid.UUID
+	GoalID      uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	TargetDate  time.Time
+	CompletedAt sql.NullTime
+	SortOrder   int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type LoginChallenge struct {
+	Token     string
+	UserID    uuid.UUID
+	Attempts  int32
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type Measurement struct {
 	ID             uuid.UUID
+	UserID         uuid.UUID
+	MetricTypeID   uuid.UUID
+	Value          string
+	SecondaryValue sql.NullString
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
-	GoalName       string
-	Description    string
-	GoalDate       time.Time
-	CompletionDate sql.NullTime
-	Notes          sql.NullString
-	Status         string
-	UserID         uuid.UUID
+	MeasuredAt     time.Time
+}
+
+type MetricType struct {
+	ID            uuid.UUID
+	UserID        uuid.NullUUID
+	Slug          string
+	Name          string
+	Kind          string
+	Unit          string
+	Decimals      int32
+	MinValue      string
+	MaxValue      string
+	SecondaryName sql.NullString
+	SortOrder     int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
-type MuscleMass struct {
+type Passkey struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	CredentialID []byte
+	Name         string
+	Credential   json.RawMessage
+	LastUsedAt   sql.NullTime
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type PasswordResetToken struct {
+	Token     string
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type PersonalRecord struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	ExerciseID        uuid.UUID
+	WorkoutExerciseID uuid.UUID
+	RecordType        string
+	Value             float64
+	Reps              int32
+	WeightLbs         int32
+	AchievedAt        time.Time
+	CreatedAt         time.Time
+}
+
+type Program struct {
 	ID          uuid.UUID
 	UserID      uuid.UUID
-	Measurement string
+	Name        string
+	Description sql.NullString
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+type ProgramDay struct {
+	ID              uuid.UUID
+	ProgramID       uuid.UUID
+	DayNumber       int32
+	Title           string
+	TemplateID      uuid.NullUUID
+	DurationMinutes int32
+	CreatedAt       time.Time
+}
+
+type ProgramDayExercise struct {
+	ID           uuid.UUID
+	ProgramDayID uuid.UUID
+	ExerciseID   uuid.UUID
+	RepsPerSet   []int32
+	WeightsLbs   []int32
+	SortOrder    int32
+	CreatedAt    time.Time
+}
+
+type ProgramEnrollment struct {
+	ID        uuid.UUID
+	ProgramID uuid.UUID
+	UserID    uuid.UUID
+	StartDate time.Time
+	CreatedAt time.Time
+}
+
+type ProgramWeek struct {
+	ID          uuid.UUID
+	ProgramID   uuid.UUID
+	WeekNumber  int32
+	Progression string
+	Amount      int32
+}
+
 type RefreshToken struct {
 	Token     string
 	CreatedAt time.Time
@@ -79,18 +210,46 @@ type RefreshToken struct {
 	RevokedAt sql.NullTime
 }
 
+type TotpFactor struct {
+	UserID       uuid.UUID
+	Secret       string
+	ConfirmedAt  sql.NullTime
+	LastUsedStep int64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type TotpRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
 type User struct {
-	ID             uuid.UUID
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
-	FirstName      string
-	MiddleName     sql.NullString
-	LastName       string
-	Email          string
-	HashedPassword sql.NullString
-	ProfileImage   sql.NullString
-	Preferences    pqtype.NullRawMessage
-	IsAdmin        bool
+	ID              uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	FirstName       string
+	MiddleName      sql.NullString
+	LastName        string
+	Email           string
+	HashedPassword  sql.NullString
+	ProfileImage    sql.NullString
+	Preferences     pqtype.NullRawMessage
+	IsAdmin         bool
+	DisabledAt      sql.NullTime
+	EmailVerifiedAt sql.NullTime
+}
+
+type WebauthnSession struct {
+	Token     string
+	UserID    uuid.NullUUID
+	Ceremony  string
+	Data      json.RawMessage
+	CreatedAt time.Time
+	ExpiresAt time.Time
 }
 
 type Workout struct {
@@ -103,6 +262,41 @@ type Workout struct {
 	DateCompleted   sql.NullTime
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+	SeriesID        uuid.NullUUID
+	StartedAt       sql.NullTime
+}
+
+type WorkoutExerciseGroup struct {
+	ID          uuid.UUID
+	WorkoutID   uuid.UUID
+	Kind        string
+	Label       string
+	Rounds      int32
+	RestSeconds int32
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type WorkoutSeries struct {
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	Weekdays     []int32
+	IntervalDays int32
+	Weeks        int32
+	StartDate    time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type WorkoutSetDetail struct {
+	WorkoutExerciseID uuid.UUID
+	SetNumber         int32
+	Rpe               sql.NullFloat64
+	Rir               sql.NullInt32
+	Tempo             sql.NullString
+	Notes             sql.NullString
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
 }
 
 type WorkoutTemplate struct {
@@ -116,17 +310,24 @@ type WorkoutTemplate struct {
 }
 
 type WorkoutsExercise struct {
-	ID                  uuid.UUID
-	WorkoutID           uuid.UUID
-	ExerciseID          uuid.UUID
-	SetsPlanned         int32
-	RepsPerSetPlanned   []int32
-	SetsCompleted       int32
-	RepsPerSetCompleted []int32
-	WeightsPlannedLbs   []int32
-	WeightsCompletedLbs []int32
-	DateCompleted       sql.NullTime
-	UpdatedAt           time.Time
-	CreatedAt           time.Time
-	SortOrder           int32
+	ID                        uuid.UUID
+	WorkoutID                 uuid.UUID
+	ExerciseID                uuid.UUID
+	SetsPlanned               int32
+	RepsPerSetPlanned         []int32
+	SetsCompleted             int32
+	RepsPerSetCompleted       []int32
+	WeightsPlannedLbs         []int32
+	WeightsCompletedLbs       []int32
+	DateCompleted             sql.NullTime
+	UpdatedAt                 time.Time
+	CreatedAt                 time.Time
+	SortOrder                 int32
+	Suggestion                pqtype.NullRawMessage
+	GroupID                   uuid.NullUUID
+	DurationsPlannedSeconds   []int32
+	DurationsCompletedSeconds []int32
+	DistancesPlannedMeters    []int32
+	DistancesCompletedMeters  []int32
+	HeartRatesCompleted       []int32
 }
@@ -10,8 +10,37 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
+const claimUnverifiedUser = `-- name: ClaimUnverifiedUser :one
+UPDATE users
+SET hashed_password = NULL, email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND email_verified_at IS NULL
+RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at
+`
+
+func (q *Queries) ClaimUnverifiedUser(ctx context.Context, id uuid.UUID) (User, error) {
+	row := q.db.QueryRowContext(ctx, claimUnverifiedUser, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FirstName,
+		&i.MiddleName,
+		&i.LastName,
+		&i.Email,
+		&i.HashedPassword,
+		&i.ProfileImage,
+		&i.Preferences,
+		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
 const createOAuthUser = `-- name: CreateOAuthUser :one
 INSERT INTO users (
     id,
@@ -20,9 +49,10 @@ INSERT INTO users (
     first_name,
     last_name,
     email,
-    profile_image
-) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4)
-RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin
+    profile_image,
+    email_verified_at
+) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, now())
+RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at
 `
 
 type CreateOAuthUserParams struct {
@@ -52,6 +82,8 @@ func (q *Queries) CreateOAuthUser(ctx context.Context, arg CreateOAuthUserParams
 		&i.ProfileImage,
 		&i.Preferences,
 		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -67,7 +99,7 @@ INSERT INTO users (
     email,
     hashed_password
 ) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, $5)
-RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin
+RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at
 `
 
 type CreateUserParams struct {
@@ -99,6 +131,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.ProfileImage,
 		&i.Preferences,
 		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
@@ -113,8 +147,73 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const disableUser = `-- name: DisableUser :exec
+UPDATE users
+SET disabled_at = NOW(), updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) DisableUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, disableUser, id)
+	return err
+}
+
+const enableUser = `-- name: EnableUser :exec
+UPDATE users
+SET disabled_at = NULL, updated_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) EnableUser(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, enableUser, id)
+	return err
+}
+
+const getRecentUsers = `-- name: GetRecentUsers :many
+SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at FROM users
+ORDER BY created_at DESC
+LIMIT $1
+`
+
+func (q *Queries) GetRecentUsers(ctx context.Context, limit int32) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, getRecentUsers, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Email,
+			&i.HashedPassword,
+			&i.ProfileImage,
+			&i.Preferences,
+			&i.IsAdmin,
+			&i.DisabledAt,
+			&i.EmailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUser = `-- name: GetUser :one
-SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin FROM users
+SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at FROM users
 WHERE email = $1
 `
 
@@ -133,12 +232,14 @@ func (q *Queries) GetUser(ctx context.Context, email string) (User, error) {
 		&i.ProfileImage,
 		&i.Preferences,
 		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin FROM users
+SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at FROM users
 WHERE id = $1
 `
 
@@ -157,10 +258,104 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.ProfileImage,
 		&i.Preferences,
 		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
+const getUserCounts = `-- name: GetUserCounts :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE is_admin) AS admins,
+    COUNT(*) FILTER (WHERE disabled_at IS NOT NULL) AS disabled,
+    COUNT(*) FILTER (WHERE created_at > NOW() - INTERVAL '30 days') AS recent
+FROM users
+`
+
+type GetUserCountsRow struct {
+	Total    int64
+	Admins   int64
+	Disabled int64
+	Recent   int64
+}
+
+func (q *Queries) GetUserCounts(ctx context.Context) (GetUserCountsRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserCounts)
+	var i GetUserCountsRow
+	err := row.Scan(
+		&i.Total,
+		&i.Admins,
+		&i.Disabled,
+		&i.Recent,
+	)
+	return i, err
+}
+
+const getUserSettings = `-- name: GetUserSettings :one
+SELECT preferences FROM users
+WHERE id = $1
+`
+
+func (q *Queries) GetUserSettings(ctx context.Context, id uuid.UUID) (pqtype.NullRawMessage, error) {
+	row := q.db.QueryRowContext(ctx, getUserSettings, id)
+	var preferences pqtype.NullRawMessage
+	err := row.Scan(&preferences)
+	return preferences, err
+}
+
+const searchUsers = `-- name: SearchUsers :many
+SELECT id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at FROM users
+WHERE
+    email ILIKE '%' || $1::text || '%' ESCAPE '\'
+    OR first_name ILIKE '%' || $1::text || '%' ESCAPE '\'
+    OR last_name ILIKE '%' || $1::text || '%' ESCAPE '\'
+ORDER BY created_at DESC
+LIMIT $2
+`
+
+type SearchUsersParams struct {
+	Query      string
+	MaxResults int32
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers, arg.Query, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.FirstName,
+			&i.MiddleName,
+			&i.LastName,
+			&i.Email,
+			&i.HashedPassword,
+			&i.ProfileImage,
+			&i.Preferences,
+			&i.IsAdmin,
+			&i.DisabledAt,
+			&i.EmailVerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
@@ -168,7 +363,7 @@ SET
     email = coalesce($3, email),
     updated_at = now()
 WHERE id = $1
-RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin
+RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at
 `
 
 type UpdateUserParams struct {
@@ -192,6 +387,57 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.ProfileImage,
 		&i.Preferences,
 		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
+	)
+	return i, err
+}
+
+const updateUserSettings = `-- name: UpdateUserSettings :exec
+UPDATE users
+SET preferences = $1, updated_at = NOW()
+WHERE id = $2
+`
+
+type UpdateUserSettingsParams struct {
+	Preferences pqtype.NullRawMessage
+	ID          uuid.UUID
+}
+
+func (q *Queries) UpdateUserSettings(ctx context.Context, arg UpdateUserSettingsParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserSettings, arg.Preferences, arg.ID)
+	return err
+}
+
+const verifyUserEmail = `-- name: VerifyUserEmail :one
+UPDATE users
+SET email = $2, email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING id, created_at, updated_at, first_name, middle_name, last_name, email, hashed_password, profile_image, preferences, is_admin, disabled_at, email_verified_at
+`
+
+type VerifyUserEmailParams struct {
+	ID    uuid.UUID
+	Email string
+}
+
+func (q *Queries) VerifyUserEmail(ctx context.Context, arg VerifyUserEmailParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, verifyUserEmail, arg.ID, arg.Email)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.FirstName,
+		&i.MiddleName,
+		&i.LastName,
+		&i.Email,
+		&i.HashedPassword,
+		&i.ProfileImage,
+		&i.Preferences,
+		&i.IsAdmin,
+		&i.DisabledAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
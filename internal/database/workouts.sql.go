@@ -13,6 +13,94 @@ import (
 	"github.com/google/uuid"
 )
 
+const countUserWorkouts = `-- name: CountUserWorkouts :one
+SELECT COUNT(*) FROM workouts
+WHERE user_id = $1
+`
+
+func (q *Queries) CountUserWorkouts(ctx context.Context, userID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUserWorkouts, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countWorkoutsCompletedSince = `-- name: CountWorkoutsCompletedSince :one
+SELECT COUNT(*) FROM workouts
+WHERE user_id = $1 AND date_completed >= $2
+`
+
+type CountWorkoutsCompletedSinceParams struct {
+	UserID        uuid.UUID
+	DateCompleted sql.NullTime
+}
+
+func (q *Queries) CountWorkoutsCompletedSince(ctx context.Context, arg CountWorkoutsCompletedSinceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countWorkoutsCompletedSince, arg.UserID, arg.DateCompleted)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createSeriesWorkout = `-- name: CreateSeriesWorkout :one
+INSERT INTO workouts (
+    id,
+    created_at,
+    updated_at,
+    user_id,
+    title,
+    description,
+    duration_minutes,
+    planned_date,
+    series_id
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at
+`
+
+type CreateSeriesWorkoutParams struct {
+	UserID          uuid.UUID
+	Title           string
+	Description     sql.NullString
+	DurationMinutes int32
+	PlannedDate     time.Time
+	SeriesID        uuid.NullUUID
+}
+
+func (q *Queries) CreateSeriesWorkout(ctx context.Context, arg CreateSeriesWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, createSeriesWorkout,
+		arg.UserID,
+		arg.Title,
+		arg.Description,
+		arg.DurationMinutes,
+		arg.PlannedDate,
+		arg.SeriesID,
+	)
+	var i Workout
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.DurationMinutes,
+		&i.PlannedDate,
+		&i.DateCompleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
+	)
+	return i, err
+}
+
 const createWorkout = `-- name: CreateWorkout :one
 INSERT INTO workouts (
     id,
@@ -32,7 +120,7 @@ INSERT INTO workouts (
     $3,
     $4,
     $5
-) RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at
+) RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at
 `
 
 type CreateWorkoutParams struct {
@@ -62,6 +150,8 @@ func (q *Queries) CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (W
 		&i.DateCompleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
 	)
 	return i, err
 }
@@ -95,6 +185,25 @@ func (q *Queries) DeleteCompletedWorkout(ctx context.Context, arg DeleteComplete
 	return count, err
 }
 
+const deleteFutureSeriesWorkouts = `-- name: DeleteFutureSeriesWorkouts :exec
+DELETE FROM workouts
+WHERE series_id = $1
+    AND user_id = $2
+    AND planned_date >= $3
+    AND date_completed IS NULL
+`
+
+type DeleteFutureSeriesWorkoutsParams struct {
+	SeriesID    uuid.NullUUID
+	UserID      uuid.UUID
+	PlannedDate time.Time
+}
+
+func (q *Queries) DeleteFutureSeriesWorkouts(ctx context.Context, arg DeleteFutureSeriesWorkoutsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFutureSeriesWorkouts, arg.SeriesID, arg.UserID, arg.PlannedDate)
+	return err
+}
+
 const deleteUpcomingWorkout = `-- name: DeleteUpcomingWorkout :one
 WITH deleted AS (
     DELETE FROM workouts WHERE workouts.id = $1 AND workouts.user_id = $2 RETURNING workouts.user_id
@@ -129,8 +238,51 @@ func (q *Queries) DeleteWorkout(ctx context.Context, arg DeleteWorkoutParams) er
 	return err
 }
 
+const finishWorkout = `-- name: FinishWorkout :one
+UPDATE workouts
+SET
+    updated_at = now(),
+    date_completed = $1,
+    duration_minutes = $2
+WHERE id = $3 AND user_id = $4
+    AND started_at IS NOT NULL
+    AND date_completed IS NULL
+RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at
+`
+
+type FinishWorkoutParams struct {
+	DateCompleted   sql.NullTime
+	DurationMinutes int32
+	ID              uuid.UUID
+	UserID          uuid.UUID
+}
+
+func (q *Queries) FinishWorkout(ctx context.Context, arg FinishWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, finishWorkout,
+		arg.DateCompleted,
+		arg.DurationMinutes,
+		arg.ID,
+		arg.UserID,
+	)
+	var i Workout
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.DurationMinutes,
+		&i.PlannedDate,
+		&i.DateCompleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
+	)
+	return i, err
+}
+
 const getAllUserWorkouts = `-- name: GetAllUserWorkouts :many
-SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at FROM workouts
+SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at FROM workouts
 WHERE user_id = $1
 `
 
@@ -153,6 +305,8 @@ func (q *Queries) GetAllUserWorkouts(ctx context.Context, userID uuid.UUID) ([]W
 			&i.DateCompleted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SeriesID,
+			&i.StartedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -168,7 +322,7 @@ func (q *Queries) GetAllUserWorkouts(ctx context.Context, userID uuid.UUID) ([]W
 }
 
 const getCompletedUserWorkouts = `-- name: GetCompletedUserWorkouts :many
-SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at FROM workouts
+SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at FROM workouts
 WHERE user_id = $1 AND date_completed IS NOT NULL
 ORDER BY date_completed DESC
 `
@@ -192,6 +346,8 @@ func (q *Queries) GetCompletedUserWorkouts(ctx context.Context, userID uuid.UUID
 			&i.DateCompleted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SeriesID,
+			&i.StartedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -206,8 +362,46 @@ func (q *Queries) GetCompletedUserWorkouts(ctx context.Context, userID uuid.UUID
 	return items, nil
 }
 
+const getCompletedWorkoutsPerDay = `-- name: GetCompletedWorkoutsPerDay :many
+SELECT
+    date_completed::date AS day,
+    COUNT(*) AS total
+FROM workouts
+WHERE date_completed >= $1
+GROUP BY day
+ORDER BY day
+`
+
+type GetCompletedWorkoutsPerDayRow struct {
+	Day   time.Time
+	Total int64
+}
+
+func (q *Queries) GetCompletedWorkoutsPerDay(ctx context.Context, dateCompleted sql.NullTime) ([]GetCompletedWorkoutsPerDayRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCompletedWorkoutsPerDay, dateCompleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompletedWorkoutsPerDayRow
+	for rows.Next() {
+		var i GetCompletedWorkoutsPerDayRow
+		if err := rows.Scan(&i.Day, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUpcomingUserWorkouts = `-- name: GetUpcomingUserWorkouts :many
-SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at FROM workouts
+SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at FROM workouts
 WHERE user_id = $1 AND date_completed IS NULL
 ORDER BY planned_date ASC
 `
@@ -231,6 +425,8 @@ func (q *Queries) GetUpcomingUserWorkouts(ctx context.Context, userID uuid.UUID)
 			&i.DateCompleted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SeriesID,
+			&i.StartedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -246,7 +442,7 @@ func (q *Queries) GetUpcomingUserWorkouts(ctx context.Context, userID uuid.UUID)
 }
 
 const getWorkoutByID = `-- name: GetWorkoutByID :one
-SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at FROM workouts
+SELECT id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at FROM workouts
 WHERE id = $1 AND user_id = $2
 `
 
@@ -268,10 +464,99 @@ func (q *Queries) GetWorkoutByID(ctx context.Context, arg GetWorkoutByIDParams)
 		&i.DateCompleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
 	)
 	return i, err
 }
 
+const setWorkoutSeries = `-- name: SetWorkoutSeries :exec
+UPDATE workouts
+SET
+    updated_at = now(),
+    series_id = $1
+WHERE id = $2 AND user_id = $3
+`
+
+type SetWorkoutSeriesParams struct {
+	SeriesID uuid.NullUUID
+	ID       uuid.UUID
+	UserID   uuid.UUID
+}
+
+func (q *Queries) SetWorkoutSeries(ctx context.Context, arg SetWorkoutSeriesParams) error {
+	_, err := q.db.ExecContext(ctx, setWorkoutSeries, arg.SeriesID, arg.ID, arg.UserID)
+	return err
+}
+
+const startWorkout = `-- name: StartWorkout :one
+UPDATE workouts
+SET
+    updated_at = now(),
+    started_at = coalesce(started_at, $1)
+WHERE id = $2 AND user_id = $3 AND date_completed IS NULL
+RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at
+`
+
+type StartWorkoutParams struct {
+	StartedAt sql.NullTime
+	ID        uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (q *Queries) StartWorkout(ctx context.Context, arg StartWorkoutParams) (Workout, error) {
+	row := q.db.QueryRowContext(ctx, startWorkout, arg.StartedAt, arg.ID, arg.UserID)
+	var i Workout
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.DurationMinutes,
+		&i.PlannedDate,
+		&i.DateCompleted,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
+	)
+	return i, err
+}
+
+const updateFutureSeriesWorkouts = `-- name: UpdateFutureSeriesWorkouts :exec
+UPDATE workouts
+SET
+    updated_at = now(),
+    title = $1,
+    description = $2,
+    duration_minutes = $3
+WHERE series_id = $4
+    AND user_id = $5
+    AND planned_date >= $6
+    AND date_completed IS NULL
+`
+
+type UpdateFutureSeriesWorkoutsParams struct {
+	Title           string
+	Description     sql.NullString
+	DurationMinutes int32
+	SeriesID        uuid.NullUUID
+	UserID          uuid.UUID
+	PlannedDate     time.Time
+}
+
+func (q *Queries) UpdateFutureSeriesWorkouts(ctx context.Context, arg UpdateFutureSeriesWorkoutsParams) error {
+	_, err := q.db.ExecContext(ctx, updateFutureSeriesWorkouts,
+		arg.Title,
+		arg.Description,
+		arg.DurationMinutes,
+		arg.SeriesID,
+		arg.UserID,
+		arg.PlannedDate,
+	)
+	return err
+}
+
 const updateWorkout = `-- name: UpdateWorkout :one
 UPDATE workouts
 SET
@@ -282,7 +567,7 @@ SET
     planned_date = $4,
     date_completed = $5
 WHERE id = $6 AND user_id = $7
-RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at
+RETURNING id, user_id, title, description, duration_minutes, planned_date, date_completed, created_at, updated_at, series_id, started_at
 `
 
 type UpdateWorkoutParams struct {
@@ -316,6 +601,8 @@ func (q *Queries) UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (W
 		&i.DateCompleted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.SeriesID,
+		&i.StartedAt,
 	)
 	return i, err
 }
@@ -7,9 +7,11 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
 )
 
 const addExerciseToWorkout = `-- name: AddExerciseToWorkout :one
@@ -25,7 +27,13 @@ INSERT INTO workouts_exercises (
     weights_completed_lbs,
     updated_at,
     created_at,
-    sort_order
+    sort_order,
+    suggestion,
+    durations_planned_seconds,
+    durations_completed_seconds,
+    distances_planned_meters,
+    distances_completed_meters,
+    heart_rates_completed
 ) VALUES (
     gen_random_uuid(),
     $1,
@@ -41,19 +49,31 @@ INSERT INTO workouts_exercises (
     (
         SELECT coalesce(max(sort_order), 0) + 1 FROM workouts_exercises
         WHERE workout_id = $1
-    )
-) RETURNING id, workout_id, exercise_id, sets_planned, reps_per_set_planned, sets_completed, reps_per_set_completed, weights_planned_lbs, weights_completed_lbs, date_completed, updated_at, created_at, sort_order
+    ),
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14
+) RETURNING id, workout_id, exercise_id, sets_planned, reps_per_set_planned, sets_completed, reps_per_set_completed, weights_planned_lbs, weights_completed_lbs, date_completed, updated_at, created_at, sort_order, suggestion, group_id, durations_planned_seconds, durations_completed_seconds, distances_planned_meters, distances_completed_meters, heart_rates_completed
 `
 
 type AddExerciseToWorkoutParams struct {
-	WorkoutID           uuid.UUID
-	ExerciseID          uuid.UUID
-	SetsPlanned         int32
-	RepsPerSetPlanned   []int32
-	SetsCompleted       int32
-	RepsPerSetCompleted []int32
-	WeightsPlannedLbs   []int32
-	WeightsCompletedLbs []int32
+	WorkoutID                 uuid.UUID
+	ExerciseID                uuid.UUID
+	SetsPlanned               int32
+	RepsPerSetPlanned         []int32
+	SetsCompleted             int32
+	RepsPerSetCompleted       []int32
+	WeightsPlannedLbs         []int32
+	WeightsCompletedLbs       []int32
+	Suggestion                pqtype.NullRawMessage
+	DurationsPlannedSeconds   []int32
+	DurationsCompletedSeconds []int32
+	DistancesPlannedMeters    []int32
+	DistancesCompletedMeters  []int32
+	HeartRatesCompleted       []int32
 }
 
 func (q *Queries) AddExerciseToWorkout(ctx context.Context, arg AddExerciseToWorkoutParams) (WorkoutsExercise, error) {
@@ -66,6 +86,12 @@ func (q *Queries) AddExerciseToWorkout(ctx context.Context, arg AddExerciseToWor
 		pq.Array(arg.RepsPerSetCompleted),
 		pq.Array(arg.WeightsPlannedLbs),
 		pq.Array(arg.WeightsCompletedLbs),
+		arg.Suggestion,
+		pq.Array(arg.DurationsPlannedSeconds),
+		pq.Array(arg.DurationsCompletedSeconds),
+		pq.Array(arg.DistancesPlannedMeters),
+		pq.Array(arg.DistancesCompletedMeters),
+		pq.Array(arg.HeartRatesCompleted),
 	)
 	var i WorkoutsExercise
 	err := row.Scan(
@@ -82,10 +108,81 @@ func (q *Queries) AddExerciseToWorkout(ctx context.Context, arg AddExerciseToWor
 		&i.UpdatedAt,
 		&i.CreatedAt,
 		&i.SortOrder,
+		&i.Suggestion,
+		&i.GroupID,
+		pq.Array(&i.DurationsPlannedSeconds),
+		pq.Array(&i.DurationsCompletedSeconds),
+		pq.Array(&i.DistancesPlannedMeters),
+		pq.Array(&i.DistancesCompletedMeters),
+		pq.Array(&i.HeartRatesCompleted),
 	)
 	return i, err
 }
 
+const copyWorkoutExercises = `-- name: CopyWorkoutExercises :exec
+INSERT INTO workouts_exercises (
+    id,
+    workout_id,
+    exercise_id,
+    sets_planned,
+    reps_per_set_planned,
+    sets_completed,
+    reps_per_set_completed,
+    weights_planned_lbs,
+    weights_completed_lbs,
+    updated_at,
+    created_at,
+    sort_order,
+    group_id,
+    durations_planned_seconds,
+    distances_planned_meters
+)
+SELECT
+    gen_random_uuid(),
+    $1::uuid,
+    we.exercise_id,
+    we.sets_planned,
+    we.reps_per_set_planned,
+    0,
+    '{}',
+    we.weights_planned_lbs,
+    '{}',
+    now(),
+    now(),
+    we.sort_order,
+    tg.id,
+    we.durations_planned_seconds,
+    we.distances_planned_meters
+FROM workouts_exercises AS we
+LEFT JOIN workout_exercise_groups AS sg
+    ON we.group_id = sg.id
+LEFT JOIN workout_exercise_groups AS tg
+    ON tg.workout_id = $1::uuid AND sg.label = tg.label
+WHERE we.workout_id = $2
+`
+
+type CopyWorkoutExercisesParams struct {
+	TargetID uuid.UUID
+	SourceID uuid.UUID
+}
+
+func (q *Queries) CopyWorkoutExercises(ctx context.Context, arg CopyWorkoutExercisesParams) error {
+	_, err := q.db.ExecContext(ctx, copyWorkoutExercises, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const countExerciseUsage = `-- name: CountExerciseUsage :one
+SELECT COUNT(*) FROM workouts_exercises
+WHERE exercise_id = $1
+`
+
+func (q *Queries) CountExerciseUsage(ctx context.Context, exerciseID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countExerciseUsage, exerciseID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteExerciseFromWorkout = `-- name: DeleteExerciseFromWorkout :exec
 DELETE FROM workouts_exercises
 WHERE workouts_exercises.id = $1
@@ -104,6 +201,283 @@ func (q *Queries) DeleteExerciseFromWorkout(ctx context.Context, arg DeleteExerc
 	return err
 }
 
+const getCompletedExerciseSets = `-- name: GetCompletedExerciseSets :many
+SELECT we.reps_per_set_completed, we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.id <> $3
+    AND we.sets_completed > 0
+`
+
+type GetCompletedExerciseSetsParams struct {
+	UserID     uuid.UUID
+	ExerciseID uuid.UUID
+	ID         uuid.UUID
+}
+
+type GetCompletedExerciseSetsRow struct {
+	RepsPerSetCompleted []int32
+	WeightsCompletedLbs []int32
+}
+
+func (q *Queries) GetCompletedExerciseSets(ctx context.Context, arg GetCompletedExerciseSetsParams) ([]GetCompletedExerciseSetsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCompletedExerciseSets, arg.UserID, arg.ExerciseID, arg.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCompletedExerciseSetsRow
+	for rows.Next() {
+		var i GetCompletedExerciseSetsRow
+		if err := rows.Scan(pq.Array(&i.RepsPerSetCompleted), pq.Array(&i.WeightsCompletedLbs)); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExerciseHistory = `-- name: GetExerciseHistory :many
+SELECT
+    we.id,
+    we.workout_id,
+    w.title,
+    coalesce(w.date_completed, w.planned_date)::timestamp AS session_date,
+    we.reps_per_set_completed,
+    we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.sets_completed > 0
+ORDER BY session_date, we.created_at
+`
+
+type GetExerciseHistoryParams struct {
+	UserID     uuid.UUID
+	ExerciseID uuid.UUID
+}
+
+type GetExerciseHistoryRow struct {
+	ID                  uuid.UUID
+	WorkoutID           uuid.UUID
+	Title               string
+	SessionDate         time.Time
+	RepsPerSetCompleted []int32
+	WeightsCompletedLbs []int32
+}
+
+func (q *Queries) GetExerciseHistory(ctx context.Context, arg GetExerciseHistoryParams) ([]GetExerciseHistoryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getExerciseHistory, arg.UserID, arg.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetExerciseHistoryRow
+	for rows.Next() {
+		var i GetExerciseHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkoutID,
+			&i.Title,
+			&i.SessionDate,
+			pq.Array(&i.RepsPerSetCompleted),
+			pq.Array(&i.WeightsCompletedLbs),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastExerciseSession = `-- name: GetLastExerciseSession :one
+SELECT
+    w.title,
+    coalesce(w.date_completed, w.planned_date)::timestamp AS session_date,
+    we.reps_per_set_planned,
+    we.weights_planned_lbs,
+    we.reps_per_set_completed,
+    we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.sets_completed > 0
+ORDER BY session_date DESC, we.created_at DESC
+LIMIT 1
+`
+
+type GetLastExerciseSessionParams struct {
+	UserID     uuid.UUID
+	ExerciseID uuid.UUID
+}
+
+type GetLastExerciseSessionRow struct {
+	Title               string
+	SessionDate         time.Time
+	RepsPerSetPlanned   []int32
+	WeightsPlannedLbs   []int32
+	RepsPerSetCompleted []int32
+	WeightsCompletedLbs []int32
+}
+
+func (q *Queries) GetLastExerciseSession(ctx context.Context, arg GetLastExerciseSessionParams) (GetLastExerciseSessionRow, error) {
+	row := q.db.QueryRowContext(ctx, getLastExerciseSession, arg.UserID, arg.ExerciseID)
+	var i GetLastExerciseSessionRow
+	err := row.Scan(
+		&i.Title,
+		&i.SessionDate,
+		pq.Array(&i.RepsPerSetPlanned),
+		pq.Array(&i.WeightsPlannedLbs),
+		pq.Array(&i.RepsPerSetCompleted),
+		pq.Array(&i.WeightsCompletedLbs),
+	)
+	return i, err
+}
+
+const getWorkoutExercise = `-- name: GetWorkoutExercise :one
+SELECT we.id, we.workout_id, we.exercise_id, we.sets_planned, we.reps_per_set_planned, we.sets_completed, we.reps_per_set_completed, we.weights_planned_lbs, we.weights_completed_lbs, we.date_completed, we.updated_at, we.created_at, we.sort_order, we.suggestion, we.group_id, we.durations_planned_seconds, we.durations_completed_seconds, we.distances_planned_meters, we.distances_completed_meters, we.heart_rates_completed FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE we.id = $1 AND we.workout_id = $2 AND w.user_id = $3
+`
+
+type GetWorkoutExerciseParams struct {
+	ID        uuid.UUID
+	WorkoutID uuid.UUID
+	UserID    uuid.UUID
+}
+
+func (q *Queries) GetWorkoutExercise(ctx context.Context, arg GetWorkoutExerciseParams) (WorkoutsExercise, error) {
+	row := q.db.QueryRowContext(ctx, getWorkoutExercise, arg.ID, arg.WorkoutID, arg.UserID)
+	var i WorkoutsExercise
+	err := row.Scan(
+		&i.ID,
+		&i.WorkoutID,
+		&i.ExerciseID,
+		&i.SetsPlanned,
+		pq.Array(&i.RepsPerSetPlanned),
+		&i.SetsCompleted,
+		pq.Array(&i.RepsPerSetCompleted),
+		pq.Array(&i.WeightsPlannedLbs),
+		pq.Array(&i.WeightsCompletedLbs),
+		&i.DateCompleted,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.SortOrder,
+		&i.Suggestion,
+		&i.GroupID,
+		pq.Array(&i.DurationsPlannedSeconds),
+		pq.Array(&i.DurationsCompletedSeconds),
+		pq.Array(&i.DistancesPlannedMeters),
+		pq.Array(&i.DistancesCompletedMeters),
+		pq.Array(&i.HeartRatesCompleted),
+	)
+	return i, err
+}
+
+const reassignWorkoutExercises = `-- name: ReassignWorkoutExercises :exec
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    exercise_id = $1
+WHERE exercise_id = $2
+`
+
+type ReassignWorkoutExercisesParams struct {
+	TargetID uuid.UUID
+	SourceID uuid.UUID
+}
+
+func (q *Queries) ReassignWorkoutExercises(ctx context.Context, arg ReassignWorkoutExercisesParams) error {
+	_, err := q.db.ExecContext(ctx, reassignWorkoutExercises, arg.TargetID, arg.SourceID)
+	return err
+}
+
+const updateCompletedSets = `-- name: UpdateCompletedSets :one
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    sets_completed = $1,
+    reps_per_set_completed = $2,
+    weights_completed_lbs = $3,
+    durations_completed_seconds = $4,
+    distances_completed_meters = $5,
+    heart_rates_completed = $6
+WHERE workouts_exercises.id = $7
+    AND workouts_exercises.workout_id = $8
+    AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $8 AND workouts.user_id = $9)
+RETURNING id, workout_id, exercise_id, sets_planned, reps_per_set_planned, sets_completed, reps_per_set_completed, weights_planned_lbs, weights_completed_lbs, date_completed, updated_at, created_at, sort_order, suggestion, group_id, durations_planned_seconds, durations_completed_seconds, distances_planned_meters, distances_completed_meters, heart_rates_completed
+`
+
+type UpdateCompletedSetsParams struct {
+	SetsCompleted             int32
+	RepsPerSetCompleted       []int32
+	WeightsCompletedLbs       []int32
+	DurationsCompletedSeconds []int32
+	DistancesCompletedMeters  []int32
+	HeartRatesCompleted       []int32
+	ID                        uuid.UUID
+	WorkoutID                 uuid.UUID
+	UserID                    uuid.UUID
+}
+
+func (q *Queries) UpdateCompletedSets(ctx context.Context, arg UpdateCompletedSetsParams) (WorkoutsExercise, error) {
+	row := q.db.QueryRowContext(ctx, updateCompletedSets,
+		arg.SetsCompleted,
+		pq.Array(arg.RepsPerSetCompleted),
+		pq.Array(arg.WeightsCompletedLbs),
+		pq.Array(arg.DurationsCompletedSeconds),
+		pq.Array(arg.DistancesCompletedMeters),
+		pq.Array(arg.HeartRatesCompleted),
+		arg.ID,
+		arg.WorkoutID,
+		arg.UserID,
+	)
+	var i WorkoutsExercise
+	err := row.Scan(
+		&i.ID,
+		&i.WorkoutID,
+		&i.ExerciseID,
+		&i.SetsPlanned,
+		pq.Array(&i.RepsPerSetPlanned),
+		&i.SetsCompleted,
+		pq.Array(&i.RepsPerSetCompleted),
+		pq.Array(&i.WeightsPlannedLbs),
+		pq.Array(&i.WeightsCompletedLbs),
+		&i.DateCompleted,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.SortOrder,
+		&i.Suggestion,
+		&i.GroupID,
+		pq.Array(&i.DurationsPlannedSeconds),
+		pq.Array(&i.DurationsCompletedSeconds),
+		pq.Array(&i.DistancesPlannedMeters),
+		pq.Array(&i.DistancesCompletedMeters),
+		pq.Array(&i.HeartRatesCompleted),
+	)
+	return i, err
+}
+
 const updateWorkoutExercise = `-- name: UpdateWorkoutExercise :one
 UPDATE workouts_exercises
 SET
@@ -113,23 +487,33 @@ SET
     sets_completed = $3,
     reps_per_set_completed = $4,
     weights_planned_lbs = $5,
-    weights_completed_lbs = $6
-WHERE workouts_exercises.id = $7
-    AND workouts_exercises.workout_id = $8
-    AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $8 AND workouts.user_id = $9)
-RETURNING id, workout_id, exercise_id, sets_planned, reps_per_set_planned, sets_completed, reps_per_set_completed, weights_planned_lbs, weights_completed_lbs, date_completed, updated_at, created_at, sort_order
+    weights_completed_lbs = $6,
+    durations_planned_seconds = $7,
+    durations_completed_seconds = $8,
+    distances_planned_meters = $9,
+    distances_completed_meters = $10,
+    heart_rates_completed = $11
+WHERE workouts_exercises.id = $12
+    AND workouts_exercises.workout_id = $13
+    AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $13 AND workouts.user_id = $14)
+RETURNING id, workout_id, exercise_id, sets_planned, reps_per_set_planned, sets_completed, reps_per_set_completed, weights_planned_lbs, weights_completed_lbs, date_completed, updated_at, created_at, sort_order, suggestion, group_id, durations_planned_seconds, durations_completed_seconds, distances_planned_meters, distances_completed_meters, heart_rates_completed
 `
 
 type UpdateWorkoutExerciseParams struct {
-	SetsPlanned         int32
-	RepsPerSetPlanned   []int32
-	SetsCompleted       int32
-	RepsPerSetCompleted []int32
-	WeightsPlannedLbs   []int32
-	WeightsCompletedLbs []int32
-	ID                  uuid.UUID
-	WorkoutID           uuid.UUID
-	UserID              uuid.UUID
+	SetsPlanned               int32
+	RepsPerSetPlanned         []int32
+	SetsCompleted             int32
+	RepsPerSetCompleted       []int32
+	WeightsPlannedLbs         []int32
+	WeightsCompletedLbs       []int32
+	DurationsPlannedSeconds   []int32
+	DurationsCompletedSeconds []int32
+	DistancesPlannedMeters    []int32
+	DistancesCompletedMeters  []int32
+	HeartRatesCompleted       []int32
+	ID                        uuid.UUID
+	WorkoutID                 uuid.UUID
+	UserID                    uuid.UUID
 }
 
 func (q *Queries) UpdateWorkoutExercise(ctx context.Context, arg UpdateWorkoutExerciseParams) (WorkoutsExercise, error) {
@@ -140,6 +524,11 @@ func (q *Queries) UpdateWorkoutExercise(ctx context.Context, arg UpdateWorkoutEx
 		pq.Array(arg.RepsPerSetCompleted),
 		pq.Array(arg.WeightsPlannedLbs),
 		pq.Array(arg.WeightsCompletedLbs),
+		pq.Array(arg.DurationsPlannedSeconds),
+		pq.Array(arg.DurationsCompletedSeconds),
+		pq.Array(arg.DistancesPlannedMeters),
+		pq.Array(arg.DistancesCompletedMeters),
+		pq.Array(arg.HeartRatesCompleted),
 		arg.ID,
 		arg.WorkoutID,
 		arg.UserID,
@@ -159,6 +548,13 @@ func (q *Queries) UpdateWorkoutExercise(ctx context.Context, arg UpdateWorkoutEx
 		&i.UpdatedAt,
 		&i.CreatedAt,
 		&i.SortOrder,
+		&i.Suggestion,
+		&i.GroupID,
+		pq.Array(&i.DurationsPlannedSeconds),
+		pq.Array(&i.DurationsCompletedSeconds),
+		pq.Array(&i.DistancesPlannedMeters),
+		pq.Array(&i.DistancesCompletedMeters),
+		pq.Array(&i.HeartRatesCompleted),
 	)
 	return i, err
 }
@@ -192,8 +588,8 @@ func (q *Queries) UpdateWorkoutExercisesSortOrder(ctx context.Context, arg Updat
 
 const workoutAndExercises = `-- name: WorkoutAndExercises :many
 SELECT
-    we.id, we.workout_id, we.exercise_id, we.sets_planned, we.reps_per_set_planned, we.sets_completed, we.reps_per_set_completed, we.weights_planned_lbs, we.weights_completed_lbs, we.date_completed, we.updated_at, we.created_at, we.sort_order,
-    e.id, e.name, e.description, e.primary_muscle_group, e.secondary_muscle_group, e.created_at, e.updated_at, e.video_url
+    we.id, we.workout_id, we.exercise_id, we.sets_planned, we.reps_per_set_planned, we.sets_completed, we.reps_per_set_completed, we.weights_planned_lbs, we.weights_completed_lbs, we.date_completed, we.updated_at, we.created_at, we.sort_order, we.suggestion, we.group_id, we.durations_planned_seconds, we.durations_completed_seconds, we.distances_planned_meters, we.distances_completed_meters, we.heart_rates_completed,
+    e.id, e.name, e.description, e.primary_muscle_group, e.secondary_muscle_group, e.created_at, e.updated_at, e.video_url, e.retired_at, e.user_id, e.tracking_type
 FROM workouts_exercises AS we
 JOIN exercises AS e
     ON we.exercise_id = e.id
@@ -236,6 +632,13 @@ func (q *Queries) WorkoutAndExercises(ctx context.Context, arg WorkoutAndExercis
 			&i.WorkoutsExercise.UpdatedAt,
 			&i.WorkoutsExercise.CreatedAt,
 			&i.WorkoutsExercise.SortOrder,
+			&i.WorkoutsExercise.Suggestion,
+			&i.WorkoutsExercise.GroupID,
+			pq.Array(&i.WorkoutsExercise.DurationsPlannedSeconds),
+			pq.Array(&i.WorkoutsExercise.DurationsCompletedSeconds),
+			pq.Array(&i.WorkoutsExercise.DistancesPlannedMeters),
+			pq.Array(&i.WorkoutsExercise.DistancesCompletedMeters),
+			pq.Array(&i.WorkoutsExercise.HeartRatesCompleted),
 			&i.Exercise.ID,
 			&i.Exercise.Name,
 			&i.Exercise.Description,
@@ -244,6 +647,9 @@ func (q *Queries) WorkoutAndExercises(ctx context.Context, arg WorkoutAndExercis
 			&i.Exercise.CreatedAt,
 			&i.Exercise.UpdatedAt,
 			&i.Exercise.VideoUrl,
+			&i.Exercise.RetiredAt,
+			&i.Exercise.UserID,
+			&i.Exercise.TrackingType,
 		); err != nil {
 			return nil, err
 		}
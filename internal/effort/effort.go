@@ -0,0 +1,109 @@
+// Package effort validates the effort ratings, tempo and notes logged with a set
+package effort
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// NoRIR marks a set logged without reps in reserve.
+	NoRIR = -1
+
+	MaxRIR      = 10
+	MaxNotesLen = 500
+)
+
+// Set is everything logged about a completed set besides its reps and weight.
+// An RPE of 0 means the set wasn't rated.
+type Set struct {
+	RPE   float64
+	RIR   int32
+	Tempo string
+	Notes string
+}
+
+// ParseSet validates raw set input. Every field is optional.
+func ParseSet(rpe, rir, tempo, notes string) (Set, error) {
+	set := Set{RIR: NoRIR}
+
+	if rpe = strings.TrimSpace(rpe); rpe != "" {
+		v, err := strconv.ParseFloat(rpe, 64)
+		if err != nil || v < 1 || v > 10 || v*2 != math.Trunc(v*2) {
+			return Set{}, errors.New("rpe must be between 1 and 10 in steps of 0.5")
+		}
+		set.RPE = v
+	}
+
+	if rir = strings.TrimSpace(rir); rir != "" {
+		v, err := strconv.Atoi(rir)
+		if err != nil || v < 0 || v > MaxRIR {
+			return Set{}, fmt.Errorf("rir must be between 0 and %d", MaxRIR)
+		}
+		set.RIR = int32(v)
+	}
+
+	t, err := ParseTempo(tempo)
+	if err != nil {
+		return Set{}, err
+	}
+	set.Tempo = t
+
+	set.Notes = strings.TrimSpace(notes)
+	if len(set.Notes) > MaxNotesLen {
+		return Set{}, fmt.Errorf("notes must be at most %d characters", MaxNotesLen)
+	}
+	return set, nil
+}
+
+// ParseTempo normalizes a four phase tempo such as "3-1-x-0" to "31X0": the
+// eccentric, bottom pause, concentric and top pause in seconds, with X for an
+// explosive phase. An empty tempo is allowed.
+func ParseTempo(s string) (string, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if s == "" {
+		return "", nil
+	}
+	s = strings.NewReplacer("-", "", ":", "", " ", "").Replace(s)
+	if len(s) != 4 {
+		return "", errors.New("tempo must have four phases, e.g. 31X0")
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && c != 'X' {
+			return "", errors.New("tempo phases must be seconds or X")
+		}
+	}
+	return s, nil
+}
+
+// At returns set i (zero-based) of sets, or an empty set past the end.
+func At(sets []Set, i int) Set {
+	if i >= 0 && i < len(sets) {
+		return sets[i]
+	}
+	return Set{RIR: NoRIR}
+}
+
+// Empty reports whether nothing was logged for the set.
+func (s Set) Empty() bool {
+	return s.RPE == 0 && s.RIR == NoRIR && s.Tempo == "" && s.Notes == ""
+}
+
+// String summarizes the ratings and tempo, e.g. "RPE 8.5, 2 RIR, 31X0".
+// Notes are left out.
+func (s Set) String() string {
+	var parts []string
+	if s.RPE > 0 {
+		parts = append(parts, "RPE "+strconv.FormatFloat(s.RPE, 'f', -1, 64))
+	}
+	if s.RIR != NoRIR {
+		parts = append(parts, fmt.Sprintf("%d RIR", s.RIR))
+	}
+	if s.Tempo != "" {
+		parts = append(parts, s.Tempo)
+	}
+	return strings.Join(parts, ", ")
+}
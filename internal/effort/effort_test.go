@@ -0,0 +1,91 @@
+package effort
+
+import "testing"
+
+func TestParseSet(t *testing.T) {
+	tests := map[string]struct {
+		rpe, rir, tempo, notes string
+		want                   Set
+		wantErr                bool
+	}{
+		"empty":         {want: Set{RIR: NoRIR}},
+		"everything":    {rpe: "8.5", rir: "2", tempo: "3-1-x-0", notes: " grindy ", want: Set{RPE: 8.5, RIR: 2, Tempo: "31X0", Notes: "grindy"}},
+		"zero rir":      {rir: "0", want: Set{RIR: 0}},
+		"rpe too high":  {rpe: "11", wantErr: true},
+		"rpe too low":   {rpe: "0.5", wantErr: true},
+		"rpe quarter":   {rpe: "7.25", wantErr: true},
+		"rpe not a num": {rpe: "hard", wantErr: true},
+		"negative rir":  {rir: "-1", wantErr: true},
+		"rir too high":  {rir: "11", wantErr: true},
+		"bad tempo":     {tempo: "3-1", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseSet(tc.rpe, tc.rir, tc.tempo, tc.notes)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want && !tc.wantErr {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseTempo(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"empty":      {input: "", want: ""},
+		"compact":    {input: "31x0", want: "31X0"},
+		"dashes":     {input: "4-0-1-0", want: "4010"},
+		"colons":     {input: "2:0:X:1", want: "20X1"},
+		"too short":  {input: "310", wantErr: true},
+		"too long":   {input: "31010", wantErr: true},
+		"bad letter": {input: "31Y0", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseTempo(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSetString(t *testing.T) {
+	tests := map[string]struct {
+		set  Set
+		want string
+	}{
+		"nothing":  {set: Set{RIR: NoRIR}, want: ""},
+		"rpe only": {set: Set{RPE: 8, RIR: NoRIR}, want: "RPE 8"},
+		"all":      {set: Set{RPE: 9.5, RIR: 0, Tempo: "31X0", Notes: "ignored"}, want: "RPE 9.5, 0 RIR, 31X0"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.set.String(); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	sets := []Set{{RPE: 8, RIR: NoRIR}}
+	if got := At(sets, 0); got.RPE != 8 {
+		t.Errorf("expected the logged set, got %+v", got)
+	}
+	if got := At(sets, 1); !got.Empty() {
+		t.Errorf("expected an empty set past the end, got %+v", got)
+	}
+}
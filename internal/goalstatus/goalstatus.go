@@ -0,0 +1,100 @@
+// Package goalstatus describes where a goal stands: in progress, completed,
+// paused or abandoned, and whether an in-progress goal has run past its date
+package goalstatus
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Status is the state a goal is stored in.
+type Status string
+
+const (
+	InProgress Status = "in_progress"
+	Completed  Status = "completed"
+	Paused     Status = "paused"
+	Abandoned  Status = "abandoned"
+)
+
+// Statuses lists every status in display order.
+var Statuses = []Status{InProgress, Paused, Completed, Abandoned}
+
+// MaxReason is the longest reason a goal can be paused or abandoned with.
+const MaxReason = 500
+
+// Parse validates a status.
+func Parse(s string) (Status, error) {
+	st := Status(strings.TrimSpace(s))
+	for _, known := range Statuses {
+		if st == known {
+			return st, nil
+		}
+	}
+	return "", errors.New("status must be in progress, paused, completed or abandoned")
+}
+
+// Label names the status for display.
+func (s Status) Label() string {
+	switch s {
+	case InProgress:
+		return "In Progress"
+	case Completed:
+		return "Completed"
+	case Paused:
+		return "Paused"
+	case Abandoned:
+		return "Abandoned"
+	}
+	return string(s)
+}
+
+// TakesReason reports whether a reason is kept with the status. Only paused
+// and abandoned goals say why they stopped.
+func (s Status) TakesReason() bool {
+	return s == Paused || s == Abandoned
+}
+
+// Overdue reports whether a goal is still in progress after its goal date.
+// A goal is due through the end of its date.
+func Overdue(s Status, goalDate, now time.Time) bool {
+	if s != InProgress {
+		return false
+	}
+	return Today(now).After(goalDate)
+}
+
+// Today is the date, in UTC, that goal dates are compared against.
+func Today(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// View is a tab of the goals page and a filter of the goals API: the goals in
+// one status, or the in-progress goals that are overdue.
+type View string
+
+// OverdueView lists the in-progress goals past their date.
+const OverdueView View = "overdue"
+
+// Views lists every view in display order.
+var Views = []View{View(InProgress), OverdueView, View(Paused), View(Completed), View(Abandoned)}
+
+// ParseView validates a view.
+func ParseView(s string) (View, error) {
+	v := View(strings.TrimSpace(s))
+	for _, known := range Views {
+		if v == known {
+			return v, nil
+		}
+	}
+	return "", errors.New("status must be in_progress, overdue, paused, completed or abandoned")
+}
+
+// Label names the view for its tab.
+func (v View) Label() string {
+	if v == OverdueView {
+		return "Overdue"
+	}
+	return Status(v).Label()
+}
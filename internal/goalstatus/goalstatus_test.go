@@ -0,0 +1,85 @@
+package goalstatus
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Status
+		wantErr bool
+	}{
+		"in progress": {input: "in_progress", want: InProgress},
+		"paused":      {input: " paused ", want: Paused},
+		"abandoned":   {input: "abandoned", want: Abandoned},
+		"completed":   {input: "completed", want: Completed},
+		"overdue":     {input: "overdue", wantErr: true},
+		"empty":       {input: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseView(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    View
+		wantErr bool
+	}{
+		"in progress": {input: "in_progress", want: View(InProgress)},
+		"overdue":     {input: "overdue", want: OverdueView},
+		"abandoned":   {input: "abandoned", want: View(Abandoned)},
+		"unknown":     {input: "late", wantErr: true},
+		"empty":       {input: "", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseView(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOverdue(t *testing.T) {
+	goalDate := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		status Status
+		now    time.Time
+		want   bool
+	}{
+		"before date":       {status: InProgress, now: time.Date(2025, 3, 9, 12, 0, 0, 0, time.UTC)},
+		"on date":           {status: InProgress, now: time.Date(2025, 3, 10, 23, 59, 0, 0, time.UTC)},
+		"day after":         {status: InProgress, now: time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC), want: true},
+		"paused past date":  {status: Paused, now: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		"completed late":    {status: Completed, now: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		"other time zone":   {status: InProgress, now: time.Date(2025, 3, 10, 20, 0, 0, 0, time.FixedZone("EST", -5*60*60)), want: true},
+		"abandoned past it": {status: Abandoned, now: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Overdue(tc.status, goalDate, tc.now); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
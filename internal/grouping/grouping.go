@@ -0,0 +1,139 @@
+// Package grouping arranges workout exercises into supersets and circuits
+package grouping
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Kind identifies how the exercises of a group are performed.
+type Kind string
+
+const (
+	// Superset alternates between its exercises with no rest in between.
+	Superset Kind = "superset"
+	// Circuit runs through every exercise once per round.
+	Circuit Kind = "circuit"
+)
+
+// Kinds lists every group kind in display order.
+var Kinds = []Kind{Superset, Circuit}
+
+// ParseKind returns the named kind. An empty string is Superset.
+func ParseKind(s string) (Kind, error) {
+	if s == "" {
+		return Superset, nil
+	}
+	for _, k := range Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown group kind %q", s)
+}
+
+// Label returns a human-readable name for the group kind.
+func (k Kind) Label() string {
+	if k == Circuit {
+		return "Circuit"
+	}
+	return "Superset"
+}
+
+const (
+	MaxRounds      = 20
+	MaxRestSeconds = 600
+	MaxLabelLen    = 10
+)
+
+// Group is the shared settings of a superset or circuit.
+type Group struct {
+	Kind        Kind
+	Label       string
+	Rounds      int32
+	RestSeconds int32
+}
+
+// Validate reports whether the group settings and member count make sense.
+func (g Group) Validate(members int) error {
+	if g.Label == "" || len(g.Label) > MaxLabelLen {
+		return fmt.Errorf("label must be between 1 and %d characters", MaxLabelLen)
+	}
+	if g.Rounds < 1 || g.Rounds > MaxRounds {
+		return fmt.Errorf("rounds must be between 1 and %d", MaxRounds)
+	}
+	if g.RestSeconds < 0 || g.RestSeconds > MaxRestSeconds {
+		return fmt.Errorf("rest must be between 0 and %d seconds", MaxRestSeconds)
+	}
+	if members < 2 {
+		return errors.New("a group needs at least two exercises")
+	}
+	return nil
+}
+
+// NextLabel returns the first letter label ("A", "B", ... "Z", "AA") not in
+// used.
+func NextLabel(used []string) string {
+	for i := 0; ; i++ {
+		label := letters(i)
+		if !slices.Contains(used, label) {
+			return label
+		}
+	}
+}
+
+func letters(n int) string {
+	s := ""
+	for n >= 0 {
+		s = string(rune('A'+n%26)) + s
+		n = n/26 - 1
+	}
+	return s
+}
+
+// Block is a run of exercises shown together. An exercise outside any group
+// is a block of its own with an invalid Group.
+type Block[T any] struct {
+	Group uuid.NullUUID
+	Items []T
+}
+
+// Layout arranges items, already in sort order, into blocks. Every member of
+// a group is gathered into one block at the position of its first member, so
+// the sort order of grouped exercises only matters relative to each other.
+func Layout[T any](items []T, groupOf func(T) uuid.NullUUID) []Block[T] {
+	var blocks []Block[T]
+	index := make(map[uuid.UUID]int)
+	for _, item := range items {
+		group := groupOf(item)
+		if !group.Valid {
+			blocks = append(blocks, Block[T]{Items: []T{item}})
+			continue
+		}
+		if i, ok := index[group.UUID]; ok {
+			blocks[i].Items = append(blocks[i].Items, item)
+			continue
+		}
+		index[group.UUID] = len(blocks)
+		blocks = append(blocks, Block[T]{Group: group, Items: []T{item}})
+	}
+	return blocks
+}
+
+// Position labels the i-th (zero-based) exercise of a group, e.g. "A2".
+func Position(label string, i int) string {
+	return fmt.Sprintf("%s%d", label, i+1)
+}
+
+// RestAfter returns how long to rest after a set of member i of a group with
+// n members: nothing between exercises, and the group's rest once the last
+// exercise of a round is done.
+func RestAfter(g Group, i, n int) int32 {
+	if i < n-1 {
+		return 0
+	}
+	return g.RestSeconds
+}
@@ -0,0 +1,133 @@
+package grouping
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Kind
+		wantErr bool
+	}{
+		"empty":    {input: "", want: Superset},
+		"superset": {input: "superset", want: Superset},
+		"circuit":  {input: "circuit", want: Circuit},
+		"unknown":  {input: "giant", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseKind(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGroupValidate(t *testing.T) {
+	valid := Group{Kind: Superset, Label: "A", Rounds: 3, RestSeconds: 90}
+
+	tests := map[string]struct {
+		group   Group
+		members int
+		wantErr bool
+	}{
+		"valid":         {group: valid, members: 2},
+		"one member":    {group: valid, members: 1, wantErr: true},
+		"no label":      {group: Group{Kind: Circuit, Rounds: 1}, members: 3, wantErr: true},
+		"long label":    {group: Group{Kind: Circuit, Label: "ABCDEFGHIJK", Rounds: 1}, members: 3, wantErr: true},
+		"zero rounds":   {group: Group{Kind: Circuit, Label: "B", Rounds: 0}, members: 3, wantErr: true},
+		"too much rest": {group: Group{Kind: Circuit, Label: "B", Rounds: 2, RestSeconds: 601}, members: 3, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.group.Validate(tc.members)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNextLabel(t *testing.T) {
+	tests := map[string]struct {
+		used []string
+		want string
+	}{
+		"none used":   {used: nil, want: "A"},
+		"fills a gap": {used: []string{"A", "C"}, want: "B"},
+		"custom used": {used: []string{"Legs"}, want: "A"},
+	}
+
+	all := make([]string, 26)
+	for i := range all {
+		all[i] = string(rune('A' + i))
+	}
+	tests["past Z"] = struct {
+		used []string
+		want string
+	}{used: all, want: "AA"}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := NextLabel(tc.used); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLayout(t *testing.T) {
+	a := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	b := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	type item struct {
+		name  string
+		group uuid.NullUUID
+	}
+	items := []item{
+		{"squat", uuid.NullUUID{}},
+		{"bench", a},
+		{"row", b},
+		{"fly", a},
+		{"plank", uuid.NullUUID{}},
+		{"curl", b},
+	}
+
+	blocks := Layout(items, func(i item) uuid.NullUUID { return i.group })
+
+	var got [][]string
+	for _, block := range blocks {
+		var names []string
+		for _, i := range block.Items {
+			names = append(names, i.name)
+		}
+		got = append(got, names)
+	}
+	want := [][]string{{"squat"}, {"bench", "fly"}, {"row", "curl"}, {"plank"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if blocks[0].Group.Valid || blocks[1].Group != a || blocks[2].Group != b {
+		t.Errorf("unexpected block groups: %v", blocks)
+	}
+}
+
+func TestRestAfter(t *testing.T) {
+	g := Group{Kind: Superset, Label: "A", Rounds: 3, RestSeconds: 120}
+	if got := RestAfter(g, 0, 2); got != 0 {
+		t.Errorf("expected no rest between exercises, got %d", got)
+	}
+	if got := RestAfter(g, 1, 2); got != 120 {
+		t.Errorf("expected group rest after the last exercise, got %d", got)
+	}
+}
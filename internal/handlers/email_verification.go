@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/mail"
+)
+
+var (
+	errInvalidVerificationToken = errors.New("this verification link is invalid or has expired")
+	errEmailTaken               = errors.New("that email already exists")
+	errSameEmail                = errors.New("that's already your email")
+	errEmailVerified            = errors.New("your email is already verified")
+)
+
+// unverifiedBlocked reports whether user is kept from signing in until they
+// verify their email.
+func (h *Handler) unverifiedBlocked(user database.User) bool {
+	return h.cfg.RequireVerifiedEmail && !user.EmailVerifiedAt.Valid
+}
+
+// sendEmailVerification mails user a link confirming they own email, which is
+// either the email they registered with or one they want to change to.
+func (h *Handler) sendEmailVerification(ctx context.Context, user database.User, email string) error {
+	token, err := auth.MakeVerificationToken()
+	if err != nil {
+		return err
+	}
+	err = h.cfg.DB.CreateEmailVerificationToken(ctx, database.CreateEmailVerificationTokenParams{
+		Token:     auth.HashVerificationToken(token),
+		UserID:    user.ID,
+		Email:     email,
+		ExpiresAt: time.Now().UTC().Add(auth.EmailVerificationExpiry),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/email/verify?token=%s", h.cfg.BaseURL, url.QueryEscape(token))
+	return h.cfg.Mailer.Send(ctx, mail.Message{
+		To:      email,
+		Subject: "Verify your FitHub email",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm this is your email address by opening this link:\n\n"+
+			"%s\n\n"+
+			"The link expires in %d hours. If you didn't sign up for FitHub or change your email, you can ignore this email.\n",
+			user.FirstName, link, int(auth.EmailVerificationExpiry.Hours())),
+	})
+}
+
+// resendEmailVerification mails a new link to the account with email if it
+// hasn't been verified. Unknown, disabled and verified accounts are skipped
+// without an error, so callers respond the same whether or not the email has
+// an account.
+func (h *Handler) resendEmailVerification(ctx context.Context, email string) error {
+	user, err := h.cfg.DB.GetUser(ctx, strings.TrimSpace(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DisabledAt.Valid || user.EmailVerifiedAt.Valid {
+		return nil
+	}
+	return h.sendEmailVerification(ctx, user, user.Email)
+}
+
+// resendUserVerification mails a new link for the signed in user's pending
+// email change, or for their current email if it hasn't been verified.
+func (h *Handler) resendUserVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := h.cfg.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	pending, err := h.pendingEmail(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if pending != "" {
+		return h.sendEmailVerification(ctx, user, pending)
+	}
+	if user.EmailVerifiedAt.Valid {
+		return errEmailVerified
+	}
+	return h.sendEmailVerification(ctx, user, user.Email)
+}
+
+// changeEmail sends a verification link to email. The user's email only
+// changes once they open it.
+func (h *Handler) changeEmail(ctx context.Context, user database.User, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if strings.EqualFold(email, user.Email) {
+		return errSameEmail
+	}
+
+	_, err := h.cfg.DB.GetUser(ctx, email)
+	if err == nil {
+		return errEmailTaken
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	return h.sendEmailVerification(ctx, user, email)
+}
+
+// pendingEmail returns the email the user asked to change to, or "" if there
+// isn't one waiting on verification.
+func (h *Handler) pendingEmail(ctx context.Context, userID uuid.UUID) (string, error) {
+	email, err := h.cfg.DB.GetPendingEmail(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return email, err
+}
+
+// verifyEmail marks the email a verification token was sent to as the user's
+// verified email, using up the token and any others the user was sent.
+func (h *Handler) verifyEmail(ctx context.Context, token string) (database.User, error) {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.User{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	verification, err := qtx.UseEmailVerificationToken(ctx, auth.HashVerificationToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.User{}, errInvalidVerificationToken
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+
+	user, err := qtx.VerifyUserEmail(ctx, database.VerifyUserEmailParams{
+		ID:    verification.UserID,
+		Email: verification.Email,
+	})
+	if err != nil {
+		// Someone else took the email after the link was sent
+		if strings.Contains(err.Error(), "users_email_key") {
+			return database.User{}, errEmailTaken
+		}
+		return database.User{}, err
+	}
+	if err := qtx.ExpireUserEmailVerificationTokens(ctx, user.ID); err != nil {
+		return database.User{}, err
+	}
+	return user, tx.Commit()
+}
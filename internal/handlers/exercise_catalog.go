@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var (
+	errMergeSameExercise  = errors.New("cannot merge an exercise into itself")
+	errMergeTargetRetired = errors.New("cannot merge into a retired exercise")
+	errMergeCustom        = errors.New("cannot merge user-defined exercises")
+)
+
+// exerciseInput is the editable part of a catalog exercise as submitted by
+// either the admin HTML forms or the JSON API.
+type exerciseInput struct {
+	Name                 string
+	Description          string
+	PrimaryMuscleGroup   string
+	SecondaryMuscleGroup string
+	VideoURL             string
+	TrackingType         string
+}
+
+// normalize trims whitespace and lowercases names and muscle groups to match
+// the seeded catalog, which is stored lowercase and title-cased on display.
+func (in exerciseInput) normalize() exerciseInput {
+	return exerciseInput{
+		Name:                 strings.ToLower(strings.TrimSpace(in.Name)),
+		Description:          strings.TrimSpace(in.Description),
+		PrimaryMuscleGroup:   strings.ToLower(strings.TrimSpace(in.PrimaryMuscleGroup)),
+		SecondaryMuscleGroup: strings.ToLower(strings.TrimSpace(in.SecondaryMuscleGroup)),
+		VideoURL:             strings.TrimSpace(in.VideoURL),
+		TrackingType:         strings.TrimSpace(in.TrackingType),
+	}
+}
+
+func (in exerciseInput) validate() []validate.FieldError {
+	return validate.Fields(
+		validate.Required(in.Name, "name"),
+		validate.Required(in.PrimaryMuscleGroup, "primary muscle group"),
+		validate.MaxLen(in.Name, 100, "name"),
+		validate.MaxLen(in.Description, 1000, "description"),
+		validate.MaxLen(in.PrimaryMuscleGroup, 50, "primary muscle group"),
+		validate.MaxLen(in.SecondaryMuscleGroup, 50, "secondary muscle group"),
+		validate.MaxLen(in.VideoURL, 500, "video url"),
+		validate.URL(in.VideoURL, "video url"),
+		func() *validate.FieldError {
+			if _, err := tracking.ParseType(in.TrackingType); err != nil {
+				return &validate.FieldError{Field: "tracking type", Message: "tracking type is not valid"}
+			}
+			return nil
+		},
+	)
+}
+
+// trackingType returns the validated tracking type, defaulting to reps and
+// weight when none was given.
+func (in exerciseInput) trackingType() string {
+	t, err := tracking.ParseType(in.TrackingType)
+	if err != nil {
+		return string(tracking.WeightReps)
+	}
+	return string(t)
+}
+
+// nameTaken reports whether another active exercise visible to ownerID
+// already uses the name. Pass uuid.Nil to check the global catalog only.
+func (h *Handler) nameTaken(ctx context.Context, name string, ownerID, exerciseID uuid.UUID) (bool, error) {
+	existing, err := h.cfg.DB.GetExerciseByName(ctx, database.GetExerciseByNameParams{
+		Name:   name,
+		UserID: ownerID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return existing.ID != exerciseID, nil
+}
+
+func optionalString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// mergeExercises re-points every workouts_exercises row, personal record, goal and
+// program exercise from source to target and then deletes source, so logged history is kept
+// under the surviving exercise.
+func (h *Handler) mergeExercises(ctx context.Context, sourceID, targetID uuid.UUID) (database.Exercise, error) {
+	if sourceID == targetID {
+		return database.Exercise{}, errMergeSameExercise
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Exercise{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	source, err := qtx.GetExerciseByID(ctx, sourceID)
+	if err != nil {
+		return database.Exercise{}, err
+	}
+	target, err := qtx.GetExerciseByID(ctx, targetID)
+	if err != nil {
+		return database.Exercise{}, err
+	}
+	if source.UserID.Valid || target.UserID.Valid {
+		return database.Exercise{}, errMergeCustom
+	}
+	if target.RetiredAt.Valid {
+		return database.Exercise{}, errMergeTargetRetired
+	}
+
+	err = qtx.ReassignWorkoutExercises(ctx, database.ReassignWorkoutExercisesParams{
+		TargetID: targetID,
+		SourceID: sourceID,
+	})
+	if err != nil {
+		return database.Exercise{}, err
+	}
+
+	err = qtx.ReassignPersonalRecords(ctx, database.ReassignPersonalRecordsParams{
+		TargetID: targetID,
+		SourceID: sourceID,
+	})
+	if err != nil {
+		return database.Exercise{}, err
+	}
+
+	err = qtx.ReassignProgramDayExercises(ctx, database.ReassignProgramDayExercisesParams{
+		TargetID: targetID,
+		SourceID: sourceID,
+	})
+	if err != nil {
+		return database.Exercise{}, err
+	}
+
+	err = qtx.ReassignGoalExercises(ctx, database.ReassignGoalExercisesParams{
+		TargetID: uuid.NullUUID{UUID: targetID, Valid: true},
+		SourceID: uuid.NullUUID{UUID: sourceID, Valid: true},
+	})
+	if err != nil {
+		return database.Exercise{}, err
+	}
+
+	if err := qtx.DeleteExercise(ctx, sourceID); err != nil {
+		return database.Exercise{}, err
+	}
+
+	return target, tx.Commit()
+}
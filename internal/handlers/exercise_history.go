@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/effort"
+	"github.com/kairos4213/fithub/internal/strength"
+	"github.com/kairos4213/fithub/internal/templates"
+)
+
+// exerciseHistory collects every session in which the user completed sets of
+// an exercise, oldest first, with volume, the best estimated one-rep max and
+// the effort logged for each set.
+func (h *Handler) exerciseHistory(ctx context.Context, userID, exerciseID uuid.UUID, formula strength.Formula) (templates.ExerciseHistory, error) {
+	rows, err := h.cfg.DB.GetExerciseHistory(ctx, database.GetExerciseHistoryParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return templates.ExerciseHistory{}, err
+	}
+
+	details, err := h.cfg.DB.GetExerciseSetDetails(ctx, database.GetExerciseSetDetailsParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return templates.ExerciseHistory{}, err
+	}
+	efforts := effortByWorkoutExercise(details)
+
+	system, err := h.unitSystem(ctx, userID)
+	if err != nil {
+		return templates.ExerciseHistory{}, err
+	}
+
+	history := templates.ExerciseHistory{Formula: formula, Units: system}
+	for _, row := range rows {
+		sets := strength.Sets(row.RepsPerSetCompleted, row.WeightsCompletedLbs)
+		if len(sets) == 0 {
+			continue
+		}
+
+		// strength.Sets skips sets without reps, so line efforts up the same way
+		var setEfforts []effort.Set
+		for i, reps := range row.RepsPerSetCompleted {
+			if reps > 0 {
+				setEfforts = append(setEfforts, effort.At(efforts[row.ID], i))
+			}
+		}
+
+		_, oneRM := strength.BestOneRM(sets, formula)
+		history.Sessions = append(history.Sessions, templates.ExerciseSession{
+			WorkoutID: row.WorkoutID,
+			Title:     row.Title,
+			Date:      row.SessionDate,
+			Sets:      sets,
+			Efforts:   setEfforts,
+			Volume:    strength.Volume(sets),
+			OneRM:     oneRM,
+		})
+	}
+	return history, nil
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+// parseMilestone validates a milestone's name and target date.
+func parseMilestone(name, targetDate string) (string, time.Time, []validate.FieldError) {
+	name = strings.TrimSpace(name)
+	if errs := validate.Fields(
+		validate.Required(name, "milestone name"),
+		validate.Required(targetDate, "milestone date"),
+		validate.MaxLen(name, 100, "milestone name"),
+	); errs != nil {
+		return "", time.Time{}, errs
+	}
+
+	date, err := time.Parse(time.DateOnly, targetDate)
+	if err != nil {
+		return "", time.Time{}, []validate.FieldError{{Field: "milestone date", Message: "milestone date must be in YYYY-MM-DD format"}}
+	}
+	return name, date, nil
+}
+
+// parseCheckin validates a check-in's date and note. A blank date is today;
+// dates after today are rejected, allowing a day for time zones ahead of UTC.
+func parseCheckin(date, note string, now time.Time) (time.Time, string, []validate.FieldError) {
+	note = strings.TrimSpace(note)
+	if errs := validate.Fields(
+		validate.Required(note, "check-in note"),
+		validate.MaxLen(note, 500, "check-in note"),
+	); errs != nil {
+		return time.Time{}, "", errs
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	if strings.TrimSpace(date) == "" {
+		return today, note, nil
+	}
+	day, err := time.Parse(time.DateOnly, strings.TrimSpace(date))
+	if err != nil {
+		return time.Time{}, "", []validate.FieldError{{Field: "check-in date", Message: "check-in date must be in YYYY-MM-DD format"}}
+	}
+	if day.After(today.AddDate(0, 0, 1)) {
+		return time.Time{}, "", []validate.FieldError{{Field: "check-in date", Message: "check-in date cannot be in the future"}}
+	}
+	return day, note, nil
+}
+
+// reorderGoalMilestones numbers a goal's milestones in the order given.
+func (h *Handler) reorderGoalMilestones(ctx context.Context, userID, goalID uuid.UUID, ids []uuid.UUID) error {
+	for index, id := range ids {
+		err := h.cfg.DB.UpdateGoalMilestoneSortOrder(ctx, database.UpdateGoalMilestoneSortOrderParams{
+			SortOrder: int32(index + 1),
+			ID:        id,
+			GoalID:    goalID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// goalDetails gathers what the goals page shows with each goal: its target's
+// progress, its milestones and its check-ins.
+func (h *Handler) goalDetails(ctx context.Context, userID uuid.UUID, goals []database.Goal) (templates.GoalDetails, error) {
+	targets, err := h.goalTargets(ctx, userID, goals)
+	if err != nil {
+		return templates.GoalDetails{}, err
+	}
+	milestones, err := h.cfg.DB.GetUserGoalMilestones(ctx, userID)
+	if err != nil {
+		return templates.GoalDetails{}, err
+	}
+	checkins, err := h.cfg.DB.GetUserGoalCheckins(ctx, userID)
+	if err != nil {
+		return templates.GoalDetails{}, err
+	}
+
+	details := templates.GoalDetails{
+		Targets:    targets,
+		Milestones: map[uuid.UUID][]database.GoalMilestone{},
+		Checkins:   map[uuid.UUID][]database.GoalCheckin{},
+	}
+	for _, m := range milestones {
+		details.Milestones[m.GoalID] = append(details.Milestones[m.GoalID], m)
+	}
+	for _, c := range checkins {
+		details.Checkins[c.GoalID] = append(details.Checkins[c.GoalID], c)
+	}
+	return details, nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/goalstatus"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+// parseGoalStatus validates a goal's status and the reason it was paused or
+// abandoned. A reason given with any other status is dropped.
+func parseGoalStatus(status, reason string) (goalstatus.Status, sql.NullString, []validate.FieldError) {
+	st, err := goalstatus.Parse(status)
+	if err != nil {
+		return "", sql.NullString{}, []validate.FieldError{{Field: "status", Message: err.Error()}}
+	}
+	reason = strings.TrimSpace(reason)
+	if errs := validate.Fields(validate.MaxLen(reason, goalstatus.MaxReason, "status reason")); errs != nil {
+		return "", sql.NullString{}, errs
+	}
+	if !st.TakesReason() || reason == "" {
+		return st, sql.NullString{}, nil
+	}
+	return st, sql.NullString{String: reason, Valid: true}, nil
+}
+
+// goalCompletionDate is when a goal saved with status was completed. A goal
+// that was already completed keeps its date.
+func goalCompletionDate(goal database.Goal, status goalstatus.Status) sql.NullTime {
+	if status != goalstatus.Completed {
+		return sql.NullTime{}
+	}
+	if goalstatus.Status(goal.Status) == goalstatus.Completed && goal.CompletionDate.Valid {
+		return goal.CompletionDate
+	}
+	return sql.NullTime{Time: time.Now().UTC(), Valid: true}
+}
+
+// goalsInView lists the user's goals in a view of the goals page.
+func (h *Handler) goalsInView(ctx context.Context, userID uuid.UUID, view goalstatus.View) ([]database.Goal, error) {
+	switch view {
+	case goalstatus.OverdueView:
+		return h.cfg.DB.GetOverdueGoals(ctx, database.GetOverdueGoalsParams{
+			UserID: userID,
+			Today:  goalstatus.Today(time.Now()),
+		})
+	case goalstatus.View(goalstatus.Paused):
+		return h.cfg.DB.GetPausedGoals(ctx, userID)
+	case goalstatus.View(goalstatus.Completed):
+		return h.cfg.DB.GetCompletedGoals(ctx, userID)
+	case goalstatus.View(goalstatus.Abandoned):
+		return h.cfg.DB.GetAbandonedGoals(ctx, userID)
+	}
+	return h.cfg.DB.GetInProgressGoals(ctx, userID)
+}
@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/goalstatus"
+	"github.com/kairos4213/fithub/internal/target"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+// goalTargetInput is the optional target of a goal as entered: the metric's
+// slug or the lift's name, and the value in the user's unit.
+type goalTargetInput struct {
+	Kind      string
+	Metric    string
+	Exercise  string
+	Direction string
+	Value     string
+}
+
+// goalTargetParams is a parsed target as the goal columns store it.
+type goalTargetParams struct {
+	Kind         sql.NullString
+	MetricTypeID uuid.NullUUID
+	ExerciseID   uuid.NullUUID
+	Direction    sql.NullString
+	Value        sql.NullString
+	Start        sql.NullString
+}
+
+// parseGoalTarget resolves a goal's target and records where the user stands
+// now as its starting point. A blank kind is a goal without a target.
+func (h *Handler) parseGoalTarget(ctx context.Context, userID uuid.UUID, in goalTargetInput, system units.System) (goalTargetParams, []validate.FieldError, error) {
+	kind, err := target.ParseKind(in.Kind)
+	if err != nil {
+		return goalTargetParams{}, []validate.FieldError{{Field: "target", Message: err.Error()}}, nil
+	}
+	if kind == "" {
+		return goalTargetParams{}, nil, nil
+	}
+	direction, err := target.ParseDirection(in.Direction, kind)
+	if err != nil {
+		return goalTargetParams{}, []validate.FieldError{{Field: "target direction", Message: err.Error()}}, nil
+	}
+
+	goal := target.Goal{Kind: kind, Direction: direction}
+	params := goalTargetParams{
+		Kind:      sql.NullString{String: string(kind), Valid: true},
+		Direction: sql.NullString{String: string(direction), Valid: true},
+	}
+	switch kind {
+	case target.Metric:
+		mt, err := h.lookupMetricType(ctx, userID, in.Metric)
+		if errors.Is(err, errUnknownMetric) {
+			return goalTargetParams{}, []validate.FieldError{{Field: "target metric", Message: "choose a metric to track"}}, nil
+		}
+		if err != nil {
+			return goalTargetParams{}, nil, err
+		}
+		goal.Metric = metricTypeFrom(mt)
+		params.MetricTypeID = uuid.NullUUID{UUID: mt.ID, Valid: true}
+	case target.OneRepMax:
+		name := strings.TrimSpace(in.Exercise)
+		exercise, err := h.cfg.DB.GetExerciseByName(ctx, database.GetExerciseByNameParams{Name: name, UserID: userID})
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && tracking.Type(exercise.TrackingType) != tracking.WeightReps) {
+			return goalTargetParams{}, []validate.FieldError{{Field: "target exercise", Message: "choose an exercise tracked by weight and reps"}}, nil
+		}
+		if err != nil {
+			return goalTargetParams{}, nil, err
+		}
+		goal.Exercise = exercise.Name
+		params.ExerciseID = uuid.NullUUID{UUID: exercise.ID, Valid: true}
+	}
+
+	value, err := goal.ParseValue(in.Value, system)
+	if err != nil {
+		return goalTargetParams{}, []validate.FieldError{{Field: "target value", Message: err.Error()}}, nil
+	}
+	params.Value = sql.NullString{String: value, Valid: true}
+
+	current, ok, err := h.currentTargetValue(ctx, userID, kind, params.MetricTypeID, params.ExerciseID)
+	if err != nil {
+		return goalTargetParams{}, nil, err
+	}
+	if ok && kind != target.WorkoutsPerWeek {
+		params.Start = sql.NullString{String: strconv.FormatFloat(current, 'f', 2, 64), Valid: true}
+	}
+	return params, nil, nil
+}
+
+// parseGoalTargetValue reads a new value for a goal's existing target.
+func (h *Handler) parseGoalTargetValue(ctx context.Context, userID uuid.UUID, goal database.Goal, value string, system units.System) (sql.NullString, []validate.FieldError, error) {
+	if !goal.TargetKind.Valid || strings.TrimSpace(value) == "" {
+		return sql.NullString{}, nil, nil
+	}
+	t, _, err := h.goalTarget(ctx, goal)
+	if err != nil {
+		return sql.NullString{}, nil, err
+	}
+	stored, err := t.ParseValue(value, system)
+	if err != nil {
+		return sql.NullString{}, []validate.FieldError{{Field: "target value", Message: err.Error()}}, nil
+	}
+	return sql.NullString{String: stored, Valid: true}, nil, nil
+}
+
+// currentTargetValue is where the user stands on a target: their latest
+// measurement, their best estimated 1RM in pounds or the workouts they've
+// finished in the last week. ok is false when there's nothing logged yet.
+func (h *Handler) currentTargetValue(ctx context.Context, userID uuid.UUID, kind target.Kind, metricTypeID, exerciseID uuid.NullUUID) (float64, bool, error) {
+	switch kind {
+	case target.Metric:
+		value, err := h.cfg.DB.GetLatestMeasurementValue(ctx, database.GetLatestMeasurementValueParams{
+			UserID:       userID,
+			MetricTypeID: metricTypeID.UUID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		return f, err == nil, err
+	case target.OneRepMax:
+		best, err := h.cfg.DB.GetBestEstimatedOneRM(ctx, database.GetBestEstimatedOneRMParams{
+			UserID:     userID,
+			ExerciseID: exerciseID.UUID,
+		})
+		return best, best > 0, err
+	}
+	count, err := h.cfg.DB.CountWorkoutsCompletedSince(ctx, database.CountWorkoutsCompletedSinceParams{
+		UserID:        userID,
+		DateCompleted: sql.NullTime{Time: time.Now().UTC().Add(-target.Week), Valid: true},
+	})
+	return float64(count), true, err
+}
+
+// goalTarget resolves what a goal's target follows. ok is false for goals
+// without a target, or whose metric type or exercise has since been deleted.
+func (h *Handler) goalTarget(ctx context.Context, goal database.Goal) (target.Goal, bool, error) {
+	if !goal.TargetKind.Valid {
+		return target.Goal{}, false, nil
+	}
+	t := target.Goal{
+		Kind:      target.Kind(goal.TargetKind.String),
+		Direction: target.Direction(goal.TargetDirection.String),
+	}
+	t.Progress.Target, _ = strconv.ParseFloat(goal.TargetValue.String, 64)
+
+	switch t.Kind {
+	case target.Metric:
+		if !goal.MetricTypeID.Valid {
+			return target.Goal{}, false, nil
+		}
+		mt, err := h.cfg.DB.GetMetricTypeByID(ctx, goal.MetricTypeID.UUID)
+		if err != nil {
+			return target.Goal{}, false, err
+		}
+		t.Metric = metricTypeFrom(mt)
+	case target.OneRepMax:
+		if !goal.ExerciseID.Valid {
+			return target.Goal{}, false, nil
+		}
+		exercise, err := h.cfg.DB.GetExerciseByID(ctx, goal.ExerciseID.UUID)
+		if err != nil {
+			return target.Goal{}, false, err
+		}
+		t.Exercise = exercise.Name
+	}
+	return t, true, nil
+}
+
+// measureGoal computes a goal's progress toward its target. Completed goals
+// aren't measured again; they met their target when they were completed. A
+// goal set before anything was logged starts from the first value logged.
+func (h *Handler) measureGoal(ctx context.Context, userID uuid.UUID, goal database.Goal) (target.Goal, bool, error) {
+	t, ok, err := h.goalTarget(ctx, goal)
+	if err != nil || !ok {
+		return t, ok, err
+	}
+	if goalstatus.Status(goal.Status) == goalstatus.Completed {
+		t.Progress = target.Progress{Target: t.Progress.Target, Percent: 100, Met: true}
+		return t, true, nil
+	}
+
+	current, measured, err := h.currentTargetValue(ctx, userID, t.Kind, goal.MetricTypeID, goal.ExerciseID)
+	if err != nil || !measured {
+		return t, true, err
+	}
+	start := current
+	switch {
+	case t.Kind == target.WorkoutsPerWeek:
+		// The count starts again from zero every week
+		start = 0
+	case goal.StartValue.Valid:
+		start, _ = strconv.ParseFloat(goal.StartValue.String, 64)
+	default:
+		err = h.cfg.DB.SetGoalStartValue(ctx, database.SetGoalStartValueParams{
+			StartValue: sql.NullString{String: strconv.FormatFloat(current, 'f', 2, 64), Valid: true},
+			ID:         goal.ID,
+			UserID:     userID,
+		})
+		if err != nil {
+			return t, true, err
+		}
+	}
+	t.Progress = target.Measure(start, current, t.Progress.Target, t.Direction)
+	t.Measured = true
+	return t, true, nil
+}
+
+// goalTargets measures every goal with a target, keyed by goal.
+func (h *Handler) goalTargets(ctx context.Context, userID uuid.UUID, goals []database.Goal) (map[uuid.UUID]target.Goal, error) {
+	targets := map[uuid.UUID]target.Goal{}
+	for _, goal := range goals {
+		t, ok, err := h.measureGoal(ctx, userID, goal)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			targets[goal.ID] = t
+		}
+	}
+	return targets, nil
+}
+
+// completeMetGoals completes every in-progress goal whose target has been met
+// and returns their ids. It runs after anything a target follows is logged.
+func (h *Handler) completeMetGoals(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, error) {
+	goals, err := h.cfg.DB.GetTrackedGoals(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var completed []uuid.UUID
+	for _, goal := range goals {
+		t, ok, err := h.measureGoal(ctx, userID, goal)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !t.Progress.Met {
+			continue
+		}
+		err = h.cfg.DB.CompleteGoal(ctx, database.CompleteGoalParams{
+			CompletionDate: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+			ID:             goal.ID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		completed = append(completed, goal.ID)
+	}
+	return completed, nil
+}
+
+// checkGoals completes any goals met by something just logged. The log is
+// already saved, so a failure is only recorded; the goals page checks again.
+func (h *Handler) checkGoals(ctx context.Context, userID uuid.UUID) {
+	if _, err := h.completeMetGoals(ctx, userID); err != nil {
+		h.cfg.Logger.Error("failed to complete met goals", slog.String("error", err.Error()))
+	}
+}
@@ -14,11 +14,12 @@ import (
 )
 
 type Handler struct {
-	cfg *config.Config
+	cfg   *config.Config
+	oauth *oauthRegistry
 }
 
 func New(cfg *config.Config) *Handler {
-	return &Handler{cfg: cfg}
+	return &Handler{cfg: cfg, oauth: newOAuthRegistry(cfg.OAuth)}
 }
 
 // issueSessionTokens creates a JWT access token and refresh token, stores the
@@ -0,0 +1,301 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+const (
+	adminActivityDays   = 14
+	adminRecentSignups  = 10
+	adminSearchMaxUsers = 50
+)
+
+func (h *Handler) GetAdminDashboard(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.cfg.DB.GetUserCounts(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user counts", slog.String("error", err.Error()))
+		return
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	since := today.AddDate(0, 0, -(adminActivityDays - 1))
+	perDay, err := h.cfg.DB.GetCompletedWorkoutsPerDay(r.Context(), sql.NullTime{Time: since, Valid: true})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get completed workouts per day", slog.String("error", err.Error()))
+		return
+	}
+
+	recent, err := h.cfg.DB.GetRecentUsers(r.Context(), adminRecentSignups)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get recent users", slog.String("error", err.Error()))
+		return
+	}
+
+	// Fill in days with no completed workouts so the chart has no gaps
+	totals := make(map[string]int64, len(perDay))
+	for _, d := range perDay {
+		totals[d.Day.Format(time.DateOnly)] = d.Total
+	}
+	data := templates.AdminDashboardData{Counts: counts, RecentSignups: recent, MaxPerDay: 1}
+	for day := since; !day.After(today); day = day.AddDate(0, 0, 1) {
+		total := totals[day.Format(time.DateOnly)]
+		data.WorkoutsPerDay = append(data.WorkoutsPerDay, templates.AdminDayCount{Day: day, Total: total})
+		data.MaxPerDay = max(data.MaxPerDay, total)
+	}
+
+	contents := templates.AdminDashboardPage(data)
+	err = templates.Layout(contents, "FitHub | Admin", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin dashboard", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if errs := validate.Fields(validate.MaxLen(query, 100, "q")); errs != nil {
+		HandleBadRequest(w, r, errs[0].Error())
+		return
+	}
+
+	var users []database.User
+	var err error
+	if query == "" {
+		users, err = h.cfg.DB.GetRecentUsers(r.Context(), adminSearchMaxUsers)
+	} else {
+		users, err = h.cfg.DB.SearchUsers(r.Context(), database.SearchUsersParams{
+			Query:      escapeLike(query),
+			MaxResults: adminSearchMaxUsers,
+		})
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to search users", slog.String("error", err.Error()))
+		return
+	}
+
+	// HTMX search — return just the results fragment
+	if r.Header.Get("HX-Target") == "admin-users-results" {
+		err = templates.AdminUsersResults(users).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render admin user results", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	contents := templates.AdminUsersPage(query, users)
+	err = templates.Layout(contents, "FitHub | Admin Users", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin users page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) GetAdminUser(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid user id")
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	workoutCount, err := h.cfg.DB.CountUserWorkouts(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to count user workouts", slog.String("error", err.Error()))
+		return
+	}
+
+	providers, err := h.cfg.DB.GetAuthProvidersByUserID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user auth providers", slog.String("error", err.Error()))
+		return
+	}
+
+	twoFactor, err := h.twoFactorEnabled(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get two-factor status", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.AdminUserPage(templates.AdminUserData{
+		User:         user,
+		WorkoutCount: workoutCount,
+		Providers:    providers,
+		IsSelf:       user.ID == adminID,
+		TwoFactor:    twoFactor,
+	})
+	err = templates.Layout(contents, "FitHub | Admin User", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin user page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+// DisableUserAccount blocks a user from logging in and revokes their refresh
+// tokens so existing sessions end once the current access token expires.
+func (h *Handler) DisableUserAccount(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid user id")
+		return
+	}
+
+	if userID == adminID {
+		HandleBadRequest(w, r, "you cannot disable your own account")
+		return
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(r.Context(), nil)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to begin transaction", slog.String("error", err.Error()))
+		return
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	if err := qtx.DisableUser(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to disable user", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := qtx.RevokeUserRefreshTokens(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to revoke user refresh tokens", slog.String("error", err.Error()))
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to commit transaction", slog.String("error", err.Error()))
+		return
+	}
+
+	h.cfg.Logger.Info("user account disabled", slog.String("user_id", userID.String()), slog.String("admin_id", adminID.String()))
+	h.renderAdminUserStatus(w, r, userID, adminID)
+}
+
+func (h *Handler) EnableUserAccount(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid user id")
+		return
+	}
+
+	if err := h.cfg.DB.EnableUser(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to enable user", slog.String("error", err.Error()))
+		return
+	}
+
+	h.cfg.Logger.Info("user account enabled", slog.String("user_id", userID.String()), slog.String("admin_id", adminID.String()))
+	h.renderAdminUserStatus(w, r, userID, adminID)
+}
+
+// ResetUserTwoFactor removes a user's second factor and recovery codes so
+// they can log in with their password alone and set it up again.
+func (h *Handler) ResetUserTwoFactor(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid user id")
+		return
+	}
+
+	if err := h.removeTwoFactor(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to reset two-factor", slog.String("error", err.Error()))
+		return
+	}
+
+	h.cfg.Logger.Info("user two-factor reset", slog.String("user_id", userID.String()), slog.String("admin_id", adminID.String()))
+	err = templates.AdminUserTwoFactor(userID, false).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin user two-factor", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) renderAdminUserStatus(w http.ResponseWriter, r *http.Request, userID, adminID uuid.UUID) {
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.AdminUserStatus(user, user.ID == adminID).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin user status", slog.String("error", err.Error()))
+		return
+	}
+}
+
+// likeEscaper escapes LIKE's wildcards so a search matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
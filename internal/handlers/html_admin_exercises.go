@@ -0,0 +1,316 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var adminExerciseFields = []string{"name", "description", "primary-muscle-group", "secondary-muscle-group", "tracking-type", "video-url"}
+
+func (h *Handler) GetAdminExercises(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+	if errs := validate.Fields(validate.MaxLen(query, 100, "q")); errs != nil {
+		HandleBadRequest(w, r, errs[0].Error())
+		return
+	}
+
+	exercises, err := h.cfg.DB.GetCatalogExercises(r.Context(), query)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get catalog exercises", slog.String("error", err.Error()))
+		return
+	}
+
+	// HTMX search — return just the results fragment
+	if r.Header.Get("HX-Target") == "admin-exercises-results" {
+		err = templates.AdminExercisesResults(exercises).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render admin exercise results", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	muscleGroups, err := h.muscleGroups(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get muscle groups", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.AdminExercisesPage(query, exercises, muscleGroups)
+	err = templates.Layout(contents, "FitHub | Admin Exercises", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin exercises page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) CreateAdminExercise(w http.ResponseWriter, r *http.Request) {
+	input := exerciseInputFromForm(r)
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, adminExerciseFields, "")
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, uuid.Nil, uuid.Nil)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to check exercise name", slog.String("error", err.Error()))
+		return
+	}
+	if taken {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "name", Message: "exercise already exists"}}, adminExerciseFields, "")
+		return
+	}
+
+	exercise, err := h.cfg.DB.CreateExercise(r.Context(), database.CreateExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		VideoUrl:             optionalString(input.VideoURL),
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": "/admin/exercises/%v"}`, exercise.ID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) GetAdminExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	exercise, err := h.cfg.DB.GetExerciseByID(r.Context(), exerciseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to fetch exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	usage, err := h.cfg.DB.CountExerciseUsage(r.Context(), exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to count exercise usage", slog.String("error", err.Error()))
+		return
+	}
+
+	muscleGroups, err := h.muscleGroups(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get muscle groups", slog.String("error", err.Error()))
+		return
+	}
+
+	catalog, err := h.cfg.DB.GetCatalogExercises(r.Context(), "")
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get catalog exercises", slog.String("error", err.Error()))
+		return
+	}
+	mergeTargets := []database.Exercise{}
+	for _, e := range catalog {
+		if e.ID != exercise.ID && !e.RetiredAt.Valid {
+			mergeTargets = append(mergeTargets, e)
+		}
+	}
+
+	contents := templates.AdminExercisePage(exercise, usage, muscleGroups, mergeTargets)
+	err = templates.Layout(contents, "FitHub | Admin Exercise", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin exercise page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) UpdateAdminExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	input := exerciseInputFromForm(r)
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, adminExerciseFields, "")
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, uuid.Nil, exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to check exercise name", slog.String("error", err.Error()))
+		return
+	}
+	if taken {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "name", Message: "exercise already exists"}}, adminExerciseFields, "")
+		return
+	}
+
+	_, err = h.cfg.DB.UpdateExercise(r.Context(), database.UpdateExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		VideoUrl:             optionalString(input.VideoURL),
+		ID:                   exerciseID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderAdminExerciseCard(w, r, exerciseID)
+}
+
+// RetireAdminExercise hides an exercise from search, listings and templates
+// while leaving it attached to workouts that already logged it.
+func (h *Handler) RetireAdminExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	if _, err := h.cfg.DB.RetireExercise(r.Context(), exerciseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to retire exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderAdminExerciseCard(w, r, exerciseID)
+}
+
+func (h *Handler) RestoreAdminExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	if _, err := h.cfg.DB.RestoreExercise(r.Context(), exerciseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to restore exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderAdminExerciseCard(w, r, exerciseID)
+}
+
+func (h *Handler) MergeAdminExercise(w http.ResponseWriter, r *http.Request) {
+	sourceID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	targetID, err := uuid.Parse(r.FormValue("target-id"))
+	if err != nil {
+		HandleBadRequest(w, r, "select an exercise to merge into")
+		return
+	}
+
+	target, err := h.mergeExercises(r.Context(), sourceID, targetID)
+	if err != nil {
+		if errors.Is(err, errMergeSameExercise) || errors.Is(err, errMergeTargetRetired) || errors.Is(err, errMergeCustom) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "exercise not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to merge exercises", slog.String("error", err.Error()))
+		return
+	}
+
+	h.cfg.Logger.Info("exercises merged", slog.String("source_id", sourceID.String()), slog.String("target_id", targetID.String()))
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": "/admin/exercises/%v"}`, target.ID))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) renderAdminExerciseCard(w http.ResponseWriter, r *http.Request, exerciseID uuid.UUID) {
+	exercise, err := h.cfg.DB.GetExerciseByID(r.Context(), exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to fetch exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	usage, err := h.cfg.DB.CountExerciseUsage(r.Context(), exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to count exercise usage", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.AdminExerciseCard(exercise, usage).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render admin exercise card", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) muscleGroups(ctx context.Context) ([]string, error) {
+	groups, err := h.cfg.DB.GetAllMuscleGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, 0, len(groups))
+	for _, g := range groups {
+		out = append(out, g.String)
+	}
+	return out, nil
+}
+
+func exerciseInputFromForm(r *http.Request) exerciseInput {
+	return exerciseInput{
+		Name:                 r.FormValue("name"),
+		Description:          r.FormValue("description"),
+		PrimaryMuscleGroup:   r.FormValue("primary-muscle-group"),
+		SecondaryMuscleGroup: r.FormValue("secondary-muscle-group"),
+		VideoURL:             r.FormValue("video-url"),
+		TrackingType:         r.FormValue("tracking-type"),
+	}.normalize()
+}
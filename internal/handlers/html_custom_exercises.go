@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var customExerciseFields = []string{"name", "description", "primary-muscle-group", "secondary-muscle-group", "tracking-type"}
+
+func (h *Handler) GetCustomExercisesPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	exercises, err := h.cfg.DB.GetUserCustomExercises(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get custom exercises", slog.String("error", err.Error()))
+		return
+	}
+
+	muscleGroups, err := h.muscleGroups(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get muscle groups", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.CustomExercisesPage(exercises, muscleGroups)
+	err = templates.Layout(contents, "FitHub | My Exercises", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render custom exercises page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) CreateCustomExercise(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	input := customExerciseInputFromForm(r)
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, customExerciseFields, "")
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, userID, uuid.Nil)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to check exercise name", slog.String("error", err.Error()))
+		return
+	}
+	if taken {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "name", Message: "exercise already exists"}}, customExerciseFields, "")
+		return
+	}
+
+	_, err = h.cfg.DB.CreateCustomExercise(r.Context(), database.CreateCustomExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		UserID:               uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create custom exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	exercises, err := h.cfg.DB.GetUserCustomExercises(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get custom exercises", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "close-create-exercise")
+	err = templates.CustomExercisesList(exercises).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render custom exercises list", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) EditCustomExercise(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	prefix := exerciseID.String() + "-"
+	editFields := make([]string, 0, len(customExerciseFields))
+	for _, f := range customExerciseFields {
+		editFields = append(editFields, prefix+f)
+	}
+
+	input := customExerciseInputFromForm(r)
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, editFields, prefix)
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, userID, exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to check exercise name", slog.String("error", err.Error()))
+		return
+	}
+	if taken {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "name", Message: "exercise already exists"}}, editFields, prefix)
+		return
+	}
+
+	exercise, err := h.cfg.DB.UpdateCustomExercise(r.Context(), database.UpdateCustomExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		ID:                   exerciseID,
+		UserID:               uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "exercise not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update custom exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.CustomExerciseCard(exercise).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render custom exercise card", slog.String("error", err.Error()))
+		return
+	}
+}
+
+// DeleteCustomExercise retires rather than deletes, so workouts that already
+// logged the exercise keep their history.
+func (h *Handler) DeleteCustomExercise(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	err = h.cfg.DB.RetireCustomExercise(r.Context(), database.RetireCustomExerciseParams{
+		ID:     exerciseID,
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to retire custom exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// customExerciseInputFromForm reads the same fields as the admin form minus
+// the video URL, which is reserved for the curated catalog.
+func customExerciseInputFromForm(r *http.Request) exerciseInput {
+	return exerciseInput{
+		Name:                 r.FormValue("name"),
+		Description:          r.FormValue("description"),
+		PrimaryMuscleGroup:   r.FormValue("primary-muscle-group"),
+		SecondaryMuscleGroup: r.FormValue("secondary-muscle-group"),
+		TrackingType:         r.FormValue("tracking-type"),
+	}.normalize()
+}
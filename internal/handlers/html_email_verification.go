@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	msg := ""
+	_, err := h.verifyEmail(r.Context(), r.URL.Query().Get("token"))
+	switch {
+	case errors.Is(err, errInvalidVerificationToken):
+		msg = "This verification link is invalid or has expired."
+	case errors.Is(err, errEmailTaken):
+		msg = "That email now belongs to another account."
+	case err != nil:
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to verify email", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.VerifyEmailPage(msg)
+	err = templates.Layout(contents, "FitHub | Verify Email", false).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render verify email page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) EmailVerification(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		contents := templates.EmailVerificationPage(r.URL.Query().Get("sent") == "true")
+		err := templates.Layout(contents, "FitHub | Verify Email", false).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render email verification page", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	email := r.FormValue("email")
+	if errs := validate.Fields(
+		validate.Required(email, "email"),
+		validate.MaxLen(email, 255, "email"),
+	); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"email"}, "")
+		return
+	}
+
+	if err := h.resendEmailVerification(r.Context(), email); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to send email verification", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-type", "text/html")
+	err := templates.EmailVerificationSent().Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render email verification sent", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	email := r.FormValue("email")
+	if errs := validate.Fields(
+		validate.Required(email, "email"),
+		validate.MaxLen(email, 255, "email"),
+	); errs != nil {
+		HandleBadRequest(w, r, errs[0].Error())
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	err = h.changeEmail(r.Context(), user, email)
+	if errors.Is(err, errEmailTaken) {
+		HandleBadRequest(w, r, DuplicateEmailMsg)
+		return
+	}
+	if errors.Is(err, errSameEmail) {
+		HandleBadRequest(w, r, "That's already your email.")
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to send email verification", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderAccountSettings(w, r, userID, "Verification link sent")
+}
+
+func (h *Handler) ResendSettingsVerification(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	err := h.resendUserVerification(r.Context(), userID)
+	if errors.Is(err, errEmailVerified) {
+		HandleBadRequest(w, r, "Your email is already verified.")
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to send email verification", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderAccountSettings(w, r, userID, "Verification link sent")
+}
+
+func (h *Handler) renderAccountSettings(w http.ResponseWriter, r *http.Request, userID uuid.UUID, notice string) {
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	pending, err := h.pendingEmail(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get pending email", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.AccountSettings(user, pending, notice).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render account settings", slog.String("error", err.Error()))
+		return
+	}
+}
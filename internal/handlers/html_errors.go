@@ -10,12 +10,15 @@ import (
 )
 
 const (
-	ServerErrMsg      = "Something went wrong. Please try later"
-	AccessForbidden   = "You don't have permission to access this resource"
-	NoAccessMsg       = "You don't have access to this! Please login, or register!"
-	AccessExpiredMsg  = "Access Expired. Please login."
-	LoginFailMsg      = "Username and/or password are incorrect. Please try again."
-	DuplicateEmailMsg = "That email already exists!"
+	ServerErrMsg       = "Something went wrong. Please try later"
+	AccessForbidden    = "You don't have permission to access this resource"
+	NoAccessMsg        = "You don't have access to this! Please login, or register!"
+	AccessExpiredMsg   = "Access Expired. Please login."
+	LoginFailMsg       = "Username and/or password are incorrect. Please try again."
+	DuplicateEmailMsg  = "That email already exists!"
+	DisabledMsg        = "This account has been disabled. Please contact support."
+	UnverifiedMsg      = "Please verify your email before logging in. Check your inbox for the link we sent."
+	OAuthUnverifiedMsg = "Your sign in provider hasn't verified your email. Please verify it there, or log in with your password."
 )
 
 func HandleInternalServerError(w http.ResponseWriter, r *http.Request) {
@@ -79,6 +82,44 @@ func HandleLoginFailure(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func HandleDisabledLogin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-type", "text/html")
+	w.WriteHeader(http.StatusForbidden)
+
+	htmlErr := templates.HtmlErr{Code: http.StatusForbidden, Msg: DisabledMsg}
+	err := templates.LoginFailure(htmlErr).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		return
+	}
+}
+
+func HandleUnverifiedLogin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-type", "text/html")
+	w.WriteHeader(http.StatusForbidden)
+
+	htmlErr := templates.HtmlErr{Code: http.StatusForbidden, Msg: UnverifiedMsg}
+	err := templates.LoginFailure(htmlErr).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		return
+	}
+}
+
+// HandleOAuthFailure shows why signing in with a provider didn't work. It's a
+// full page, as the provider redirected the browser here.
+func HandleOAuthFailure(w http.ResponseWriter, r *http.Request, errMsg string) {
+	w.Header().Set("Content-type", "text/html")
+	w.WriteHeader(http.StatusForbidden)
+
+	htmlErr := templates.HtmlErr{Code: http.StatusForbidden, Msg: errMsg}
+	err := templates.Layout(templates.ErrorDisplay(htmlErr), "FitHub", false).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		return
+	}
+}
+
 func HandleRegPageEmailAlert(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-type", "text/html")
 	w.WriteHeader(http.StatusConflict)
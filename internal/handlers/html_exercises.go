@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -10,11 +11,22 @@ import (
 	"github.com/google/uuid"
 	"github.com/kairos4213/fithub/internal/cntx"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/strength"
 	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/units"
 	"github.com/kairos4213/fithub/internal/validate"
+	"github.com/sqlc-dev/pqtype"
 )
 
 func (h *Handler) GetExerciseByKeyword(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
 	exerciseSearch := strings.ToLower(r.FormValue("exercise-search"))
 	workoutID, err := uuid.Parse(r.FormValue("workoutID"))
 	if err != nil {
@@ -36,7 +48,10 @@ func (h *Handler) GetExerciseByKeyword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exercises, err := h.cfg.DB.GetExerciseByKeyword(r.Context(), exerciseSearch)
+	exercises, err := h.cfg.DB.GetExerciseByKeyword(r.Context(), database.GetExerciseByKeywordParams{
+		Word:   exerciseSearch,
+		UserID: userID,
+	})
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to fetch searched exercise", slog.String("error", err.Error()))
@@ -76,12 +91,18 @@ func (h *Handler) AddExerciseToWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
 	exerciseName := r.FormValue("exercise-name")
 	reqPlannedSets := r.FormValue("planned-sets")
 
 	if errs := validate.Fields(
 		validate.Required(exerciseName, "exercise name"),
-		validate.Required(reqPlannedSets, "planned sets"),
 		validate.MaxLen(exerciseName, 100, "exercise name"),
 	); errs != nil {
 		HandleBadRequest(w, r, errs[0].Error())
@@ -89,54 +110,100 @@ func (h *Handler) AddExerciseToWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exercise, err := h.cfg.DB.GetExerciseByName(r.Context(), exerciseName)
+	exercise, err := h.cfg.DB.GetExerciseByName(r.Context(), database.GetExerciseByNameParams{
+		Name:   exerciseName,
+		UserID: userID,
+	})
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to fetch exercise by name", slog.String("error", err.Error()))
 		return
 	}
 
-	plannedSets, err := strconv.ParseInt(reqPlannedSets, 10, 32)
-	if err != nil {
-		HandleBadRequest(w, r, "planned sets must be a number")
-		h.cfg.Logger.Info("invalid planned sets input", slog.String("value", reqPlannedSets), slog.String("error", err.Error()))
-		return
+	params := database.AddExerciseToWorkoutParams{
+		WorkoutID:                 workoutID,
+		ExerciseID:                exercise.ID,
+		SetsPlanned:               1,
+		RepsPerSetPlanned:         []int32{1},
+		SetsCompleted:             0,
+		RepsPerSetCompleted:       []int32{},
+		WeightsPlannedLbs:         []int32{0},
+		WeightsCompletedLbs:       []int32{},
+		DurationsPlannedSeconds:   []int32{},
+		DurationsCompletedSeconds: []int32{},
+		DistancesPlannedMeters:    []int32{},
+		DistancesCompletedMeters:  []int32{},
+		HeartRatesCompleted:       []int32{},
 	}
 
-	plannedRepsSlice := r.PostForm["planned-reps[]"]
-	plannedReps := make([]int32, len(plannedRepsSlice))
-	for i, plannedRep := range plannedRepsSlice {
-		rep, err := strconv.ParseInt(plannedRep, 10, 32)
+	timed := tracking.Type(exercise.TrackingType).Timed()
+	if timed {
+		params.RepsPerSetPlanned = []int32{}
+		params.WeightsPlannedLbs = []int32{}
+		params.DurationsPlannedSeconds = []int32{0}
+		params.DistancesPlannedMeters = []int32{0}
+	}
+
+	// Without an explicit plan, prefill from the last completed session.
+	// Suggestions only cover reps and weight, so timed exercises start blank.
+	if reqPlannedSets == "" && !timed {
+		suggestion, err := h.suggestNextSession(r.Context(), userID, exercise.ID)
 		if err != nil {
 			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to parse planned rep", slog.String("error", err.Error()))
+			h.cfg.Logger.Error("failed to suggest next session", slog.String("error", err.Error()))
 			return
 		}
-		plannedReps[i] = int32(rep)
-	}
-
-	plannedWeightsSlice := r.PostForm["planned-weights[]"]
-	plannedWeights := make([]int32, len(plannedWeightsSlice))
-	for i, plannedWeight := range plannedWeightsSlice {
-		weight, err := strconv.ParseInt(plannedWeight, 10, 32)
+		if suggestion.Sets() > 0 {
+			params.SetsPlanned = suggestion.Sets()
+			params.RepsPerSetPlanned = suggestion.Reps
+			params.WeightsPlannedLbs = suggestion.Weights
+			basis, err := json.Marshal(suggestion.Basis)
+			if err != nil {
+				HandleInternalServerError(w, r)
+				h.cfg.Logger.Error("failed to encode suggestion", slog.String("error", err.Error()))
+				return
+			}
+			params.Suggestion = pqtype.NullRawMessage{RawMessage: basis, Valid: true}
+		}
+	} else if reqPlannedSets != "" {
+		plannedSets, err := strconv.ParseInt(reqPlannedSets, 10, 32)
 		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to parse planned weight", slog.String("error", err.Error()))
+			HandleBadRequest(w, r, "planned sets must be a number")
+			h.cfg.Logger.Info("invalid planned sets input", slog.String("value", reqPlannedSets), slog.String("error", err.Error()))
 			return
 		}
-		plannedWeights[i] = int32(weight)
-	}
-
-	workoutExercise, err := h.cfg.DB.AddExerciseToWorkout(r.Context(), database.AddExerciseToWorkoutParams{
-		WorkoutID:           workoutID,
-		ExerciseID:          exercise.ID,
-		SetsPlanned:         int32(plannedSets),
-		RepsPerSetPlanned:   plannedReps,
-		SetsCompleted:       0,
-		RepsPerSetCompleted: []int32{},
-		WeightsPlannedLbs:   plannedWeights,
-		WeightsCompletedLbs: []int32{},
-	})
+		params.SetsPlanned = int32(plannedSets)
+
+		if timed {
+			params.DurationsPlannedSeconds, params.DistancesPlannedMeters, err = plannedTimedSets(r, int(plannedSets), system)
+			if err != nil {
+				HandleBadRequest(w, r, err.Error())
+				h.cfg.Logger.Info("invalid planned timed set input", slog.String("error", err.Error()))
+				return
+			}
+		} else {
+			plannedRepsSlice := r.PostForm["planned-reps[]"]
+			params.RepsPerSetPlanned = make([]int32, len(plannedRepsSlice))
+			for i, plannedRep := range plannedRepsSlice {
+				rep, err := strconv.ParseInt(plannedRep, 10, 32)
+				if err != nil {
+					HandleInternalServerError(w, r)
+					h.cfg.Logger.Error("failed to parse planned rep", slog.String("error", err.Error()))
+					return
+				}
+				params.RepsPerSetPlanned[i] = int32(rep)
+			}
+
+			params.WeightsPlannedLbs, err = system.ParseWeights(r.PostForm["planned-weights[]"])
+			if err != nil {
+				HandleBadRequest(w, r, "planned weights must be numbers")
+				h.cfg.Logger.Info("invalid planned weight input", slog.String("error", err.Error()))
+				return
+			}
+		}
+	}
+
+	workoutExercise, err := h.cfg.DB.AddExerciseToWorkout(r.Context(), params)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to add exercise to workout", slog.String("error", err.Error()))
@@ -148,7 +215,7 @@ func (h *Handler) AddExerciseToWorkout(w http.ResponseWriter, r *http.Request) {
 		Exercise:         exercise,
 	}
 
-	err = templates.WorkoutExerciseCard(exerciseForWorkout).Render(r.Context(), w)
+	err = templates.WorkoutExerciseCard(exerciseForWorkout, nil, "", nil, system).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render workout exercise card", slog.String("error", err.Error()))
@@ -190,6 +257,13 @@ func (h *Handler) UpdateWorkoutExercise(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
 	reqPlannedSets := r.FormValue("planned-sets")
 	reqCompletedSets := r.FormValue("completed-sets")
 
@@ -221,16 +295,11 @@ func (h *Handler) UpdateWorkoutExercise(w http.ResponseWriter, r *http.Request)
 		plannedReps[i] = int32(rep)
 	}
 
-	plannedWeightsSlice := r.PostForm["planned-weights[]"]
-	plannedWeights := make([]int32, len(plannedWeightsSlice))
-	for i, plannedWeight := range plannedWeightsSlice {
-		weight, err := strconv.ParseInt(plannedWeight, 10, 32)
-		if err != nil {
-			HandleBadRequest(w, r, "planned weights must be numbers")
-			h.cfg.Logger.Info("invalid planned weight input", slog.String("value", plannedWeight), slog.String("error", err.Error()))
-			return
-		}
-		plannedWeights[i] = int32(weight)
+	plannedWeights, err := system.ParseWeights(r.PostForm["planned-weights[]"])
+	if err != nil {
+		HandleBadRequest(w, r, "planned weights must be numbers")
+		h.cfg.Logger.Info("invalid planned weight input", slog.String("error", err.Error()))
+		return
 	}
 
 	completedSets, err := strconv.ParseInt(reqCompletedSets, 10, 32)
@@ -252,28 +321,56 @@ func (h *Handler) UpdateWorkoutExercise(w http.ResponseWriter, r *http.Request)
 		completedReps[i] = int32(rep)
 	}
 
-	completedWeightsSlice := r.PostForm["completed-weights[]"]
-	completedWeights := make([]int32, len(completedWeightsSlice))
-	for i, completedWeight := range completedWeightsSlice {
-		weight, err := strconv.ParseInt(completedWeight, 10, 32)
-		if err != nil {
-			HandleBadRequest(w, r, "completed weights must be numbers")
-			h.cfg.Logger.Info("invalid completed weight input", slog.String("value", completedWeight), slog.String("error", err.Error()))
-			return
-		}
-		completedWeights[i] = int32(weight)
+	completedWeights, err := system.ParseWeights(r.PostForm["completed-weights[]"])
+	if err != nil {
+		HandleBadRequest(w, r, "completed weights must be numbers")
+		h.cfg.Logger.Info("invalid completed weight input", slog.String("error", err.Error()))
+		return
+	}
+
+	plannedDurations, plannedDistances, err := plannedTimedSets(r, int(plannedSets), system)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		h.cfg.Logger.Info("invalid planned timed set input", slog.String("error", err.Error()))
+		return
+	}
+	completedDurations, completedDistances, completedHeartRates, err := completedTimedSets(r, system)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		h.cfg.Logger.Info("invalid completed timed set input", slog.String("error", err.Error()))
+		return
+	}
+
+	completed := len(completedReps)
+	if tracking.Type(exercise.TrackingType).Timed() {
+		completed = len(completedDurations)
+	} else {
+		// Only timed exercises keep a plan by duration
+		plannedDurations, plannedDistances = []int32{}, []int32{}
+	}
+
+	efforts, err := effortFromForm(r, completed)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		h.cfg.Logger.Info("invalid set effort input", slog.String("error", err.Error()))
+		return
 	}
 
 	updatedWorkoutExercise, err := h.cfg.DB.UpdateWorkoutExercise(r.Context(), database.UpdateWorkoutExerciseParams{
-		SetsPlanned:         int32(plannedSets),
-		RepsPerSetPlanned:   plannedReps,
-		SetsCompleted:       int32(completedSets),
-		RepsPerSetCompleted: completedReps,
-		WeightsPlannedLbs:   plannedWeights,
-		WeightsCompletedLbs: completedWeights,
-		ID:                  workoutExerciseID,
-		WorkoutID:           workoutID,
-		UserID:              userID,
+		SetsPlanned:               int32(plannedSets),
+		RepsPerSetPlanned:         plannedReps,
+		SetsCompleted:             int32(completedSets),
+		RepsPerSetCompleted:       completedReps,
+		WeightsPlannedLbs:         plannedWeights,
+		WeightsCompletedLbs:       completedWeights,
+		DurationsPlannedSeconds:   plannedDurations,
+		DurationsCompletedSeconds: completedDurations,
+		DistancesPlannedMeters:    plannedDistances,
+		DistancesCompletedMeters:  completedDistances,
+		HeartRatesCompleted:       completedHeartRates,
+		ID:                        workoutExerciseID,
+		WorkoutID:                 workoutID,
+		UserID:                    userID,
 	})
 	if err != nil {
 		HandleInternalServerError(w, r)
@@ -281,12 +378,37 @@ func (h *Handler) UpdateWorkoutExercise(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if err := h.saveSetDetails(r.Context(), updatedWorkoutExercise.ID, efforts); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to save set details", slog.String("error", err.Error()))
+		return
+	}
+
+	records, err := h.recordPersonalRecords(r.Context(), userID, updatedWorkoutExercise)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to record personal records", slog.String("error", err.Error()))
+		return
+	}
+	h.checkGoals(r.Context(), userID)
+
+	member, grouped, err := h.findGroupMember(r.Context(), userID, updatedWorkoutExercise)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to find workout exercise group", slog.String("error", err.Error()))
+		return
+	}
+	position := ""
+	if grouped {
+		position = member.Position()
+	}
+
 	exerciseForWorkout := database.WorkoutAndExercisesRow{
 		WorkoutsExercise: updatedWorkoutExercise,
 		Exercise:         exercise,
 	}
 
-	err = templates.WorkoutExerciseCard(exerciseForWorkout).Render(r.Context(), w)
+	err = templates.WorkoutExerciseCard(exerciseForWorkout, records, position, efforts, system).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render workout exercise card", slog.String("error", err.Error()))
@@ -373,7 +495,14 @@ func (h *Handler) DeleteExerciseFromWorkout(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *Handler) GetExercisesPage(w http.ResponseWriter, r *http.Request) {
-	muscleGroups, err := h.cfg.DB.GetMuscleGroupsWithCount(r.Context())
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	muscleGroups, err := h.cfg.DB.GetMuscleGroupsWithCount(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to get muscle groups", slog.String("error", err.Error()))
@@ -389,8 +518,18 @@ func (h *Handler) GetExercisesPage(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetMGExercisesPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
 	group := r.PathValue("group")
-	exercises, err := h.cfg.DB.GetExercisesByPrimaryMG(r.Context(), sql.NullString{String: group, Valid: true})
+	exercises, err := h.cfg.DB.GetExercisesByPrimaryMG(r.Context(), database.GetExercisesByPrimaryMGParams{
+		PrimaryMuscleGroup: sql.NullString{String: group, Valid: true},
+		UserID:             userID,
+	})
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to get exercises by muscle group", slog.String("error", err.Error()))
@@ -406,6 +545,13 @@ func (h *Handler) GetMGExercisesPage(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) GetSpecificExercisePage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
 	muscleGroup := r.PathValue("group")
 	exerciseID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
@@ -420,10 +566,124 @@ func (h *Handler) GetSpecificExercisePage(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	err = templates.Layout(templates.ExercisePage(muscleGroup, exercise), "FitHub | Exercises", true).Render(r.Context(), w)
+	// Custom exercises are only visible to their owner
+	if exercise.UserID.Valid && exercise.UserID.UUID != userID {
+		GetForbiddenPage(w, r)
+		return
+	}
+
+	formula := strength.ParseFormula(r.URL.Query().Get("formula"))
+	history, err := h.exerciseHistory(r.Context(), userID, exerciseID, formula)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get exercise history", slog.String("error", err.Error()))
+		return
+	}
+
+	// HTMX formula switch — return just the history section
+	if r.Header.Get("HX-Target") == "exercise-history" {
+		err = templates.ExerciseHistorySection(exerciseID, history).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render exercise history", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	err = templates.Layout(templates.ExercisePage(muscleGroup, exercise, history), "FitHub | Exercises", true).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render exercise page", slog.String("error", err.Error()))
 		return
 	}
 }
+
+func (h *Handler) GetExerciseRecordsPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	exercise, err := h.cfg.DB.GetExerciseByID(r.Context(), exerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to fetch exercise", slog.String("error", err.Error()))
+		return
+	}
+	if exercise.UserID.Valid && exercise.UserID.UUID != userID {
+		GetForbiddenPage(w, r)
+		return
+	}
+
+	records, err := h.cfg.DB.GetExerciseRecords(r.Context(), database.GetExerciseRecordsParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get exercise personal records", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.Layout(templates.ExerciseRecordsPage(exercise, records, system), "FitHub | Personal Records", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render personal records page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+// plannedTimedSets reads the planned durations and distances of a timed
+// exercise, sized to the planned number of sets.
+func plannedTimedSets(r *http.Request, sets int, system units.System) ([]int32, []int32, error) {
+	durations, err := tracking.ParseDurations(r.PostForm["planned-durations[]"])
+	if err != nil {
+		return nil, nil, err
+	}
+	distances, err := system.ParseDistances(r.PostForm["planned-distances[]"])
+	if err != nil {
+		return nil, nil, err
+	}
+	sets = max(sets, 0)
+	return fitSets(durations, sets), fitSets(distances, sets), nil
+}
+
+// completedTimedSets reads the completed durations, distances and heart rates
+// of a timed exercise, one set per duration.
+func completedTimedSets(r *http.Request, system units.System) ([]int32, []int32, []int32, error) {
+	durations, err := tracking.ParseDurations(r.PostForm["completed-durations[]"])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	distances, err := system.ParseDistances(r.PostForm["completed-distances[]"])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	heartRates, err := tracking.ParseHeartRates(r.PostForm["completed-heart-rates[]"])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return durations, fitSets(distances, len(durations)), fitSets(heartRates, len(durations)), nil
+}
+
+// fitSets trims or zero-pads values to one per set.
+func fitSets(values []int32, sets int) []int32 {
+	out := make([]int32, sets)
+	copy(out, values)
+	return out
+}
@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+)
+
+func (h *Handler) AddGoalMilestone(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+
+	prefix := goalID.String() + "-"
+	name, targetDate, errs := parseMilestone(r.FormValue("milestone-name"), r.FormValue("milestone-date"))
+	if errs != nil {
+		fields := []string{prefix + "milestone-name", prefix + "milestone-date"}
+		HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, fields, prefix, fmt.Sprintf("form-error-milestone-%v", goalID))
+		return
+	}
+
+	_, err = h.cfg.DB.CreateGoalMilestone(r.Context(), database.CreateGoalMilestoneParams{
+		Name:       name,
+		TargetDate: targetDate,
+		GoalID:     goalID,
+		UserID:     userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "goal not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create goal milestone", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderGoalMilestones(w, r, userID, goalID)
+}
+
+func (h *Handler) SetGoalMilestoneCompletion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+	milestoneID, err := uuid.Parse(r.PathValue("milestoneID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid milestone id")
+		return
+	}
+	completed, err := strconv.ParseBool(r.FormValue("completed"))
+	if err != nil {
+		HandleBadRequest(w, r, "completed must be true or false")
+		return
+	}
+
+	completedAt := sql.NullTime{}
+	if completed {
+		completedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	_, err = h.cfg.DB.SetGoalMilestoneCompleted(r.Context(), database.SetGoalMilestoneCompletedParams{
+		CompletedAt: completedAt,
+		ID:          milestoneID,
+		GoalID:      goalID,
+		UserID:      userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "milestone not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update goal milestone", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderGoalMilestones(w, r, userID, goalID)
+}
+
+func (h *Handler) SortGoalMilestones(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to parse goal milestones form", slog.String("error", err.Error()))
+		return
+	}
+	var ids []uuid.UUID
+	for _, v := range r.PostForm["sort-order[]"] {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			HandleBadRequest(w, r, "invalid milestone id")
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	if err := h.reorderGoalMilestones(r.Context(), userID, goalID, ids); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update goal milestone sort order", slog.String("error", err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) DeleteGoalMilestone(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+	milestoneID, err := uuid.Parse(r.PathValue("milestoneID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid milestone id")
+		return
+	}
+
+	err = h.cfg.DB.DeleteGoalMilestone(r.Context(), database.DeleteGoalMilestoneParams{
+		ID:     milestoneID,
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete goal milestone", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderGoalMilestones(w, r, userID, goalID)
+}
+
+func (h *Handler) AddGoalCheckin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+
+	prefix := goalID.String() + "-"
+	day, note, errs := parseCheckin(r.FormValue("checkin-date"), r.FormValue("checkin-note"), time.Now())
+	if errs != nil {
+		fields := []string{prefix + "check-in-date", prefix + "check-in-note"}
+		HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, fields, prefix, fmt.Sprintf("form-error-checkin-%v", goalID))
+		return
+	}
+
+	_, err = h.cfg.DB.CreateGoalCheckin(r.Context(), database.CreateGoalCheckinParams{
+		CheckedInOn: day,
+		Note:        note,
+		GoalID:      goalID,
+		UserID:      userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "goal not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create goal check-in", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderGoalCheckins(w, r, userID, goalID)
+}
+
+func (h *Handler) DeleteGoalCheckin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid goal id")
+		return
+	}
+	checkinID, err := uuid.Parse(r.PathValue("checkinID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid check-in id")
+		return
+	}
+
+	err = h.cfg.DB.DeleteGoalCheckin(r.Context(), database.DeleteGoalCheckinParams{
+		ID:     checkinID,
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete goal check-in", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderGoalCheckins(w, r, userID, goalID)
+}
+
+// renderGoalMilestones re-renders a goal's milestone list after a change.
+func (h *Handler) renderGoalMilestones(w http.ResponseWriter, r *http.Request, userID, goalID uuid.UUID) {
+	milestones, err := h.cfg.DB.GetGoalMilestones(r.Context(), database.GetGoalMilestonesParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal milestones", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.GoalMilestones(goalID, milestones).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render goal milestones", slog.String("error", err.Error()))
+		return
+	}
+}
+
+// renderGoalCheckins re-renders a goal's check-ins after a change.
+func (h *Handler) renderGoalCheckins(w http.ResponseWriter, r *http.Request, userID, goalID uuid.UUID) {
+	checkins, err := h.cfg.DB.GetGoalCheckins(r.Context(), database.GetGoalCheckinsParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal check-ins", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.GoalCheckins(goalID, checkins).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render goal check-ins", slog.String("error", err.Error()))
+		return
+	}
+}
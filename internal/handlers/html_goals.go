@@ -1,15 +1,20 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kairos4213/fithub/internal/cntx"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/goalstatus"
 	"github.com/kairos4213/fithub/internal/templates"
 	"github.com/kairos4213/fithub/internal/validate"
 )
@@ -23,28 +28,43 @@ func (h *Handler) GetAllGoals(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Tab-based filtering
-	tab := r.URL.Query().Get("tab")
-	if tab != "completed" {
-		tab = "in_progress"
+	tab, err := goalstatus.ParseView(r.URL.Query().Get("tab"))
+	if err != nil {
+		tab = goalstatus.View(goalstatus.InProgress)
+	}
+
+	// Goals met since they were last checked are listed as completed
+	if _, err := h.completeMetGoals(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to complete met goals", slog.String("error", err.Error()))
+		return
 	}
 
-	var goals []database.Goal
-	var err error
-	if tab == "completed" {
-		goals, err = h.cfg.DB.GetCompletedGoals(r.Context(), userID)
-	} else {
-		goals, err = h.cfg.DB.GetInProgressGoals(r.Context(), userID)
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
 	}
+
+	goals, err := h.goalsInView(r.Context(), userID, tab)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to get user goals", slog.String("error", err.Error()))
 		return
 	}
 
+	details, err := h.goalDetails(r.Context(), userID, goals)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal details", slog.String("error", err.Error()))
+		return
+	}
+
 	// HTMX tab switch — return just the card grid fragment
 	target := r.Header.Get("HX-Target")
 	if target == "goals-content" {
-		err = templates.GoalsCardGrid(goals, tab).Render(r.Context(), w)
+		err = templates.GoalsCardGrid(goals, details, tab, system).Render(r.Context(), w)
 		if err != nil {
 			HandleInternalServerError(w, r)
 			h.cfg.Logger.Error("failed to render goals card grid", slog.String("error", err.Error()))
@@ -53,8 +73,15 @@ func (h *Handler) GetAllGoals(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	options, err := h.goalTargetOptions(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal target options", slog.String("error", err.Error()))
+		return
+	}
+
 	// Full page render
-	contents := templates.GoalsPage(goals, tab)
+	contents := templates.GoalsPage(goals, details, options, tab, system)
 	err = templates.Layout(contents, "Fithub | Goals", true).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
@@ -76,7 +103,7 @@ func (h *Handler) AddNewGoal(w http.ResponseWriter, r *http.Request) {
 	reqGoalDate := r.FormValue("goal-date")
 	reqNotes := r.FormValue("notes")
 
-	inputFields := []string{"goal-name", "description", "goal-date", "notes"}
+	inputFields := []string{"goal-name", "description", "goal-date", "notes", "target", "target-metric", "target-exercise", "target-direction", "target-value"}
 	if errs := validate.Fields(
 		validate.Required(reqGoalName, "goal name"),
 		validate.Required(reqDescription, "description"),
@@ -95,6 +122,30 @@ func (h *Handler) AddNewGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	goalTarget, errs, err := h.parseGoalTarget(r.Context(), userID, goalTargetInput{
+		Kind:      r.FormValue("target-kind"),
+		Metric:    r.FormValue("target-metric"),
+		Exercise:  r.FormValue("target-exercise"),
+		Direction: r.FormValue("target-direction"),
+		Value:     r.FormValue("target-value"),
+	}, system)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to parse goal target", slog.String("error", err.Error()))
+		return
+	}
+	if errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, inputFields, "")
+		return
+	}
+
 	notes := sql.NullString{Valid: false}
 	if reqNotes != "" {
 		notes.String = reqNotes
@@ -102,11 +153,17 @@ func (h *Handler) AddNewGoal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	_, err = h.cfg.DB.CreateGoal(r.Context(), database.CreateGoalParams{
-		GoalName:    reqGoalName,
-		Description: reqDescription,
-		GoalDate:    goalDate,
-		Notes:       notes,
-		UserID:      userID,
+		GoalName:        reqGoalName,
+		Description:     reqDescription,
+		GoalDate:        goalDate,
+		Notes:           notes,
+		UserID:          userID,
+		TargetKind:      goalTarget.Kind,
+		MetricTypeID:    goalTarget.MetricTypeID,
+		ExerciseID:      goalTarget.ExerciseID,
+		TargetDirection: goalTarget.Direction,
+		TargetValue:     goalTarget.Value,
+		StartValue:      goalTarget.Start,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "pq: duplicate key value violates unique constraint") {
@@ -118,6 +175,13 @@ func (h *Handler) AddNewGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A target already met completes the goal straight away
+	if _, err := h.completeMetGoals(r.Context(), userID); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to complete met goals", slog.String("error", err.Error()))
+		return
+	}
+
 	goals, err := h.cfg.DB.GetInProgressGoals(r.Context(), userID)
 	if err != nil {
 		HandleInternalServerError(w, r)
@@ -125,9 +189,16 @@ func (h *Handler) AddNewGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	details, err := h.goalDetails(r.Context(), userID, goals)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal details", slog.String("error", err.Error()))
+		return
+	}
+
 	w.Header().Set("HX-Trigger", "close-create-goal")
 	w.Header().Set("HX-Push-Url", "/goals?tab=in_progress")
-	err = templates.GoalsCardGrid(goals, "in_progress").Render(r.Context(), w)
+	err = templates.GoalsCardGrid(goals, details, goalstatus.View(goalstatus.InProgress), system).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render goals card grid", slog.String("error", err.Error()))
@@ -156,7 +227,7 @@ func (h *Handler) EditGoal(w http.ResponseWriter, r *http.Request) {
 	reqNotes := r.FormValue("notes")
 
 	prefix := goalID.String() + "-"
-	editFields := []string{prefix + "goal-name", prefix + "description", prefix + "goal-date", prefix + "notes", prefix + "status"}
+	editFields := []string{prefix + "goal-name", prefix + "description", prefix + "goal-date", prefix + "notes", prefix + "status", prefix + "status-reason", prefix + "target-value"}
 
 	if errs := validate.Fields(
 		validate.Required(reqGoalName, "goal name"),
@@ -177,27 +248,54 @@ func (h *Handler) EditGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	status, statusReason, errs := parseGoalStatus(reqStatus, r.FormValue("status-reason"))
+	if errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, editFields, prefix)
+		return
+	}
+
+	goal, err := h.cfg.DB.GetGoal(r.Context(), database.GetGoalParams{ID: goalID, UserID: userID})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	targetValue, errs, err := h.parseGoalTargetValue(r.Context(), userID, goal, r.FormValue("target-value"), system)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to parse goal target", slog.String("error", err.Error()))
+		return
+	}
+	if errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, editFields, prefix)
+		return
+	}
+
 	notes := sql.NullString{Valid: false}
 	if reqNotes != "" {
 		notes.String = reqNotes
 		notes.Valid = true
 	}
 
-	completionDate := sql.NullTime{Valid: false}
-	if reqStatus == "completed" {
-		completionDate.Time = time.Now().UTC()
-		completionDate.Valid = true
-	}
-
 	updatedGoal, err := h.cfg.DB.UpdateGoal(r.Context(), database.UpdateGoalParams{
 		GoalName:       reqGoalName,
 		Description:    reqDescription,
 		GoalDate:       goalDate,
-		CompletionDate: completionDate,
+		CompletionDate: goalCompletionDate(goal, status),
 		Notes:          notes,
-		Status:         reqStatus,
+		Status:         string(status),
 		ID:             goalID,
 		UserID:         userID,
+		TargetValue:    targetValue,
+		StatusReason:   statusReason,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), "pq: duplicate key value violates unique constraint") {
@@ -209,14 +307,32 @@ func (h *Handler) EditGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Redirect to completed tab when marking a goal complete
-	if reqStatus == "completed" {
-		w.Header().Set("HX-Location", `{ "path": "/goals?tab=completed" }`)
+	completed, err := h.completeMetGoals(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to complete met goals", slog.String("error", err.Error()))
+		return
+	}
+
+	// Redirect to the goal's new tab when its status changes, or to completed
+	// when its new target is already met
+	if slices.Contains(completed, goalID) {
+		status = goalstatus.Completed
+	}
+	if status != goalstatus.Status(goal.Status) {
+		w.Header().Set("HX-Location", fmt.Sprintf(`{ "path": "/goals?tab=%s" }`, status))
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
 
-	err = templates.GoalCard(updatedGoal).Render(r.Context(), w)
+	details, err := h.goalDetails(r.Context(), userID, []database.Goal{updatedGoal})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get goal details", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.GoalCard(updatedGoal, details, system).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render goal card", slog.String("error", err.Error()))
@@ -224,6 +340,20 @@ func (h *Handler) EditGoal(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// goalTargetOptions lists what a new goal's target can follow: the user's
+// metric types and the lifts they've logged.
+func (h *Handler) goalTargetOptions(ctx context.Context, userID uuid.UUID) (templates.GoalTargetOptions, error) {
+	mts, err := h.cfg.DB.GetMetricTypes(ctx, uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		return templates.GoalTargetOptions{}, err
+	}
+	lifts, err := h.cfg.DB.GetRecordedLifts(ctx, userID)
+	if err != nil {
+		return templates.GoalTargetOptions{}, err
+	}
+	return templates.GoalTargetOptions{Metrics: metricTypesFrom(mts), Lifts: lifts}, nil
+}
+
 func (h *Handler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
@@ -238,23 +368,27 @@ func (h *Handler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// List page delete — use tab-specific query to get remaining count
-	currentURL := r.Header.Get("HX-Current-URL")
-	var count int64
-	tab := "in_progress"
-	if strings.Contains(currentURL, "tab=completed") {
-		tab = "completed"
-		count, err = h.cfg.DB.DeleteCompletedGoal(r.Context(), database.DeleteCompletedGoalParams{ID: goalID, UserID: userID})
-	} else {
-		count, err = h.cfg.DB.DeleteInProgressGoal(r.Context(), database.DeleteInProgressGoalParams{ID: goalID, UserID: userID})
+	if err := h.cfg.DB.DeleteGoal(r.Context(), database.DeleteGoalParams{ID: goalID, UserID: userID}); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete goal", slog.String("error", err.Error()))
+		return
 	}
+
+	// List page delete — check whether the current tab has any goals left
+	tab := goalstatus.View(goalstatus.InProgress)
+	if currentURL, err := url.Parse(r.Header.Get("HX-Current-URL")); err == nil {
+		if v, err := goalstatus.ParseView(currentURL.Query().Get("tab")); err == nil {
+			tab = v
+		}
+	}
+	remaining, err := h.goalsInView(r.Context(), userID, tab)
 	if err != nil {
 		HandleInternalServerError(w, r)
-		h.cfg.Logger.Error("failed to delete goal", slog.String("error", err.Error()))
+		h.cfg.Logger.Error("failed to get user goals", slog.String("error", err.Error()))
 		return
 	}
 
-	if count <= 1 {
+	if len(remaining) == 0 {
 		err = templates.GoalsEmptyOOB(true, tab).Render(r.Context(), w)
 		if err != nil {
 			HandleInternalServerError(w, r)
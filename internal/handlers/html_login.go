@@ -1,18 +1,21 @@
 package handlers
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
 
 	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/database"
 	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/utils"
 	"github.com/kairos4213/fithub/internal/validate"
 )
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 	if r.Method == "GET" {
 		// TODO: Make this page inaccessible unless user is logged out
-		contents := templates.LoginPage()
+		contents := templates.LoginPage(h.oauth.links())
 		err := templates.Layout(contents, "FitHub | Login", false).Render(r.Context(), w)
 		if err != nil {
 			HandleInternalServerError(w, r)
@@ -60,21 +63,62 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		_, _, err = h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+		if user.DisabledAt.Valid {
+			HandleDisabledLogin(w, r)
+			h.cfg.Logger.Info("login attempted for disabled user", slog.String("user_email", user.Email))
+			return
+		}
+
+		if h.unverifiedBlocked(user) {
+			HandleUnverifiedLogin(w, r)
+			h.cfg.Logger.Info("login attempted for unverified user", slog.String("user_email", user.Email))
+			return
+		}
+
+		twoFactor, err := h.twoFactorEnabled(r.Context(), user.ID)
 		if err != nil {
 			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to issue session tokens", slog.String("error", err.Error()))
+			h.cfg.Logger.Error("failed to get two-factor status", slog.String("error", err.Error()))
 			return
 		}
 
-		w.Header().Set("Content-type", "text/html")
-
-		if user.IsAdmin {
-			w.Header().Set("HX-Location", `{"path": "/admin"}`)
+		// Tokens wait on the second factor, entered on its own page
+		if twoFactor {
+			challenge, err := h.startLoginChallenge(r.Context(), user.ID)
+			if err != nil {
+				HandleInternalServerError(w, r)
+				h.cfg.Logger.Error("failed to start login challenge", slog.String("error", err.Error()))
+				return
+			}
+			utils.SetChallengeCookie(w, challenge)
+			w.Header().Set("Content-type", "text/html")
+			w.Header().Set("HX-Location", `{"path": "/login/two-factor"}`)
 			w.WriteHeader(http.StatusAccepted)
+			return
 		}
 
-		w.Header().Set("HX-Location", `{"path": "/workouts"}`)
-		w.WriteHeader(http.StatusAccepted)
+		h.completeLogin(w, r, user)
+	}
+}
+
+// completeLogin issues session tokens for user and sends them on to the app.
+func (h *Handler) completeLogin(w http.ResponseWriter, r *http.Request, user database.User) {
+	_, _, err := h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to issue session tokens", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-type", "text/html")
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": %q}`, loginDestination(user)))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// loginDestination is where a user lands once they've logged in.
+func loginDestination(user database.User) string {
+	if user.IsAdmin {
+		return "/admin"
 	}
+	return "/workouts"
 }
@@ -1,17 +1,91 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/kairos4213/fithub/internal/cntx"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/metrics"
 	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/units"
 	"github.com/kairos4213/fithub/internal/validate"
 )
 
+const defaultMetricTab = "body_weight"
+
+// metricFormFields are the inputs a measurement form renders errors under.
+var metricFormFields = []string{"measurement", "secondary-measurement", "measured-at"}
+
+// metricTypeLookupError responds to a failed metric type lookup on an HTML
+// route.
+func (h *Handler) metricTypeLookupError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, errUnknownMetric) {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+	HandleInternalServerError(w, r)
+	h.cfg.Logger.Error("failed to get metric type", slog.String("error", err.Error()))
+}
+
+// activeMetricType picks the tab to show. Unknown tabs fall back to body
+// weight, or the first type listed.
+func activeMetricType(types []database.MetricType, tab string) database.MetricType {
+	active := types[0]
+	for _, mt := range types {
+		if mt.Slug == tab {
+			return mt
+		}
+		if mt.Slug == defaultMetricTab {
+			active = mt
+		}
+	}
+	return active
+}
+
+// refreshMetricContent re-renders a metric's whole tab in place of the
+// swapped row, keeping entries in measured-at order.
+func (h *Handler) refreshMetricContent(w http.ResponseWriter, r *http.Request, userID uuid.UUID, mt database.MetricType, system units.System) {
+	measurements, err := h.cfg.DB.GetMeasurements(r.Context(), database.GetMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: mt.ID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get metrics", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Retarget", "#metrics-content")
+	w.Header().Set("HX-Reswap", "innerHTML")
+	err = templates.MetricContent(metricTypeFrom(mt), measurements, metricSummary(measurements), system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render metrics content", slog.String("error", err.Error()))
+	}
+}
+
+// renderMetricTrendsOOB refreshes a metric's trend after one of its entries
+// changed.
+func (h *Handler) renderMetricTrendsOOB(w http.ResponseWriter, r *http.Request, userID uuid.UUID, mt database.MetricType, system units.System) {
+	measurements, err := h.cfg.DB.GetMeasurements(r.Context(), database.GetMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: mt.ID,
+	})
+	if err != nil {
+		h.cfg.Logger.Error("failed to get metrics", slog.String("error", err.Error()))
+		return
+	}
+	err = templates.MetricTrends(metricTypeFrom(mt), measurements, metricSummary(measurements), system, true).Render(r.Context(), w)
+	if err != nil {
+		h.cfg.Logger.Error("failed to render metric trends", slog.String("error", err.Error()))
+	}
+}
+
 func (h *Handler) GetAllMetrics(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
@@ -20,24 +94,31 @@ func (h *Handler) GetAllMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tab := r.URL.Query().Get("tab")
-	if tab != "muscleMasses" && tab != "bfPercents" {
-		tab = "bodyweights"
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	dbTypes, err := h.cfg.DB.GetMetricTypes(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get metric types", slog.String("error", err.Error()))
+		return
+	}
+	if len(dbTypes) == 0 {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("no metric types found")
+		return
 	}
 
-	var bodyweights []database.BodyWeight
-	var muscleMasses []database.MuscleMass
-	var bfPercents []database.BodyFatPercent
-	var err error
+	active := activeMetricType(dbTypes, metrics.Canonical(r.URL.Query().Get("tab")))
 
-	switch tab {
-	case "bodyweights":
-		bodyweights, err = h.cfg.DB.GetAllBodyWeights(r.Context(), userID)
-	case "muscleMasses":
-		muscleMasses, err = h.cfg.DB.GetAllMuscleMasses(r.Context(), userID)
-	case "bfPercents":
-		bfPercents, err = h.cfg.DB.GetAllBodyFatPercs(r.Context(), userID)
-	}
+	measurements, err := h.cfg.DB.GetMeasurements(r.Context(), database.GetMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: active.ID,
+	})
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to get metrics", slog.String("error", err.Error()))
@@ -47,24 +128,16 @@ func (h *Handler) GetAllMetrics(w http.ResponseWriter, r *http.Request) {
 	// HTMX tab switch — return just the content fragment
 	target := r.Header.Get("HX-Target")
 	if target == "metrics-content" {
-		var renderErr error
-		switch tab {
-		case "bodyweights":
-			renderErr = templates.BodyweightsContent(bodyweights).Render(r.Context(), w)
-		case "muscleMasses":
-			renderErr = templates.MuscleMassesContent(muscleMasses).Render(r.Context(), w)
-		case "bfPercents":
-			renderErr = templates.BfPercentsContent(bfPercents).Render(r.Context(), w)
-		}
-		if renderErr != nil {
+		err = templates.MetricContent(metricTypeFrom(active), measurements, metricSummary(measurements), system).Render(r.Context(), w)
+		if err != nil {
 			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render metrics content", slog.String("error", renderErr.Error()))
+			h.cfg.Logger.Error("failed to render metrics content", slog.String("error", err.Error()))
 		}
 		return
 	}
 
 	// Full page render
-	contents := templates.MetricsPage(tab, bodyweights, muscleMasses, bfPercents)
+	contents := templates.MetricsPage(metricTypesFrom(dbTypes), metricTypeFrom(active), measurements, metricSummary(measurements), system)
 	err = templates.Layout(contents, "Fithub | Metrics", true).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
@@ -73,7 +146,7 @@ func (h *Handler) GetAllMetrics(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *Handler) LogMetrics(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) GetUserMetricTrends(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		HandleInternalServerError(w, r)
@@ -81,99 +154,116 @@ func (h *Handler) LogMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricType := r.PathValue("type")
-	switch metricType {
-	case "bodyweights":
-		entry := r.FormValue("bodyweight")
-		if errs := validate.Fields(
-			validate.Required(entry, "bodyweight"),
-			validate.Numeric(entry, "bodyweight"),
-		); errs != nil {
-			HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"bodyweight"}, "")
-			return
-		}
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		h.metricTypeLookupError(w, r, err)
+		return
+	}
+	t := metricTypeFrom(mt)
 
-		bw, err := h.cfg.DB.AddBodyWeight(r.Context(), database.AddBodyWeightParams{UserID: userID, Measurement: entry})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to add body weight", slog.String("error", err.Error()))
-			return
-		}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
 
-		w.Header().Set("HX-Trigger", "close-log-bw-card")
-		err = templates.BWRow(bw).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render body weights", slog.String("error", err.Error()))
-			return
-		}
-		err = templates.MetricsEmptyOOB(false, "").Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render metrics empty oob", slog.String("error", err.Error()))
-			return
-		}
-	case "muscleMasses":
-		entry := r.FormValue("muscle-mass")
-		if errs := validate.Fields(
-			validate.Required(entry, "muscle mass"),
-			validate.Numeric(entry, "muscle mass"),
-		); errs != nil {
-			HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"muscle-mass"}, "")
-			return
-		}
+	reqTarget := r.FormValue("target")
+	if errs := validate.Fields(
+		validate.Required(reqTarget, "target"),
+		validate.Numeric(reqTarget, "target"),
+	); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"target"}, "")
+		return
+	}
+	target, err := parseTarget(t, reqTarget, system)
+	if err != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "target", Message: err.Error()}}, []string{"target"}, "")
+		return
+	}
 
-		mm, err := h.cfg.DB.AddMuscleMass(r.Context(), database.AddMuscleMassParams{UserID: userID, Measurement: entry})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to add muscle mass", slog.String("error", err.Error()))
-			return
-		}
+	measurements, err := h.cfg.DB.GetMeasurements(r.Context(), database.GetMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: mt.ID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get metrics", slog.String("error", err.Error()))
+		return
+	}
 
-		w.Header().Set("HX-Trigger", "close-log-mm-card")
-		err = templates.MMRow(mm).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render muscle masses", slog.String("error", err.Error()))
-			return
-		}
-		err = templates.MetricsEmptyOOB(false, "").Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render metrics empty oob", slog.String("error", err.Error()))
-			return
-		}
-	case "bfPercents":
-		entry := r.FormValue("bf-percent")
-		if errs := validate.Fields(
-			validate.Required(entry, "body fat percent"),
-			validate.Numeric(entry, "body fat percent"),
-		); errs != nil {
-			HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"body-fat-percent"}, "")
-			return
-		}
+	projection := metricSummary(measurements).Project(target, time.Now())
+	err = templates.MetricProjection(t, projection, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render metric projection", slog.String("error", err.Error()))
+		return
+	}
+}
 
-		bf, err := h.cfg.DB.AddBodyFatPerc(r.Context(), database.AddBodyFatPercParams{UserID: userID, Measurement: entry})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to add body fat", slog.String("error", err.Error()))
-			return
-		}
+func (h *Handler) LogMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
 
-		w.Header().Set("HX-Trigger", "close-log-bf-card")
-		err = templates.BFRow(bf).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render body fats", slog.String("error", err.Error()))
-			return
-		}
-		err = templates.MetricsEmptyOOB(false, "").Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render metrics empty oob", slog.String("error", err.Error()))
-			return
-		}
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		h.metricTypeLookupError(w, r, err)
+		return
+	}
+	t := metricTypeFrom(mt)
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	value, secondary, errs := parseMeasurement(t, r.FormValue("measurement"), r.FormValue("secondary-measurement"), system)
+	measuredAt, timeErrs := parseMeasuredAt(r.FormValue("measured-at"))
+	if errs = append(errs, timeErrs...); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, metricFormFields, "")
+		return
+	}
+
+	entry, err := h.cfg.DB.AddMeasurement(r.Context(), database.AddMeasurementParams{
+		UserID:         userID,
+		MetricTypeID:   mt.ID,
+		Value:          value,
+		SecondaryValue: secondary,
+		MeasuredAt:     measuredAt,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to add measurement", slog.String("error", err.Error()))
+		return
+	}
+	h.checkGoals(r.Context(), userID)
+
+	// A backdated entry may belong anywhere in the list
+	if measuredAt.Valid {
+		h.refreshMetricContent(w, r, userID, mt, system)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", "close-log-metric-card")
+	err = templates.MetricRow(t, entry, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render measurement", slog.String("error", err.Error()))
+		return
+	}
+	err = templates.MetricsEmptyOOB(false, t).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render metrics empty oob", slog.String("error", err.Error()))
+		return
 	}
+	h.renderMetricTrendsOOB(w, r, userID, mt, system)
 }
 
 func (h *Handler) EditMetrics(w http.ResponseWriter, r *http.Request) {
@@ -184,7 +274,6 @@ func (h *Handler) EditMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricType := r.PathValue("type")
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		HandleInternalServerError(w, r)
@@ -192,77 +281,60 @@ func (h *Handler) EditMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	prefix := fmt.Sprintf("%v-", id)
-	switch metricType {
-	case "bodyweights":
-		entry := r.FormValue("bodyweight")
-		if errs := validate.Fields(
-			validate.Required(entry, "bodyweight"),
-			validate.Numeric(entry, "bodyweight"),
-		); errs != nil {
-			HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, []string{prefix + "bodyweight"}, prefix, fmt.Sprintf("form-error-bw-%v", id))
-			return
-		}
 
-		updatedBW, err := h.cfg.DB.UpdateBodyWeight(r.Context(), database.UpdateBodyWeightParams{Measurement: entry, ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to update body weight", slog.String("error", err.Error()))
-			return
-		}
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		h.metricTypeLookupError(w, r, err)
+		return
+	}
+	t := metricTypeFrom(mt)
 
-		err = templates.BWRow(updatedBW).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render body weight", slog.String("error", err.Error()))
-			return
-		}
-	case "muscleMasses":
-		entry := r.FormValue("muscle-mass")
-		if errs := validate.Fields(
-			validate.Required(entry, "muscle mass"),
-			validate.Numeric(entry, "muscle mass"),
-		); errs != nil {
-			HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, []string{prefix + "muscle-mass"}, prefix, fmt.Sprintf("form-error-mm-%v", id))
-			return
-		}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
 
-		updatedMM, err := h.cfg.DB.UpdateMuscleMass(r.Context(), database.UpdateMuscleMassParams{Measurement: entry, ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to update muscle mass", slog.String("error", err.Error()))
-			return
+	value, secondary, errs := parseMeasurement(t, r.FormValue("measurement"), r.FormValue("secondary-measurement"), system)
+	measuredAt, timeErrs := parseMeasuredAt(r.FormValue("measured-at"))
+	if errs = append(errs, timeErrs...); errs != nil {
+		fields := make([]string, len(metricFormFields))
+		for i, f := range metricFormFields {
+			fields[i] = prefix + f
 		}
+		HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, fields, prefix, fmt.Sprintf("form-error-metric-%v", id))
+		return
+	}
 
-		err = templates.MMRow(updatedMM).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render muscle mass", slog.String("error", err.Error()))
-			return
-		}
-	case "bfPercents":
-		entry := r.FormValue("bf-percent")
-		if errs := validate.Fields(
-			validate.Required(entry, "body fat percent"),
-			validate.Numeric(entry, "body fat percent"),
-		); errs != nil {
-			HandleScopedFieldErrors(w, r, h.cfg.Logger, errs, []string{prefix + "body-fat-percent"}, prefix, fmt.Sprintf("form-error-bf-%v", id))
-			return
-		}
+	updated, err := h.cfg.DB.UpdateMeasurement(r.Context(), database.UpdateMeasurementParams{
+		Value:          value,
+		SecondaryValue: secondary,
+		ID:             id,
+		MetricTypeID:   mt.ID,
+		UserID:         userID,
+		MeasuredAt:     measuredAt,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update measurement", slog.String("error", err.Error()))
+		return
+	}
+	h.checkGoals(r.Context(), userID)
 
-		updatedBF, err := h.cfg.DB.UpdateBodyFatPerc(r.Context(), database.UpdateBodyFatPercParams{Measurement: entry, ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to update body fat", slog.String("error", err.Error()))
-			return
-		}
+	// The entry may have moved in the list
+	if measuredAt.Valid {
+		h.refreshMetricContent(w, r, userID, mt, system)
+		return
+	}
 
-		err = templates.BFRow(updatedBF).Render(r.Context(), w)
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to render body fat", slog.String("error", err.Error()))
-			return
-		}
+	err = templates.MetricRow(t, updated, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render measurement", slog.String("error", err.Error()))
+		return
 	}
+	h.renderMetricTrendsOOB(w, r, userID, mt, system)
 }
 
 func (h *Handler) DeleteMetrics(w http.ResponseWriter, r *http.Request) {
@@ -273,51 +345,111 @@ func (h *Handler) DeleteMetrics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricType := r.PathValue("type")
 	id, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to parse metric id", slog.String("error", err.Error()))
 		return
 	}
-	var count int64
-	var emptyMessage string
 
-	switch metricType {
-	case "bodyweights":
-		count, err = h.cfg.DB.DeleteBodyWeight(r.Context(), database.DeleteBodyWeightParams{ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to delete body weight", slog.String("error", err.Error()))
-			return
-		}
-		emptyMessage = "No body weight entries yet."
-	case "muscleMasses":
-		count, err = h.cfg.DB.DeleteMuscleMass(r.Context(), database.DeleteMuscleMassParams{ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to delete muscle mass", slog.String("error", err.Error()))
-			return
-		}
-		emptyMessage = "No muscle mass entries yet."
-	case "bfPercents":
-		count, err = h.cfg.DB.DeleteBodyFatPerc(r.Context(), database.DeleteBodyFatPercParams{ID: id, UserID: userID})
-		if err != nil {
-			HandleInternalServerError(w, r)
-			h.cfg.Logger.Error("failed to delete body fat", slog.String("error", err.Error()))
-			return
-		}
-		emptyMessage = "No body fat entries yet."
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		h.metricTypeLookupError(w, r, err)
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	count, err := h.cfg.DB.DeleteMeasurement(r.Context(), database.DeleteMeasurementParams{
+		ID:           id,
+		MetricTypeID: mt.ID,
+		UserID:       userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete measurement", slog.String("error", err.Error()))
+		return
 	}
 
 	if count <= 1 {
-		err = templates.MetricsEmptyOOB(true, emptyMessage).Render(r.Context(), w)
+		err = templates.MetricsEmptyOOB(true, metricTypeFrom(mt)).Render(r.Context(), w)
 		if err != nil {
 			HandleInternalServerError(w, r)
 			h.cfg.Logger.Error("failed to render metrics empty oob", slog.String("error", err.Error()))
 			return
 		}
+	}
+	h.renderMetricTrendsOOB(w, r, userID, mt, system)
+}
+
+func (h *Handler) AddCustomMetric(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	t, err := metrics.NewCustom(
+		r.FormValue("metric-name"),
+		r.FormValue("metric-unit"),
+		r.FormValue("metric-decimals"),
+		r.FormValue("metric-min"),
+		r.FormValue("metric-max"),
+	)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	mt, err := h.createCustomMetric(r.Context(), userID, t)
+	if err != nil {
+		if errors.Is(err, errMetricExists) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create metric type", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{ "path": "/metrics?tab=%s" }`, mt.Slug))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) RemoveCustomMetric(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("slug"))
+	if err != nil {
+		h.metricTypeLookupError(w, r, err)
 		return
 	}
+	if !mt.UserID.Valid {
+		HandleBadRequest(w, r, "built-in metrics cannot be deleted")
+		return
+	}
+
+	err = h.cfg.DB.DeleteMetricType(r.Context(), database.DeleteMetricTypeParams{
+		Slug:   mt.Slug,
+		UserID: mt.UserID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete metric type", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", `{ "path": "/metrics" }`)
 	w.WriteHeader(http.StatusOK)
 }
@@ -1,41 +1,34 @@
 package handlers
 
 import (
-	"context"
 	"crypto/rand"
-	"database/sql"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 
-	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/utils"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 )
 
-type googleUserInfo struct {
-	ID            string `json:"id"`
-	Email         string `json:"email"`
-	VerifiedEmail bool   `json:"verified_email"`
-	GivenName     string `json:"given_name"`
-	FamilyName    string `json:"family_name"`
-	Picture       string `json:"picture"`
-}
-
-func (h *Handler) googleOAuthConfig() *oauth2.Config {
-	provider := h.cfg.OAuth["google"]
-	return &oauth2.Config{
-		ClientID:     provider.ClientID,
-		ClientSecret: provider.ClientSecret,
-		RedirectURL:  provider.RedirectURL,
-		Scopes:       []string{"openid", "email", "profile"},
-		Endpoint:     google.Endpoint,
+// oauthCookieMaxAge is how long a user has to sign in with a provider
+const oauthCookieMaxAge = 600
+
+// OAuthLogin sends the user to sign in with the provider in the path. The
+// state, PKCE verifier and nonce it'll be checked against are kept in cookies
+// scoped to that provider's routes.
+func (h *Handler) OAuthLogin(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("provider")
+	client, err := h.oauth.client(key)
+	if errors.Is(err, errUnknownOAuthProvider) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to set up OAuth provider", slog.String("provider", key), slog.String("error", err.Error()))
+		return
 	}
-}
-
-func (h *Handler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
-	oauthCfg := h.googleOAuthConfig()
 
 	state, err := generateRandomState()
 	if err != nil {
@@ -43,22 +36,34 @@ func (h *Handler) GoogleLogin(w http.ResponseWriter, r *http.Request) {
 		h.cfg.Logger.Error("failed to generate OAuth state", slog.String("error", err.Error()))
 		return
 	}
+	nonce, err := generateRandomState()
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to generate OAuth nonce", slog.String("error", err.Error()))
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		Value:    state,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteDefaultMode,
-		MaxAge:   600,
-	})
+	setOAuthCookie(w, key, "oauth_state", state)
+	setOAuthCookie(w, key, "oauth_verifier", verifier)
+	setOAuthCookie(w, key, "oauth_nonce", nonce)
 
-	url := oauthCfg.AuthCodeURL(state, oauth2.AccessTypeOffline)
-	http.Redirect(w, r, url, http.StatusTemporaryRedirect)
+	http.Redirect(w, r, client.authCodeURL(state, verifier, nonce), http.StatusTemporaryRedirect)
 }
 
-func (h *Handler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) OAuthCallback(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("provider")
+	client, err := h.oauth.client(key)
+	if errors.Is(err, errUnknownOAuthProvider) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to set up OAuth provider", slog.String("provider", key), slog.String("error", err.Error()))
+		return
+	}
+
 	// Validate state for CSRF protection
 	stateCookie, err := r.Cookie("oauth_state")
 	if err != nil {
@@ -70,123 +75,97 @@ func (h *Handler) GoogleCallback(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid OAuth state", http.StatusForbidden)
 		return
 	}
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		MaxAge:   -1,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteDefaultMode,
-	})
-
-	// Exchange authorization code for tokens
-	code := r.URL.Query().Get("code")
-	oauthCfg := h.googleOAuthConfig()
-	token, err := oauthCfg.Exchange(r.Context(), code)
+	verifier, err := r.Cookie("oauth_verifier")
 	if err != nil {
-		HandleInternalServerError(w, r)
-		h.cfg.Logger.Error("failed to exchange OAuth code", slog.String("error", err.Error()))
+		http.Error(w, "Invalid OAuth state", http.StatusForbidden)
 		return
 	}
+	var nonce string
+	if c, err := r.Cookie("oauth_nonce"); err == nil {
+		nonce = c.Value
+	}
+	for _, name := range []string{"oauth_state", "oauth_verifier", "oauth_nonce"} {
+		setOAuthCookie(w, key, name, "")
+	}
 
-	// Fetch user info from Google
-	gUser, err := fetchGoogleUserInfo(r.Context(), oauthCfg, token)
-	if err != nil {
-		HandleInternalServerError(w, r)
-		h.cfg.Logger.Error("failed to fetch Google user info", slog.String("error", err.Error()))
+	// The user turned down signing in at the provider
+	if r.URL.Query().Get("error") != "" {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
 		return
 	}
 
-	// Find or create user via account linking
-	user, err := h.findOrCreateGoogleUser(r.Context(), gUser)
+	identity, err := client.identify(r.Context(), r.URL.Query().Get("code"), verifier.Value, nonce)
 	if err != nil {
 		HandleInternalServerError(w, r)
-		h.cfg.Logger.Error("failed to find or create Google user", slog.String("error", err.Error()))
+		h.cfg.Logger.Error("failed to identify OAuth user", slog.String("provider", key), slog.String("error", err.Error()))
 		return
 	}
 
-	// Issue session tokens (same as password login)
-	_, _, err = h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+	// Find or create user via account linking
+	user, err := h.findOrCreateOAuthUser(r.Context(), key, identity)
+	if errors.Is(err, errOAuthEmailUnverified) {
+		HandleOAuthFailure(w, r, OAuthUnverifiedMsg)
+		return
+	}
 	if err != nil {
 		HandleInternalServerError(w, r)
-		h.cfg.Logger.Error("failed to issue session tokens", slog.String("error", err.Error()))
+		h.cfg.Logger.Error("failed to find or create OAuth user", slog.String("provider", key), slog.String("error", err.Error()))
 		return
 	}
 
-	if user.IsAdmin {
-		http.Redirect(w, r, "/admin", http.StatusSeeOther)
+	if user.DisabledAt.Valid {
+		GetForbiddenPage(w, r)
+		h.cfg.Logger.Info("OAuth login attempted for disabled user", slog.String("user_email", user.Email))
 		return
 	}
-	http.Redirect(w, r, "/workouts", http.StatusSeeOther)
-}
-
-func (h *Handler) findOrCreateGoogleUser(ctx context.Context, gUser *googleUserInfo) (database.User, error) {
-	provider, err := h.cfg.DB.GetAuthProvider(ctx, database.GetAuthProviderParams{Provider: "google", ProviderUserID: gUser.ID})
-	// User exists in auth providers
-	if err == nil {
-		user, err := h.cfg.DB.GetUserByID(ctx, provider.UserID)
-		if err != nil {
-			h.cfg.Logger.Error("error fetching user from database", slog.String("error", err.Error()))
-			return database.User{}, err
-		}
-
-		return user, nil
-	}
 
-	user, err := h.cfg.DB.GetUser(ctx, gUser.Email)
-	// user does not exist at all
+	twoFactor, err := h.twoFactorEnabled(r.Context(), user.ID)
 	if err != nil {
-		user, err := h.cfg.DB.CreateOAuthUser(ctx, database.CreateOAuthUserParams{
-			FirstName:    gUser.GivenName,
-			LastName:     gUser.FamilyName,
-			Email:        gUser.Email,
-			ProfileImage: sql.NullString{String: gUser.Picture, Valid: true},
-		})
-		if err != nil {
-			h.cfg.Logger.Error("error creating oauth user", slog.String("error", err.Error()))
-			return database.User{}, err
-		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get two-factor status", slog.String("error", err.Error()))
+		return
+	}
 
-		_, err = h.cfg.DB.CreateAuthProvider(ctx, database.CreateAuthProviderParams{
-			UserID:         user.ID,
-			Provider:       "google",
-			ProviderUserID: gUser.ID,
-		})
+	// The provider stands in for the password, not the second factor
+	if twoFactor {
+		challenge, err := h.startLoginChallenge(r.Context(), user.ID)
 		if err != nil {
-			h.cfg.Logger.Error("error linking user to oauth provider", "error", err.Error())
-			return database.User{}, err
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to start login challenge", slog.String("error", err.Error()))
+			return
 		}
-
-		return user, nil
+		utils.SetChallengeCookie(w, challenge)
+		http.Redirect(w, r, "/login/two-factor", http.StatusSeeOther)
+		return
 	}
 
-	// user exists
-	_, err = h.cfg.DB.CreateAuthProvider(ctx, database.CreateAuthProviderParams{
-		UserID:         user.ID,
-		Provider:       "google",
-		ProviderUserID: gUser.ID,
-	})
+	// Issue session tokens (same as password login)
+	_, _, err = h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
 	if err != nil {
-		h.cfg.Logger.Error("error linking user to oauth provider", "error", err.Error())
-		return database.User{}, err
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to issue session tokens", slog.String("error", err.Error()))
+		return
 	}
 
-	return user, nil
+	http.Redirect(w, r, loginDestination(user), http.StatusSeeOther)
 }
 
-func fetchGoogleUserInfo(ctx context.Context, cfg *oauth2.Config, token *oauth2.Token) (*googleUserInfo, error) {
-	client := cfg.Client(ctx, token)
-	resp, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	var userInfo googleUserInfo
-	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return nil, err
+// setOAuthCookie sets a cookie only sent to the provider's /auth routes, or
+// clears it when value is empty.
+func setOAuthCookie(w http.ResponseWriter, provider, name, value string) {
+	maxAge := oauthCookieMaxAge
+	if value == "" {
+		maxAge = -1
 	}
-	return &userInfo, nil
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/auth/" + provider + "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteDefaultMode,
+		MaxAge:   maxAge,
+	})
 }
 
 func generateRandomState() (string, error) {
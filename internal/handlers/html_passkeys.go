@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+func (h *Handler) RenamePasskey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	passkeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "Invalid passkey.")
+		return
+	}
+
+	name := r.FormValue("name")
+	if errs := validate.Fields(
+		validate.Required(name, "name"),
+		validate.MaxLen(name, 100, "name"),
+	); errs != nil {
+		HandleBadRequest(w, r, errs[0].Error())
+		return
+	}
+
+	_, err = h.renamePasskey(r.Context(), userID, passkeyID, name)
+	if errors.Is(err, errPasskeyNotFound) {
+		HandleBadRequest(w, r, "Please reload the page and try again.")
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to rename passkey", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderPasskeySettings(w, r, userID)
+}
+
+func (h *Handler) DeletePasskey(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	passkeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "Invalid passkey.")
+		return
+	}
+
+	err = h.cfg.DB.DeletePasskey(r.Context(), database.DeletePasskeyParams{ID: passkeyID, UserID: userID})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete passkey", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderPasskeySettings(w, r, userID)
+}
+
+func (h *Handler) renderPasskeySettings(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	passkeys, err := h.cfg.DB.GetUserPasskeys(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get passkeys", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.PasskeySettings(passkeys).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render passkey settings", slog.String("error", err.Error()))
+		return
+	}
+}
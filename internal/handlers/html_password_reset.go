@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		contents := templates.ForgotPasswordPage()
+		err := templates.Layout(contents, "FitHub | Forgot Password", false).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render forgot password page", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	email := r.FormValue("email")
+	if errs := validate.Fields(
+		validate.Required(email, "email"),
+		validate.MaxLen(email, 255, "email"),
+	); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"email"}, "")
+		return
+	}
+
+	if err := h.requestPasswordReset(r.Context(), email); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to send password reset", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-type", "text/html")
+	err := templates.PasswordResetSent().Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render password reset sent", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		token := r.URL.Query().Get("token")
+		valid := token != ""
+		if valid {
+			_, err := h.cfg.DB.GetPasswordResetTokenUser(r.Context(), auth.HashResetToken(token))
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				HandleInternalServerError(w, r)
+				h.cfg.Logger.Error("failed to get password reset token", slog.String("error", err.Error()))
+				return
+			}
+			valid = err == nil
+		}
+
+		contents := templates.ResetPasswordPage(token, valid)
+		err := templates.Layout(contents, "FitHub | Reset Password", false).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render reset password page", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	token := r.FormValue("token")
+	password := r.FormValue("password")
+	if errs := validateNewPassword(password, r.FormValue("confirm-password")); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"password", "confirm-password"}, "")
+		return
+	}
+
+	if err := h.resetPassword(r.Context(), token, password); err != nil {
+		if errors.Is(err, errInvalidResetToken) {
+			HandleBadRequest(w, r, "This reset link is invalid or has expired. Please request a new one.")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to reset password", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-type", "text/html")
+	err := templates.PasswordResetDone().Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render password reset done", slog.String("error", err.Error()))
+		return
+	}
+}
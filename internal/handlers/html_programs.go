@@ -0,0 +1,519 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var (
+	programFields    = []string{"name", "description", "weeks"}
+	programDayFields = []string{"title", "day", "duration"}
+)
+
+func (h *Handler) GetProgramsPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	programs, err := h.cfg.DB.GetUserPrograms(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get programs", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.Layout(templates.ProgramsPage(programs), "FitHub | Programs", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render programs page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) CreateUserProgram(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	input := programInput{
+		Name:        strings.TrimSpace(r.FormValue("name")),
+		Description: strings.TrimSpace(r.FormValue("description")),
+		Weeks:       r.FormValue("weeks"),
+	}
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, programFields, "")
+		return
+	}
+
+	program, err := h.createProgram(r.Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, errProgramMaxWeeks) {
+			HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "weeks", Message: err.Error()}}, programFields, "")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create program", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": "/programs/%v"}`, program.ID))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) GetProgramPage(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	weeks, err := h.cfg.DB.GetProgramWeeks(r.Context(), program.ID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program weeks", slog.String("error", err.Error()))
+		return
+	}
+
+	days, err := h.programDays(r.Context(), program.ID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program days", slog.String("error", err.Error()))
+		return
+	}
+
+	workoutTemplates, err := h.cfg.DB.GetAllWorkoutTemplates(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout templates", slog.String("error", err.Error()))
+		return
+	}
+
+	enrollments, err := h.cfg.DB.GetProgramEnrollments(r.Context(), database.GetProgramEnrollmentsParams{
+		ProgramID: program.ID,
+		UserID:    program.UserID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program enrollments", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), program.UserID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.ProgramPage(templates.ProgramPageData{
+		Program:     program,
+		Weeks:       weeks,
+		Days:        days,
+		Templates:   workoutTemplates,
+		Enrollments: enrollments,
+		Units:       system,
+	})
+	err = templates.Layout(contents, "FitHub | Program", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render program page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) DeleteUserProgram(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.cfg.DB.DeleteProgram(r.Context(), database.DeleteProgramParams{
+		ID:     program.ID,
+		UserID: program.UserID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete program", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", `{ "path": "/programs" }`)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) AddUserProgramWeek(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.addProgramWeek(r.Context(), program.ID); err != nil {
+		if errors.Is(err, errProgramMaxWeeks) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to add program week", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderProgramWeeks(w, r, program)
+}
+
+func (h *Handler) RemoveUserProgramWeek(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.removeProgramWeek(r.Context(), program.ID); err != nil {
+		if errors.Is(err, errProgramLastWeek) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to remove program week", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderProgramWeeks(w, r, program)
+}
+
+func (h *Handler) UpdateUserProgramWeek(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+	weekNumber, err := strconv.ParseInt(r.PathValue("week"), 10, 32)
+	if err != nil {
+		HandleBadRequest(w, r, "invalid week")
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), program.UserID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	rule, err := programRule(r.FormValue("progression"), r.FormValue("amount"), system)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	week, err := h.cfg.DB.UpdateProgramWeek(r.Context(), database.UpdateProgramWeekParams{
+		Progression: string(rule.Kind),
+		Amount:      rule.Amount,
+		ProgramID:   program.ID,
+		WeekNumber:  int32(weekNumber),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "week not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update program week", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.ProgramWeekRow(program.ID, week, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render program week", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) CreateUserProgramDay(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	input := programDayInput{
+		Title:      strings.TrimSpace(r.FormValue("title")),
+		DayNumber:  r.FormValue("day"),
+		TemplateID: r.FormValue("template-id"),
+		Duration:   r.FormValue("duration"),
+	}
+	if errs := input.validate(); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, programDayFields, "")
+		return
+	}
+
+	if _, err := h.createProgramDay(r.Context(), program.ID, input); err != nil {
+		if errors.Is(err, errInvalidDayNumber) {
+			HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "day", Message: err.Error()}}, programDayFields, "")
+			return
+		}
+		if errors.Is(err, errUnknownTemplate) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to create program day", slog.String("error", err.Error()))
+		return
+	}
+
+	h.renderProgramDays(w, r, program)
+}
+
+func (h *Handler) DeleteUserProgramDay(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+	dayID, err := uuid.Parse(r.PathValue("dayID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid day id")
+		return
+	}
+
+	err = h.cfg.DB.DeleteProgramDay(r.Context(), database.DeleteProgramDayParams{
+		ID:        dayID,
+		ProgramID: program.ID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete program day", slog.String("error", err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) AddUserProgramDayExercise(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+	dayID, err := uuid.Parse(r.PathValue("dayID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid day id")
+		return
+	}
+	if !h.programHasDay(w, r, program.ID, dayID) {
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), program.UserID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = h.addProgramDayExercise(r.Context(), program.UserID, dayID,
+		r.FormValue("exercise-name"), r.FormValue("sets"), r.FormValue("reps"), r.FormValue("weight"), system)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	h.renderProgramDays(w, r, program)
+}
+
+func (h *Handler) DeleteUserProgramDayExercise(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("exerciseID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid exercise id")
+		return
+	}
+
+	err = h.cfg.DB.DeleteProgramDayExercise(r.Context(), database.DeleteProgramDayExerciseParams{
+		ID:        exerciseID,
+		ProgramID: program.ID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete program day exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) EnrollInUserProgram(w http.ResponseWriter, r *http.Request) {
+	program, ok := h.userProgram(w, r)
+	if !ok {
+		return
+	}
+
+	startDate, err := time.Parse(time.DateOnly, r.FormValue("start-date"))
+	if err != nil {
+		HandleBadRequest(w, r, "start date must be in YYYY-MM-DD format")
+		return
+	}
+
+	count, err := h.enrollInProgram(r.Context(), program.UserID, program.ID, startDate)
+	if err != nil {
+		if errors.Is(err, errProgramEmpty) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to enroll in program", slog.String("error", err.Error()))
+		return
+	}
+
+	h.cfg.Logger.Info("program enrolled", slog.String("program_id", program.ID.String()), slog.Int("workouts", count))
+	w.Header().Set("HX-Location", `{ "path": "/workouts?tab=upcoming" }`)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// userProgram loads the program named in the path for the signed-in user,
+// writing the error response itself when it can't.
+func (h *Handler) userProgram(w http.ResponseWriter, r *http.Request) (database.Program, bool) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return database.Program{}, false
+	}
+	programID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid program id")
+		return database.Program{}, false
+	}
+
+	program, err := h.cfg.DB.GetProgram(r.Context(), database.GetProgramParams{
+		ID:     programID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.NotFound(w, r)
+			return database.Program{}, false
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program", slog.String("error", err.Error()))
+		return database.Program{}, false
+	}
+	return program, true
+}
+
+func (h *Handler) programHasDay(w http.ResponseWriter, r *http.Request, programID, dayID uuid.UUID) bool {
+	days, err := h.cfg.DB.GetProgramDays(r.Context(), programID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program days", slog.String("error", err.Error()))
+		return false
+	}
+	for _, d := range days {
+		if d.ID == dayID {
+			return true
+		}
+	}
+	HandleBadRequest(w, r, "day not found")
+	return false
+}
+
+// programDays groups a program's fixed exercises under their days and looks
+// up the template each day draws from.
+func (h *Handler) programDays(ctx context.Context, programID uuid.UUID) ([]templates.ProgramDayData, error) {
+	days, err := h.cfg.DB.GetProgramDays(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := h.cfg.DB.GetProgramDayExercises(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]templates.ProgramDayData, 0, len(days))
+	for _, day := range days {
+		d := templates.ProgramDayData{Day: day}
+		if day.TemplateID.Valid {
+			tmpl, err := h.cfg.DB.GetWorkoutTemplateByID(ctx, day.TemplateID.UUID)
+			if err != nil {
+				return nil, err
+			}
+			d.TemplateName = tmpl.TemplateName
+		}
+		for _, ex := range exercises {
+			if ex.ProgramDayID == day.ID {
+				d.Exercises = append(d.Exercises, ex)
+			}
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+func (h *Handler) renderProgramWeeks(w http.ResponseWriter, r *http.Request, program database.Program) {
+	weeks, err := h.cfg.DB.GetProgramWeeks(r.Context(), program.ID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program weeks", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), program.UserID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.ProgramWeeksList(program.ID, weeks, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render program weeks", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) renderProgramDays(w http.ResponseWriter, r *http.Request, program database.Program) {
+	days, err := h.programDays(r.Context(), program.ID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get program days", slog.String("error", err.Error()))
+		return
+	}
+
+	workoutTemplates, err := h.cfg.DB.GetAllWorkoutTemplates(r.Context())
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout templates", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), program.UserID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.ProgramDaysList(program.ID, days, workoutTemplates, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render program days", slog.String("error", err.Error()))
+		return
+	}
+}
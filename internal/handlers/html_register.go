@@ -16,7 +16,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		// TODO: Make this page inaccessible unless user is logged out
 		w.Header().Set("Content-type", "text/html")
-		contents := templates.RegisterPage()
+		contents := templates.RegisterPage(h.oauth.links())
 		err := templates.Layout(contents, "FitHub | Register", false).Render(r.Context(), w)
 		if err != nil {
 			HandleInternalServerError(w, r)
@@ -70,6 +70,18 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		// The account exists either way, so a failed send is left to be resent
+		if err := h.sendEmailVerification(r.Context(), user, user.Email); err != nil {
+			h.cfg.Logger.Error("failed to send email verification", slog.String("error", err.Error()))
+		}
+
+		w.Header().Set("Content-type", "text/html")
+		if h.unverifiedBlocked(user) {
+			w.Header().Set("HX-Location", `{"path": "/email/verification?sent=true"}`)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+
 		_, _, err = h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
 		if err != nil {
 			HandleInternalServerError(w, r)
@@ -77,7 +89,6 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		w.Header().Set("Content-type", "text/html")
 		w.Header().Set("HX-Location", `{"path": "/workouts"}`)
 		w.WriteHeader(http.StatusCreated)
 	}
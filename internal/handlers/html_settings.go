@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+func (h *Handler) GetSettingsPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	pending, err := h.pendingEmail(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get pending email", slog.String("error", err.Error()))
+		return
+	}
+
+	twoFactor, recoveryCodes, err := h.twoFactorStatus(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get two-factor status", slog.String("error", err.Error()))
+		return
+	}
+
+	passkeys, err := h.cfg.DB.GetUserPasskeys(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get passkeys", slog.String("error", err.Error()))
+		return
+	}
+
+	policy, err := h.progressionPolicy(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get progression policy", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.Layout(templates.SettingsPage(user, pending, twoFactor, recoveryCodes, passkeys, policy, system), "FitHub | Settings", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render settings page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) UpdateProgressionSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	current, err := h.progressionPolicy(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get progression policy", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	// The weight step is entered in the user's unit but stored in pounds
+	weightStep := r.FormValue("weight-step")
+	if weightStep != "" {
+		lbs, err := system.ParseWeight(weightStep)
+		if err != nil {
+			HandleBadRequest(w, r, "weight step must be a number")
+			return
+		}
+		weightStep = strconv.Itoa(int(lbs))
+	}
+
+	policy, err := parsePolicy(
+		current,
+		system,
+		r.FormValue("policy"),
+		weightStep,
+		r.FormValue("rep-min"),
+		r.FormValue("rep-max"),
+		r.FormValue("hold-on-failure") == "true",
+	)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.saveProgressionPolicy(r.Context(), userID, policy); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to save progression policy", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.ProgressionSettingsForm(policy, system, true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render progression settings", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) UpdateUnitSettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	system, err := units.ParseSystem(r.FormValue("units"))
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	if err := h.saveUnitSystem(r.Context(), userID, system); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to save unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", `{ "path": "/settings" }`)
+	w.WriteHeader(http.StatusOK)
+}
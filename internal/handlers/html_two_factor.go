@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/templates"
+	"github.com/kairos4213/fithub/internal/utils"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+// LoginTwoFactor is the second step of logging in for users with a second
+// factor, reached with the challenge cookie set once their password checks
+// out.
+func (h *Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	challenge, err := r.Cookie("login_challenge")
+	if r.Method == http.MethodGet {
+		if err != nil {
+			http.Redirect(w, r, "/login", http.StatusSeeOther)
+			return
+		}
+		contents := templates.TwoFactorLoginPage()
+		err := templates.Layout(contents, "FitHub | Two-Factor Authentication", false).Render(r.Context(), w)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to render two-factor login page", slog.String("error", err.Error()))
+			return
+		}
+		return
+	}
+
+	if err != nil {
+		HandleBadRequest(w, r, "This login has expired. Please log in again.")
+		return
+	}
+
+	code := r.FormValue("code")
+	if errs := validate.Fields(validate.Required(code, "code")); errs != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, errs, []string{"code"}, "")
+		return
+	}
+
+	user, err := h.completeLoginChallenge(r.Context(), challenge.Value, code)
+	if err != nil {
+		if errors.Is(err, errInvalidChallenge) {
+			utils.ClearCookies(w, challenge)
+			HandleBadRequest(w, r, "This login has expired. Please log in again.")
+			return
+		}
+		if errors.Is(err, errInvalidCode) {
+			HandleBadRequest(w, r, "That code is incorrect. Please try again.")
+			h.cfg.Logger.Info("incorrect two-factor code attempt", slog.String("ip", r.RemoteAddr))
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to complete login challenge", slog.String("error", err.Error()))
+		return
+	}
+	utils.ClearCookies(w, challenge)
+
+	if user.DisabledAt.Valid {
+		HandleDisabledLogin(w, r)
+		h.cfg.Logger.Info("login attempted for disabled user", slog.String("user_email", user.Email))
+		return
+	}
+
+	h.completeLogin(w, r, user)
+}
+
+func (h *Handler) SetUpTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get user", slog.String("error", err.Error()))
+		return
+	}
+
+	secret, uri, err := h.beginTOTPEnrollment(r.Context(), user)
+	if errors.Is(err, errTwoFactorEnabled) {
+		HandleBadRequest(w, r, "Two-factor authentication is already on.")
+		return
+	}
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to begin two-factor setup", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.TwoFactorSetup(secret, uri).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render two-factor setup", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	codes, err := h.confirmTOTPEnrollment(r.Context(), userID, r.FormValue("code"))
+	if err != nil {
+		if errors.Is(err, errInvalidCode) {
+			HandleBadRequest(w, r, "That code is incorrect. Check your authenticator app and try again.")
+			return
+		}
+		if errors.Is(err, errTwoFactorEnabled) || errors.Is(err, errTwoFactorNotPending) {
+			HandleBadRequest(w, r, "Please reload the page and try again.")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to confirm two-factor setup", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render recovery codes", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	codes, err := h.regenerateRecoveryCodes(r.Context(), userID, r.FormValue("code"))
+	if err != nil {
+		if errors.Is(err, errInvalidCode) {
+			HandleBadRequest(w, r, "That code is incorrect. Please try again.")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to regenerate recovery codes", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.TwoFactorRecoveryCodes(codes).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render recovery codes", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+
+	err := h.disableTwoFactor(r.Context(), userID, r.FormValue("code"))
+	if err != nil {
+		if errors.Is(err, errInvalidCode) {
+			HandleBadRequest(w, r, "That code is incorrect. Please try again.")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to disable two-factor", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.TwoFactorSettings(false, 0).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render two-factor settings", slog.String("error", err.Error()))
+		return
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+)
+
+func (h *Handler) CreateUserWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	h.saveUserWorkoutGroup(w, r, uuid.NullUUID{})
+}
+
+func (h *Handler) UpdateUserWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := uuid.Parse(r.PathValue("groupID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid group id")
+		return
+	}
+	h.saveUserWorkoutGroup(w, r, uuid.NullUUID{UUID: groupID, Valid: true})
+}
+
+// saveUserWorkoutGroup creates or updates a group from the group form and
+// reloads the workout page.
+func (h *Handler) saveUserWorkoutGroup(w http.ResponseWriter, r *http.Request, groupID uuid.NullUUID) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to parse group form", slog.String("error", err.Error()))
+		return
+	}
+	input := groupInput{
+		Kind:        r.FormValue("kind"),
+		Label:       r.FormValue("label"),
+		Rounds:      r.FormValue("rounds"),
+		RestSeconds: r.FormValue("rest-seconds"),
+		Members:     r.Form["members"],
+	}
+
+	others, err := h.otherGroups(r.Context(), workoutID, groupID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout exercise groups", slog.String("error", err.Error()))
+		return
+	}
+	group, members, err := input.parse(others)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+
+	_, err = h.saveWorkoutGroup(r.Context(), userID, workoutID, groupID, group, members)
+	if err != nil {
+		if errors.Is(err, errUnknownWorkoutExercise) || errors.Is(err, errExerciseInOtherGroup) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "group not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to save workout exercise group", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{ "path": "/workouts/%v" }`, workoutID))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) DeleteUserWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+	groupID, err := uuid.Parse(r.PathValue("groupID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid group id")
+		return
+	}
+
+	err = h.deleteWorkoutGroup(r.Context(), userID, workoutID, groupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "workout not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to delete workout exercise group", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{ "path": "/workouts/%v" }`, workoutID))
+	w.WriteHeader(http.StatusOK)
+}
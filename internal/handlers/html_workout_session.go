@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/session"
+	"github.com/kairos4213/fithub/internal/templates"
+)
+
+func (h *Handler) StartUserWorkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+
+	if _, err := h.startWorkout(r.Context(), userID, workoutID); err != nil {
+		if errors.Is(err, errSessionFinished) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to start workout", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": "/workouts/%v/session"}`, workoutID))
+}
+
+func (h *Handler) GetWorkoutSessionPage(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+
+	workout, err := h.activeWorkout(r.Context(), userID, workoutID)
+	if err != nil {
+		if errors.Is(err, errSessionNotStarted) || errors.Is(err, errSessionFinished) {
+			http.Redirect(w, r, fmt.Sprintf("/workouts/%v", workoutID), http.StatusSeeOther)
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to fetch workout", slog.String("error", err.Error()))
+		return
+	}
+
+	workoutExercises, err := h.cfg.DB.WorkoutAndExercises(r.Context(), database.WorkoutAndExercisesParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout exercises", slog.String("error", err.Error()))
+		return
+	}
+
+	records, err := h.cfg.DB.GetWorkoutRecords(r.Context(), database.GetWorkoutRecordsParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout personal records", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	restSeconds := int(h.cfg.RestTimer.Seconds())
+	contents := templates.WorkoutSessionPage(workout, workoutExercises, recordsByWorkoutExercise(records), restSeconds, system)
+	err = templates.Layout(contents, "FitHub | Workout Session", true).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render workout session page", slog.String("error", err.Error()))
+		return
+	}
+}
+
+func (h *Handler) LogUserWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	reqSet := r.FormValue("set")
+	reqReps := r.FormValue("reps")
+	reqWeight := r.FormValue("weight")
+
+	index, err := strconv.Atoi(reqSet)
+	if err != nil {
+		HandleBadRequest(w, r, "invalid set")
+		return
+	}
+
+	// Timed exercises send a duration in place of reps and weight
+	if reqDuration := r.FormValue("duration"); reqDuration != "" {
+		set, err := parseTimedSet(reqDuration, r.FormValue("distance"), r.FormValue("heart-rate"), system)
+		if err != nil {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		h.updateUserSessionSets(w, r, true, func(sets session.Sets) (session.Sets, error) {
+			return sets.LogTimed(index, set)
+		})
+		return
+	}
+
+	reps, err := strconv.ParseInt(reqReps, 10, 32)
+	if err != nil || reps < 1 {
+		HandleBadRequest(w, r, "reps must be a number greater than 0")
+		return
+	}
+	weight, err := system.ParseWeight(reqWeight)
+	if err != nil || reqWeight == "" {
+		HandleBadRequest(w, r, "weight must be a number of at least 0")
+		return
+	}
+
+	h.updateUserSessionSets(w, r, true, func(sets session.Sets) (session.Sets, error) {
+		return sets.Log(index, int32(reps), weight)
+	})
+}
+
+func (h *Handler) UndoUserWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	h.updateUserSessionSets(w, r, false, session.Sets.Undo)
+}
+
+func (h *Handler) FinishUserWorkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+
+	if _, err := h.finishWorkout(r.Context(), userID, workoutID); err != nil {
+		if errors.Is(err, errSessionNotStarted) || errors.Is(err, errSessionFinished) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to finish workout", slog.String("error", err.Error()))
+		return
+	}
+	h.checkGoals(r.Context(), userID)
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{"path": "/workouts/%v"}`, workoutID))
+}
+
+// updateUserSessionSets changes the logged sets of one exercise in a live
+// session and re-renders its card, starting the rest timer if rest is set.
+func (h *Handler) updateUserSessionSets(w http.ResponseWriter, r *http.Request, rest bool, change func(session.Sets) (session.Sets, error)) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+	workoutExerciseID, err := uuid.Parse(r.PathValue("workoutExerciseID"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout exercise id")
+		return
+	}
+
+	we, records, err := h.updateSessionSets(r.Context(), userID, workoutID, workoutExerciseID, change)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "exercise not found in this workout")
+			return
+		}
+		if errors.Is(err, errSessionNotStarted) || errors.Is(err, errSessionFinished) || errors.Is(err, session.ErrSetOutOfOrder) || errors.Is(err, session.ErrNoSets) ||
+			errors.Is(err, session.ErrTimedSets) || errors.Is(err, session.ErrRepSets) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to update session sets", slog.String("error", err.Error()))
+		return
+	}
+
+	exercise, err := h.cfg.DB.GetExerciseByID(r.Context(), we.ExerciseID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to fetch exercise", slog.String("error", err.Error()))
+		return
+	}
+
+	if rest {
+		seconds, err := h.sessionRest(r.Context(), userID, we)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to get rest after set", slog.String("error", err.Error()))
+			return
+		}
+		// Supersets and circuits move straight on to the next exercise
+		if seconds > 0 {
+			w.Header().Set("HX-Trigger", fmt.Sprintf(`{"rest-start": {"seconds": %d}}`, seconds))
+		}
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	row := database.WorkoutAndExercisesRow{WorkoutsExercise: we, Exercise: exercise}
+	err = templates.SessionExerciseCard(row, records, system).Render(r.Context(), w)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to render session exercise", slog.String("error", err.Error()))
+		return
+	}
+}
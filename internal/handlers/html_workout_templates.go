@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -66,7 +68,7 @@ func (h *Handler) GetAllWorkoutTemplates(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *Handler) GetTemplatePreview(w http.ResponseWriter, r *http.Request) {
-	_, ok := cntx.UserID(r.Context())
+	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("missing user id in context")
@@ -94,19 +96,27 @@ func (h *Handler) GetTemplatePreview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exercises, err := h.resolveTemplateExercises(r, exerciseData)
+	exercises, err := h.resolveTemplateExercises(r.Context(), exerciseData)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to resolve template exercises", slog.String("error", err.Error()))
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
 	previewData := templates.TemplatePreviewData{
 		TemplateID:  tmpl.ID,
 		Title:       utils.TitleString(tmpl.TemplateName),
 		Description: tmpl.Description,
 		Duration:    tmpl.DurationMinutes,
 		Exercises:   exercises,
+		Units:       system,
 	}
 
 	err = templates.Layout(templates.TemplatePreviewPage(previewData), "FitHub | Preview Template", true).Render(r.Context(), w)
@@ -118,12 +128,12 @@ func (h *Handler) GetTemplatePreview(w http.ResponseWriter, r *http.Request) {
 }
 
 // resolveTemplateExercises picks random exercises for each muscle group slot.
-func (h *Handler) resolveTemplateExercises(r *http.Request, exerciseData map[string][]exerciseSlot) ([]templates.PreviewExercise, error) {
+func (h *Handler) resolveTemplateExercises(ctx context.Context, exerciseData map[string][]exerciseSlot) ([]templates.PreviewExercise, error) {
 	var exercises []templates.PreviewExercise
 
 	for group, slots := range exerciseData {
 		count := int32(len(slots))
-		dbExercises, err := h.cfg.DB.GetRandomExercisesByMuscleGroup(r.Context(), database.GetRandomExercisesByMuscleGroupParams{
+		dbExercises, err := h.cfg.DB.GetRandomExercisesByMuscleGroup(ctx, database.GetRandomExercisesByMuscleGroupParams{
 			PrimaryMuscleGroup: sql.NullString{String: group, Valid: true},
 			Limit:              count,
 		})
@@ -205,6 +215,13 @@ func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
 	// Parse each exercise from indexed form fields
 	type exerciseInput struct {
 		exerciseID uuid.UUID
@@ -223,6 +240,21 @@ func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
 			h.cfg.Logger.Info("invalid exercise id at index", slog.String("index", prefix), slog.String("error", err.Error()))
 			return
 		}
+		// Only the catalog and the user's own custom exercises can be added
+		_, err = h.cfg.DB.GetAvailableExerciseByID(r.Context(), database.GetAvailableExerciseByIDParams{
+			ID:     exerciseID,
+			UserID: userID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "exercise not found")
+			h.cfg.Logger.Info("unavailable exercise id at index", slog.String("index", prefix), slog.String("exercise_id", exerciseID.String()))
+			return
+		}
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to get exercise", slog.String("error", err.Error()))
+			return
+		}
 
 		setsStr := r.FormValue("sets_" + prefix)
 		sets, err := strconv.ParseInt(setsStr, 10, 32)
@@ -246,16 +278,11 @@ func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// Parse per-set weights array (weight_0[], weight_1[], etc.)
-		weightsSlice := r.PostForm[fmt.Sprintf("weight_%s[]", prefix)]
-		weights := make([]int32, 0, len(weightsSlice))
-		for _, v := range weightsSlice {
-			wt, err := strconv.ParseInt(v, 10, 32)
-			if err != nil {
-				HandleBadRequest(w, r, "weights must be numbers")
-				h.cfg.Logger.Info("invalid weight value at index", slog.String("index", prefix), slog.String("value", v))
-				return
-			}
-			weights = append(weights, int32(wt))
+		weights, err := system.ParseWeights(r.PostForm[fmt.Sprintf("weight_%s[]", prefix)])
+		if err != nil {
+			HandleBadRequest(w, r, "weights must be numbers")
+			h.cfg.Logger.Info("invalid weight value at index", slog.String("index", prefix), slog.String("error", err.Error()))
+			return
 		}
 
 		exerciseInputs = append(exerciseInputs, exerciseInput{
@@ -293,14 +320,19 @@ func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
 
 	for _, ex := range exerciseInputs {
 		_, err := qtx.AddExerciseToWorkout(ctx, database.AddExerciseToWorkoutParams{
-			WorkoutID:           workout.ID,
-			ExerciseID:          ex.exerciseID,
-			SetsPlanned:         ex.sets,
-			RepsPerSetPlanned:   ex.reps,
-			SetsCompleted:       0,
-			RepsPerSetCompleted: []int32{},
-			WeightsPlannedLbs:   ex.weights,
-			WeightsCompletedLbs: []int32{},
+			WorkoutID:                 workout.ID,
+			ExerciseID:                ex.exerciseID,
+			SetsPlanned:               ex.sets,
+			RepsPerSetPlanned:         ex.reps,
+			SetsCompleted:             0,
+			RepsPerSetCompleted:       []int32{},
+			WeightsPlannedLbs:         ex.weights,
+			WeightsCompletedLbs:       []int32{},
+			DurationsPlannedSeconds:   []int32{},
+			DurationsCompletedSeconds: []int32{},
+			DistancesPlannedMeters:    []int32{},
+			DistancesCompletedMeters:  []int32{},
+			HeartRatesCompleted:       []int32{},
 		})
 		if err != nil {
 			HandleInternalServerError(w, r)
@@ -320,7 +352,7 @@ func (h *Handler) ApplyTemplate(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handler) RerollExercise(w http.ResponseWriter, r *http.Request) {
-	_, ok := cntx.UserID(r.Context())
+	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("missing user id in context")
@@ -383,7 +415,14 @@ func (h *Handler) RerollExercise(w http.ResponseWriter, r *http.Request) {
 		Weight:      0,
 	}
 
-	err = templates.TemplateExerciseRow(previewExercise, index).Render(r.Context(), w)
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	err = templates.TemplateExerciseRow(previewExercise, index, system).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to render exercise row", slog.String("error", err.Error()))
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -96,7 +97,7 @@ func (h *Handler) CreateUserWorkout(w http.ResponseWriter, r *http.Request) {
 	reqDuration := r.FormValue("duration")
 	reqPlannedDate := r.FormValue("planned-date")
 
-	workoutFields := []string{"title", "duration", "description", "planned-date"}
+	workoutFields := []string{"title", "duration", "description", "planned-date", "repeat"}
 
 	if errs := validate.Fields(
 		validate.Required(reqTitle, "title"),
@@ -129,9 +130,20 @@ func (h *Handler) CreateUserWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.cfg.DB.CreateWorkout(r.Context(), database.CreateWorkoutParams{
+	rule, repeats, err := ruleFromForm(r)
+	if err != nil {
+		HandleFieldErrors(w, r, h.cfg.Logger, []validate.FieldError{{Field: "repeat", Message: err.Error()}}, workoutFields, "")
+		return
+	}
+
+	params := database.CreateWorkoutParams{
 		UserID: userID, Title: reqTitle, Description: description, DurationMinutes: int32(duration), PlannedDate: plannedDate,
-	})
+	}
+	if repeats {
+		_, err = h.createRepeatingWorkout(r.Context(), params, rule)
+	} else {
+		_, err = h.cfg.DB.CreateWorkout(r.Context(), params)
+	}
 	if err != nil {
 		HandleInternalServerError(w, r)
 		h.cfg.Logger.Error("failed to create workout", slog.String("error", err.Error()))
@@ -174,6 +186,7 @@ func (h *Handler) EditUserWorkout(w http.ResponseWriter, r *http.Request) {
 	reqDuration := r.FormValue("duration")
 	reqPlannedDate := r.FormValue("planned-date")
 	reqCompletionDate := r.FormValue("date-completed")
+	scope := r.FormValue("scope")
 
 	// Determine context for error targeting
 	currentURL := r.Header.Get("HX-Current-URL")
@@ -249,6 +262,22 @@ func (h *Handler) EditUserWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if scope == scopeFuture && updatedWorkout.SeriesID.Valid {
+		err = h.cfg.DB.UpdateFutureSeriesWorkouts(r.Context(), database.UpdateFutureSeriesWorkoutsParams{
+			Title:           reqTitle,
+			Description:     description,
+			DurationMinutes: int32(duration),
+			SeriesID:        updatedWorkout.SeriesID,
+			UserID:          userID,
+			PlannedDate:     updatedWorkout.PlannedDate,
+		})
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to update future series workouts", slog.String("error", err.Error()))
+			return
+		}
+	}
+
 	w.Header().Set("HX-Trigger", "close-edit-card")
 	if isDetailPage {
 		err = templates.WorkoutInfo(updatedWorkout).Render(r.Context(), w)
@@ -291,6 +320,19 @@ func (h *Handler) DeleteUserWorkout(w http.ResponseWriter, r *http.Request) {
 
 	currentURL := r.Header.Get("HX-Current-URL")
 
+	// Series delete — remove this occurrence and every later one still planned
+	if r.FormValue("scope") == scopeFuture {
+		err = h.deleteFutureOccurrences(r.Context(), userID, workoutID)
+		if err != nil {
+			HandleInternalServerError(w, r)
+			h.cfg.Logger.Error("failed to delete future series workouts", slog.String("error", err.Error()))
+			return
+		}
+		w.Header().Set("HX-Location", `{ "path": "/workouts" }`)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
 	// Detail page delete — redirect to list
 	if strings.Contains(currentURL, "/workouts/"+workoutID.String()) {
 		err = h.cfg.DB.DeleteWorkout(r.Context(), database.DeleteWorkoutParams{
@@ -334,6 +376,48 @@ func (h *Handler) DeleteUserWorkout(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+func (h *Handler) RepeatUserWorkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("missing user id in context")
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		HandleBadRequest(w, r, "invalid workout id")
+		return
+	}
+
+	rule, repeats, err := ruleFromForm(r)
+	if err != nil {
+		HandleBadRequest(w, r, err.Error())
+		return
+	}
+	if !repeats {
+		HandleBadRequest(w, r, "choose how often to repeat the workout")
+		return
+	}
+
+	_, err = h.repeatExistingWorkout(r.Context(), userID, workoutID, rule)
+	if err != nil {
+		if errors.Is(err, errAlreadyRepeating) {
+			HandleBadRequest(w, r, err.Error())
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			HandleBadRequest(w, r, "workout not found")
+			return
+		}
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to repeat workout", slog.String("error", err.Error()))
+		return
+	}
+
+	w.Header().Set("HX-Location", fmt.Sprintf(`{ "path": "/workouts/%v" }`, workoutID))
+	w.WriteHeader(http.StatusCreated)
+}
+
 func (h *Handler) GetUserWorkoutExercises(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
@@ -368,7 +452,48 @@ func (h *Handler) GetUserWorkoutExercises(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	contents := templates.WorkoutPage(workout, workoutExercises, []database.Exercise{})
+	records, err := h.cfg.DB.GetWorkoutRecords(r.Context(), database.GetWorkoutRecordsParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout personal records", slog.String("error", err.Error()))
+		return
+	}
+
+	groups, err := h.cfg.DB.GetWorkoutExerciseGroups(r.Context(), workoutID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout exercise groups", slog.String("error", err.Error()))
+		return
+	}
+
+	details, err := h.cfg.DB.GetWorkoutSetDetails(r.Context(), database.GetWorkoutSetDetailsParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout set details", slog.String("error", err.Error()))
+		return
+	}
+
+	repeats, err := h.describeSeries(r.Context(), userID, workout)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get workout series", slog.String("error", err.Error()))
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		HandleInternalServerError(w, r)
+		h.cfg.Logger.Error("failed to get unit system", slog.String("error", err.Error()))
+		return
+	}
+
+	contents := templates.WorkoutPage(workout, workoutExercises, []database.Exercise{}, groups, recordsByWorkoutExercise(records), effortByWorkoutExercise(details), repeats, system)
 	err = templates.Layout(contents, "FitHub | Workout Page", true).Render(r.Context(), w)
 	if err != nil {
 		HandleInternalServerError(w, r)
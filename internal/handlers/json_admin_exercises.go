@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+type Exercise struct {
+	ID                   string `json:"id,omitempty"`
+	Name                 string `json:"name,omitempty"`
+	Description          string `json:"description,omitempty"`
+	PrimaryMuscleGroup   string `json:"primary_muscle_group,omitempty"`
+	SecondaryMuscleGroup string `json:"secondary_muscle_group,omitempty"`
+	VideoURL             string `json:"video_url,omitempty"`
+	TrackingType         string `json:"tracking_type,omitempty"`
+	RetiredAt            string `json:"retired_at,omitempty"`
+	UserID               string `json:"user_id,omitempty"`
+	CreatedAt            string `json:"created_at,omitempty"`
+	UpdatedAt            string `json:"updated_at,omitempty"`
+}
+
+type mergeExerciseRequest struct {
+	TargetID string `json:"target_id"`
+}
+
+func exerciseResponse(exercise database.Exercise) Exercise {
+	resp := Exercise{
+		ID:                   exercise.ID.String(),
+		Name:                 exercise.Name,
+		Description:          exercise.Description.String,
+		PrimaryMuscleGroup:   exercise.PrimaryMuscleGroup.String,
+		SecondaryMuscleGroup: exercise.SecondaryMuscleGroup.String,
+		VideoURL:             exercise.VideoUrl.String,
+		TrackingType:         exercise.TrackingType,
+		CreatedAt:            exercise.CreatedAt.Format(time.RFC822),
+		UpdatedAt:            exercise.UpdatedAt.Format(time.RFC822),
+	}
+	if exercise.RetiredAt.Valid {
+		resp.RetiredAt = exercise.RetiredAt.Time.Format(time.RFC822)
+	}
+	if exercise.UserID.Valid {
+		resp.UserID = exercise.UserID.UUID.String()
+	}
+	return resp
+}
+
+func (h *Handler) GetCatalogExercisesJSON(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
+
+	exercises, err := h.cfg.DB.GetCatalogExercises(r.Context(), query)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving exercises", err)
+		return
+	}
+
+	response := []Exercise{}
+	for _, exercise := range exercises {
+		response = append(response, exerciseResponse(exercise))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	reqParams := Exercise{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	input := exerciseInputFromJSON(reqParams)
+	if errs := input.validate(); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, uuid.Nil, uuid.Nil)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error checking exercise name", err)
+		return
+	}
+	if taken {
+		utils.RespondWithError(w, http.StatusConflict, "exercise already exists", nil)
+		return
+	}
+
+	exercise, err := h.cfg.DB.CreateExercise(r.Context(), database.CreateExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		VideoUrl:             optionalString(input.VideoURL),
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error creating exercise", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, exerciseResponse(exercise))
+}
+
+func (h *Handler) UpdateCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	reqParams := Exercise{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	input := exerciseInputFromJSON(reqParams)
+	if errs := input.validate(); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, uuid.Nil, exerciseID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error checking exercise name", err)
+		return
+	}
+	if taken {
+		utils.RespondWithError(w, http.StatusConflict, "exercise already exists", nil)
+		return
+	}
+
+	exercise, err := h.cfg.DB.UpdateExercise(r.Context(), database.UpdateExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		VideoUrl:             optionalString(input.VideoURL),
+		ID:                   exerciseID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error updating exercise", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, exerciseResponse(exercise))
+}
+
+func (h *Handler) RetireCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	if _, err := h.cfg.DB.RetireExercise(r.Context(), exerciseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retiring exercise", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) RestoreCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	if _, err := h.cfg.DB.RestoreExercise(r.Context(), exerciseID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error restoring exercise", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) MergeCatalogExercise(w http.ResponseWriter, r *http.Request) {
+	sourceID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	reqParams := mergeExerciseRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	targetID, err := uuid.Parse(reqParams.TargetID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid target id", err)
+		return
+	}
+
+	target, err := h.mergeExercises(r.Context(), sourceID, targetID)
+	if err != nil {
+		if errors.Is(err, errMergeSameExercise) || errors.Is(err, errMergeTargetRetired) || errors.Is(err, errMergeCustom) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error merging exercises", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, exerciseResponse(target))
+}
+
+func exerciseInputFromJSON(reqParams Exercise) exerciseInput {
+	return exerciseInput{
+		Name:                 reqParams.Name,
+		Description:          reqParams.Description,
+		PrimaryMuscleGroup:   reqParams.PrimaryMuscleGroup,
+		SecondaryMuscleGroup: reqParams.SecondaryMuscleGroup,
+		VideoURL:             reqParams.VideoURL,
+		TrackingType:         reqParams.TrackingType,
+	}.normalize()
+}
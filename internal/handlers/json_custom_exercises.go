@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+func (h *Handler) GetCustomExercisesJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	exercises, err := h.cfg.DB.GetUserCustomExercises(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving exercises", err)
+		return
+	}
+
+	response := []Exercise{}
+	for _, exercise := range exercises {
+		response = append(response, exerciseResponse(exercise))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateCustomExerciseJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := Exercise{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	input := customExerciseInputFromJSON(reqParams)
+	if errs := input.validate(); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, userID, uuid.Nil)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error checking exercise name", err)
+		return
+	}
+	if taken {
+		utils.RespondWithError(w, http.StatusConflict, "exercise already exists", nil)
+		return
+	}
+
+	exercise, err := h.cfg.DB.CreateCustomExercise(r.Context(), database.CreateCustomExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		UserID:               uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error creating exercise", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, exerciseResponse(exercise))
+}
+
+func (h *Handler) UpdateCustomExerciseJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	reqParams := Exercise{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	input := customExerciseInputFromJSON(reqParams)
+	if errs := input.validate(); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	taken, err := h.nameTaken(r.Context(), input.Name, userID, exerciseID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error checking exercise name", err)
+		return
+	}
+	if taken {
+		utils.RespondWithError(w, http.StatusConflict, "exercise already exists", nil)
+		return
+	}
+
+	exercise, err := h.cfg.DB.UpdateCustomExercise(r.Context(), database.UpdateCustomExerciseParams{
+		Name:                 input.Name,
+		Description:          optionalString(input.Description),
+		PrimaryMuscleGroup:   optionalString(input.PrimaryMuscleGroup),
+		SecondaryMuscleGroup: optionalString(input.SecondaryMuscleGroup),
+		TrackingType:         input.trackingType(),
+		ID:                   exerciseID,
+		UserID:               uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error updating exercise", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, exerciseResponse(exercise))
+}
+
+func (h *Handler) DeleteCustomExerciseJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	err = h.cfg.DB.RetireCustomExercise(r.Context(), database.RetireCustomExerciseParams{
+		ID:     exerciseID,
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting exercise", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func customExerciseInputFromJSON(reqParams Exercise) exerciseInput {
+	return exerciseInput{
+		Name:                 reqParams.Name,
+		Description:          reqParams.Description,
+		PrimaryMuscleGroup:   reqParams.PrimaryMuscleGroup,
+		SecondaryMuscleGroup: reqParams.SecondaryMuscleGroup,
+		TrackingType:         reqParams.TrackingType,
+	}.normalize()
+}
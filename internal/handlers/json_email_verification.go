@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/utils"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+type EmailVerification struct {
+	Email string `json:"email,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+func (h *Handler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	reqParams := EmailVerification{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	if errs := validate.Fields(
+		validate.Required(reqParams.Email, "email"),
+		validate.MaxLen(reqParams.Email, 255, "email"),
+	); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	if err := h.resendEmailVerification(r.Context(), reqParams.Email); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error sending email verification", err)
+		return
+	}
+
+	// Accepted whether or not the email has an unverified account
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) ConfirmEmailVerification(w http.ResponseWriter, r *http.Request) {
+	reqParams := EmailVerification{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	if errs := validate.Fields(validate.Required(reqParams.Token, "token")); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	user, err := h.verifyEmail(r.Context(), reqParams.Token)
+	if err != nil {
+		if errors.Is(err, errInvalidVerificationToken) {
+			utils.RespondWithError(w, http.StatusBadRequest, "verification token is invalid or has expired", err)
+			return
+		}
+		if errors.Is(err, errEmailTaken) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error verifying email", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response{User: User{
+		ID:            user.ID.String(),
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt.Valid,
+	}})
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/effort"
+	"github.com/kairos4213/fithub/internal/strength"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// ExerciseHistory reports weights in Unit. Fields ending in _lbs are always
+// pounds.
+type ExerciseHistory struct {
+	ExerciseID string            `json:"exercise_id"`
+	Formula    string            `json:"formula"`
+	Unit       string            `json:"unit"`
+	Sessions   []ExerciseSession `json:"sessions"`
+}
+
+type ExerciseSession struct {
+	WorkoutID      string        `json:"workout_id"`
+	Title          string        `json:"title"`
+	Date           string        `json:"date"`
+	Volume         string        `json:"volume"`
+	VolumeLbs      string        `json:"volume_lbs"`
+	EstimatedOneRM string        `json:"estimated_1rm"`
+	Sets           []ExerciseSet `json:"sets"`
+}
+
+type ExerciseSet struct {
+	Reps      string `json:"reps"`
+	Weight    string `json:"weight"`
+	WeightLbs string `json:"weight_lbs"`
+	RPE       string `json:"rpe,omitempty"`
+	RIR       string `json:"rir,omitempty"`
+	Tempo     string `json:"tempo,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+func (h *Handler) GetExerciseHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	formula := strength.ParseFormula(r.URL.Query().Get("formula"))
+	history, err := h.exerciseHistory(r.Context(), userID, exerciseID, formula)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving exercise history", err)
+		return
+	}
+
+	response := ExerciseHistory{
+		ExerciseID: exerciseID.String(),
+		Formula:    string(history.Formula),
+		Unit:       history.Units.Unit(),
+		Sessions:   []ExerciseSession{},
+	}
+	for _, session := range history.Sessions {
+		sets := make([]ExerciseSet, len(session.Sets))
+		for i, s := range session.Sets {
+			detail := setDetailResponse(i, effort.At(session.Efforts, i))
+			sets[i] = ExerciseSet{
+				Reps:      strconv.Itoa(int(s.Reps)),
+				Weight:    history.Units.FormatWeight(s.Weight),
+				WeightLbs: strconv.Itoa(int(s.Weight)),
+				RPE:       detail.RPE,
+				RIR:       detail.RIR,
+				Tempo:     detail.Tempo,
+				Notes:     detail.Notes,
+			}
+		}
+		response.Sessions = append(response.Sessions, ExerciseSession{
+			WorkoutID:      session.WorkoutID.String(),
+			Title:          session.Title,
+			Date:           session.Date.Format(time.RFC822),
+			Volume:         history.Units.Format(float64(session.Volume), 0),
+			VolumeLbs:      strconv.FormatInt(session.Volume, 10),
+			EstimatedOneRM: history.Units.Format(session.OneRM, 1),
+			Sets:           sets,
+		})
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
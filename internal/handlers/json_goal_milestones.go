@@ -0,0 +1,366 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+type GoalMilestone struct {
+	ID          string `json:"milestone_id,omitempty"`
+	GoalID      string `json:"goal_id,omitempty"`
+	Name        string `json:"name,omitempty"`
+	TargetDate  string `json:"target_date,omitempty"`
+	Completed   bool   `json:"completed"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	SortOrder   int32  `json:"sort_order"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+type GoalCheckin struct {
+	ID        string `json:"checkin_id,omitempty"`
+	GoalID    string `json:"goal_id,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Note      string `json:"note,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+func goalMilestoneResponse(m database.GoalMilestone) GoalMilestone {
+	resp := GoalMilestone{
+		ID:         m.ID.String(),
+		GoalID:     m.GoalID.String(),
+		Name:       m.Name,
+		TargetDate: m.TargetDate.Format(time.DateOnly),
+		Completed:  m.CompletedAt.Valid,
+		SortOrder:  m.SortOrder,
+		CreatedAt:  m.CreatedAt.Format(time.RFC822),
+		UpdatedAt:  m.UpdatedAt.Format(time.RFC822),
+	}
+	if m.CompletedAt.Valid {
+		resp.CompletedAt = m.CompletedAt.Time.Format(time.RFC822)
+	}
+	return resp
+}
+
+func goalCheckinResponse(c database.GoalCheckin) GoalCheckin {
+	return GoalCheckin{
+		ID:        c.ID.String(),
+		GoalID:    c.GoalID.String(),
+		Date:      c.CheckedInOn.Format(time.DateOnly),
+		Note:      c.Note,
+		CreatedAt: c.CreatedAt.Format(time.RFC822),
+	}
+}
+
+// ownedGoal checks the goal in the path belongs to the user, responding with
+// an error when it doesn't.
+func (h *Handler) ownedGoal(w http.ResponseWriter, r *http.Request, userID uuid.UUID) (uuid.UUID, bool) {
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return uuid.Nil, false
+	}
+	_, err = h.cfg.DB.GetGoal(r.Context(), database.GetGoalParams{ID: goalID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		utils.RespondWithError(w, http.StatusNotFound, "goal not found", err)
+		return uuid.Nil, false
+	}
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goal", err)
+		return uuid.Nil, false
+	}
+	return goalID, true
+}
+
+func (h *Handler) GetGoalMilestones(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, ok := h.ownedGoal(w, r, userID)
+	if !ok {
+		return
+	}
+
+	milestones, err := h.cfg.DB.GetGoalMilestones(r.Context(), database.GetGoalMilestonesParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goal milestones", err)
+		return
+	}
+
+	response := []GoalMilestone{}
+	for _, m := range milestones {
+		response = append(response, goalMilestoneResponse(m))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateGoalMilestone(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return
+	}
+
+	reqParams := GoalMilestone{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	name, targetDate, errs := parseMilestone(reqParams.Name, reqParams.TargetDate)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	milestone, err := h.cfg.DB.CreateGoalMilestone(r.Context(), database.CreateGoalMilestoneParams{
+		Name:       name,
+		TargetDate: targetDate,
+		GoalID:     goalID,
+		UserID:     userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "goal not found", err)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error saving goal milestone", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, goalMilestoneResponse(milestone))
+}
+
+func (h *Handler) UpdateGoalMilestone(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return
+	}
+	milestoneID, err := uuid.Parse(r.PathValue("milestoneID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid milestone id", err)
+		return
+	}
+
+	reqParams := GoalMilestone{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	name, targetDate, errs := parseMilestone(reqParams.Name, reqParams.TargetDate)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	completedAt := sql.NullTime{}
+	if reqParams.Completed {
+		completedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	}
+	milestone, err := h.cfg.DB.UpdateGoalMilestone(r.Context(), database.UpdateGoalMilestoneParams{
+		Name:        name,
+		TargetDate:  targetDate,
+		ID:          milestoneID,
+		GoalID:      goalID,
+		UserID:      userID,
+		CompletedAt: completedAt,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "milestone not found", err)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error updating goal milestone", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, goalMilestoneResponse(milestone))
+}
+
+func (h *Handler) ReorderGoalMilestones(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, ok := h.ownedGoal(w, r, userID)
+	if !ok {
+		return
+	}
+
+	reqParams := struct {
+		MilestoneIDs []uuid.UUID `json:"milestone_ids"`
+	}{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	if err := h.reorderGoalMilestones(r.Context(), userID, goalID, reqParams.MilestoneIDs); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error updating goal milestone order", err)
+		return
+	}
+
+	milestones, err := h.cfg.DB.GetGoalMilestones(r.Context(), database.GetGoalMilestonesParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goal milestones", err)
+		return
+	}
+
+	response := []GoalMilestone{}
+	for _, m := range milestones {
+		response = append(response, goalMilestoneResponse(m))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) DeleteGoalMilestoneJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return
+	}
+	milestoneID, err := uuid.Parse(r.PathValue("milestoneID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid milestone id", err)
+		return
+	}
+
+	if err := h.cfg.DB.DeleteGoalMilestone(r.Context(), database.DeleteGoalMilestoneParams{
+		ID:     milestoneID,
+		GoalID: goalID,
+		UserID: userID,
+	}); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error deleting goal milestone", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetGoalCheckins(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, ok := h.ownedGoal(w, r, userID)
+	if !ok {
+		return
+	}
+
+	checkins, err := h.cfg.DB.GetGoalCheckins(r.Context(), database.GetGoalCheckinsParams{
+		GoalID: goalID,
+		UserID: userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goal check-ins", err)
+		return
+	}
+
+	response := []GoalCheckin{}
+	for _, c := range checkins {
+		response = append(response, goalCheckinResponse(c))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateGoalCheckin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return
+	}
+
+	reqParams := GoalCheckin{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	day, note, errs := parseCheckin(reqParams.Date, reqParams.Note, time.Now())
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	checkin, err := h.cfg.DB.CreateGoalCheckin(r.Context(), database.CreateGoalCheckinParams{
+		CheckedInOn: day,
+		Note:        note,
+		GoalID:      goalID,
+		UserID:      userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "goal not found", err)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error saving goal check-in", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, goalCheckinResponse(checkin))
+}
+
+func (h *Handler) DeleteGoalCheckinJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	goalID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid goal id", err)
+		return
+	}
+	checkinID, err := uuid.Parse(r.PathValue("checkinID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid check-in id", err)
+		return
+	}
+
+	if err := h.cfg.DB.DeleteGoalCheckin(r.Context(), database.DeleteGoalCheckinParams{
+		ID:     checkinID,
+		GoalID: goalID,
+		UserID: userID,
+	}); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error deleting goal check-in", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,29 +1,74 @@
 package handlers
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kairos4213/fithub/internal/cntx"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/goalstatus"
+	"github.com/kairos4213/fithub/internal/target"
+	"github.com/kairos4213/fithub/internal/units"
 	"github.com/kairos4213/fithub/internal/utils"
 	"github.com/kairos4213/fithub/internal/validate"
 )
 
 type Goal struct {
-	ID             string `json:"goal_id,omitempty"`
-	CreatedAt      string `json:"created_at,omitempty"`
-	UpdatedAt      string `json:"updated_at,omitempty"`
-	Name           string `json:"name,omitempty"`
-	Description    string `json:"description,omitempty"`
-	GoalDate       string `json:"goal_date,omitempty"`
-	CompletionDate string `json:"completion_date,omitempty"`
-	Notes          string `json:"notes,omitempty"`
-	Status         string `json:"status,omitempty"`
-	UserID         string `json:"user_id,omitempty"`
+	ID             string      `json:"goal_id,omitempty"`
+	CreatedAt      string      `json:"created_at,omitempty"`
+	UpdatedAt      string      `json:"updated_at,omitempty"`
+	Name           string      `json:"name,omitempty"`
+	Description    string      `json:"description,omitempty"`
+	GoalDate       string      `json:"goal_date,omitempty"`
+	CompletionDate string      `json:"completion_date,omitempty"`
+	Notes          string      `json:"notes,omitempty"`
+	Status         string      `json:"status,omitempty"`
+	StatusReason   string      `json:"status_reason,omitempty"`
+	PausedAt       string      `json:"paused_at,omitempty"`
+	AbandonedAt    string      `json:"abandoned_at,omitempty"`
+	Overdue        bool        `json:"overdue"`
+	UserID         string      `json:"user_id,omitempty"`
+	Target         *GoalTarget `json:"target,omitempty"`
+}
+
+// GoalTarget is a goal's measurable target. Metric is a metric type's slug and
+// Exercise a lift's name; values are in the user's unit. Current, Progress and
+// Met are only set on responses.
+type GoalTarget struct {
+	Kind      string `json:"kind,omitempty"`
+	Metric    string `json:"metric,omitempty"`
+	Exercise  string `json:"exercise,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Value     string `json:"value,omitempty"`
+	Current   string `json:"current,omitempty"`
+	Progress  int    `json:"progress"`
+	Met       bool   `json:"met"`
+}
+
+// goalTargetResponse renders a measured target in the user's unit.
+func goalTargetResponse(t target.Goal, ok bool, system units.System) *GoalTarget {
+	if !ok {
+		return nil
+	}
+	resp := &GoalTarget{
+		Kind:      string(t.Kind),
+		Metric:    t.Metric.Slug,
+		Exercise:  t.Exercise,
+		Direction: string(t.Direction),
+		Value:     t.Format(t.Progress.Target, system),
+		Progress:  t.Progress.Percent,
+		Met:       t.Progress.Met,
+	}
+	if t.Measured {
+		resp.Current = t.Format(t.Progress.Current, system)
+	}
+	return resp
 }
 
 func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
@@ -57,6 +102,32 @@ func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting unit system", err)
+		return
+	}
+
+	var goalTarget goalTargetParams
+	if reqParams.Target != nil {
+		var errs []validate.FieldError
+		goalTarget, errs, err = h.parseGoalTarget(r.Context(), userID, goalTargetInput{
+			Kind:      reqParams.Target.Kind,
+			Metric:    reqParams.Target.Metric,
+			Exercise:  reqParams.Target.Exercise,
+			Direction: reqParams.Target.Direction,
+			Value:     reqParams.Target.Value,
+		}, system)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error reading goal target", err)
+			return
+		}
+		if errs != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+			return
+		}
+	}
+
 	goalNotes := sql.NullString{Valid: false}
 	if reqParams.Notes != "" {
 		goalNotes.Valid = true
@@ -64,11 +135,17 @@ func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	goal, err := h.cfg.DB.CreateGoal(r.Context(), database.CreateGoalParams{
-		GoalName:    strings.ToLower(reqParams.Name),
-		Description: reqParams.Description,
-		GoalDate:    goalDate.UTC(),
-		Notes:       goalNotes,
-		UserID:      userID,
+		GoalName:        strings.ToLower(reqParams.Name),
+		Description:     reqParams.Description,
+		GoalDate:        goalDate.UTC(),
+		Notes:           goalNotes,
+		UserID:          userID,
+		TargetKind:      goalTarget.Kind,
+		MetricTypeID:    goalTarget.MetricTypeID,
+		ExerciseID:      goalTarget.ExerciseID,
+		TargetDirection: goalTarget.Direction,
+		TargetValue:     goalTarget.Value,
+		StartValue:      goalTarget.Start,
 	})
 	if err != nil {
 		if strings.Contains(err.Error(), `pq: duplicate key value violates unique constraint "goals_name_user_id_key"`) {
@@ -79,32 +156,105 @@ func (h *Handler) CreateGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	goal, t, ok, err := h.settleGoal(r.Context(), userID, goal)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error measuring goal target", err)
+		return
+	}
+
 	utils.RespondWithJSON(w, http.StatusCreated, Goal{
-		ID:          goal.ID.String(),
-		CreatedAt:   goal.CreatedAt.Format(time.RFC822),
-		UpdatedAt:   goal.UpdatedAt.Format(time.RFC822),
-		Name:        goal.GoalName,
-		Description: goal.Description,
-		GoalDate:    goal.GoalDate.Format(time.DateOnly),
-		Notes:       goal.Notes.String,
-		UserID:      goal.UserID.String(),
+		ID:             goal.ID.String(),
+		CreatedAt:      goal.CreatedAt.Format(time.RFC822),
+		UpdatedAt:      goal.UpdatedAt.Format(time.RFC822),
+		Name:           goal.GoalName,
+		Description:    goal.Description,
+		GoalDate:       goal.GoalDate.Format(time.DateOnly),
+		CompletionDate: completionDate(goal),
+		Notes:          goal.Notes.String,
+		Status:         goal.Status,
+		StatusReason:   goal.StatusReason.String,
+		PausedAt:       statusTime(goal.PausedAt),
+		AbandonedAt:    statusTime(goal.AbandonedAt),
+		Overdue:        goalstatus.Overdue(goalstatus.Status(goal.Status), goal.GoalDate, time.Now()),
+		UserID:         goal.UserID.String(),
+		Target:         goalTargetResponse(t, ok, system),
 	})
 }
 
+// settleGoal completes any goals whose targets are now met and measures goal,
+// returning it as it stands afterwards.
+func (h *Handler) settleGoal(ctx context.Context, userID uuid.UUID, goal database.Goal) (database.Goal, target.Goal, bool, error) {
+	completed, err := h.completeMetGoals(ctx, userID)
+	if err != nil {
+		return database.Goal{}, target.Goal{}, false, err
+	}
+	if slices.Contains(completed, goal.ID) {
+		goal, err = h.cfg.DB.GetGoal(ctx, database.GetGoalParams{ID: goal.ID, UserID: userID})
+		if err != nil {
+			return database.Goal{}, target.Goal{}, false, err
+		}
+	}
+	t, ok, err := h.measureGoal(ctx, userID, goal)
+	return goal, t, ok, err
+}
+
+// completionDate is when a goal was completed, blank while in progress.
+func completionDate(goal database.Goal) string {
+	if !goal.CompletionDate.Valid {
+		return ""
+	}
+	return goal.CompletionDate.Time.Format(time.DateOnly)
+}
+
+// statusTime is when a goal was paused or abandoned, blank when it isn't.
+func statusTime(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC822)
+}
+
 func (h *Handler) GetAllUserGoals(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
-	goals, err := h.cfg.DB.GetAllUserGoals(r.Context(), userID)
+	if _, err := h.completeMetGoals(r.Context(), userID); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error completing met goals", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting unit system", err)
+		return
+	}
+
+	// ?status= narrows the list to one status, or to overdue goals
+	var goals []database.Goal
+	if filter := r.URL.Query().Get("status"); filter != "" {
+		view, err := goalstatus.ParseView(filter)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		goals, err = h.goalsInView(r.Context(), userID, view)
+	} else {
+		goals, err = h.cfg.DB.GetAllUserGoals(r.Context(), userID)
+	}
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goals", err)
 		return
 	}
+	targets, err := h.goalTargets(r.Context(), userID, goals)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error measuring goal targets", err)
+		return
+	}
 
 	response := []Goal{}
 	for _, goal := range goals {
+		t, ok := targets[goal.ID]
 		response = append(response, Goal{
 			ID:             goal.ID.String(),
 			CreatedAt:      goal.CreatedAt.Format(time.RFC822),
@@ -115,7 +265,12 @@ func (h *Handler) GetAllUserGoals(w http.ResponseWriter, r *http.Request) {
 			CompletionDate: goal.CompletionDate.Time.Format(time.DateOnly),
 			Notes:          goal.Notes.String,
 			Status:         goal.Status,
+			StatusReason:   goal.StatusReason.String,
+			PausedAt:       statusTime(goal.PausedAt),
+			AbandonedAt:    statusTime(goal.AbandonedAt),
+			Overdue:        goalstatus.Overdue(goalstatus.Status(goal.Status), goal.GoalDate, time.Now()),
 			UserID:         goal.UserID.String(),
+			Target:         goalTargetResponse(t, ok, system),
 		})
 	}
 	utils.RespondWithJSON(w, http.StatusOK, response)
@@ -152,12 +307,19 @@ func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	status, statusReason, errs := parseGoalStatus(reqParams.Status, reqParams.StatusReason)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
 	updateGoalParams := database.UpdateGoalParams{
-		ID:          goalID,
-		GoalName:    reqParams.Name,
-		Description: reqParams.Description,
-		Status:      reqParams.Status,
-		UserID:      userID,
+		ID:           goalID,
+		GoalName:     reqParams.Name,
+		Description:  reqParams.Description,
+		Status:       string(status),
+		UserID:       userID,
+		StatusReason: statusReason,
 	}
 
 	goalDate, err := time.Parse(time.DateOnly, reqParams.GoalDate)
@@ -167,9 +329,13 @@ func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
 	}
 	updateGoalParams.GoalDate = goalDate
 
-	if reqParams.CompletionDate == "" {
+	// Only completed goals have a completion date; one left blank is today
+	switch {
+	case status != goalstatus.Completed:
 		updateGoalParams.CompletionDate = sql.NullTime{Valid: false}
-	} else {
+	case reqParams.CompletionDate == "":
+		updateGoalParams.CompletionDate = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	default:
 		completionDate, err := time.Parse(time.DateOnly, reqParams.CompletionDate)
 		if err != nil {
 			utils.RespondWithError(w, http.StatusBadRequest, "completion date must be in YYYY-MM-DD format", err)
@@ -184,12 +350,51 @@ func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
 		updateGoalParams.Notes = sql.NullString{String: reqParams.Notes, Valid: true}
 	}
 
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting unit system", err)
+		return
+	}
+
+	// Only a target's value can change; what it follows is fixed
+	if reqParams.Target != nil && reqParams.Target.Value != "" {
+		existing, err := h.cfg.DB.GetGoal(r.Context(), database.GetGoalParams{ID: goalID, UserID: userID})
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "goal not found", err)
+			return
+		}
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error getting goal", err)
+			return
+		}
+		if !existing.TargetKind.Valid {
+			utils.RespondWithError(w, http.StatusBadRequest, "goal has no target", nil)
+			return
+		}
+		value, errs, err := h.parseGoalTargetValue(r.Context(), userID, existing, reqParams.Target.Value, system)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error reading goal target", err)
+			return
+		}
+		if errs != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+			return
+		}
+		updateGoalParams.TargetValue = value
+	}
+
 	goal, err := h.cfg.DB.UpdateGoal(r.Context(), updateGoalParams)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Error updating goal", err)
 		return
 	}
 
+	goal, t, ok, err := h.settleGoal(r.Context(), userID, goal)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error measuring goal target", err)
+		return
+	}
+
 	utils.RespondWithJSON(w, http.StatusOK, Goal{
 		ID:             goal.ID.String(),
 		CreatedAt:      goal.CreatedAt.Format(time.RFC822),
@@ -200,7 +405,12 @@ func (h *Handler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
 		CompletionDate: goal.CompletionDate.Time.Format(time.DateOnly),
 		Notes:          goal.Notes.String,
 		Status:         goal.Status,
+		StatusReason:   goal.StatusReason.String,
+		PausedAt:       statusTime(goal.PausedAt),
+		AbandonedAt:    statusTime(goal.AbandonedAt),
+		Overdue:        goalstatus.Overdue(goalstatus.Status(goal.Status), goal.GoalDate, time.Now()),
 		UserID:         goal.UserID.String(),
+		Target:         goalTargetResponse(t, ok, system),
 	})
 }
 
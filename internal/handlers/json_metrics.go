@@ -1,174 +1,398 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/kairos4213/fithub/internal/cntx"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/metrics"
+	"github.com/kairos4213/fithub/internal/trend"
+	"github.com/kairos4213/fithub/internal/units"
 	"github.com/kairos4213/fithub/internal/utils"
-	"github.com/kairos4213/fithub/internal/validate"
 )
 
 type Metric struct {
-	ID          string `json:"metric_id,omitempty"`
-	MetricType  string `json:"metric_type,omitempty"`
-	Measurement string `json:"measurement,omitempty"`
-	CreatedAt   string `json:"created_at,omitempty"`
-	UpdatedAt   string `json:"updated_at,omitempty"`
-	UserID      string `json:"user_id,omitempty"`
+	ID                   string `json:"metric_id,omitempty"`
+	MetricType           string `json:"metric_type,omitempty"`
+	Measurement          string `json:"measurement,omitempty"`
+	SecondaryMeasurement string `json:"secondary_measurement,omitempty"`
+	Unit                 string `json:"unit,omitempty"`
+	MeasuredAt           string `json:"measured_at,omitempty"`
+	CreatedAt            string `json:"created_at,omitempty"`
+	UpdatedAt            string `json:"updated_at,omitempty"`
+	UserID               string `json:"user_id,omitempty"`
 }
 
-func (h *Handler) AddMetric(w http.ResponseWriter, r *http.Request) {
+// MetricType describes a built-in or custom metric. Min and Max are in Unit.
+type MetricType struct {
+	Slug          string `json:"slug"`
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	Unit          string `json:"unit"`
+	Decimals      string `json:"decimals"`
+	Min           string `json:"min"`
+	Max           string `json:"max"`
+	SecondaryName string `json:"secondary_name,omitempty"`
+	Custom        bool   `json:"custom"`
+}
+
+// MetricTrend is the trend of a metric's measurements, in Unit.
+type MetricTrend struct {
+	MetricType   string            `json:"metric_type"`
+	Unit         string            `json:"unit"`
+	Latest       string            `json:"latest,omitempty"`
+	Average      string            `json:"average,omitempty"`
+	WeeklyChange string            `json:"weekly_change,omitempty"`
+	Averages     []MetricAverage   `json:"averages"`
+	Ranges       []MetricRange     `json:"ranges"`
+	Projection   *MetricProjection `json:"projection,omitempty"`
+}
+
+// MetricAverage is the moving average as of one measurement.
+type MetricAverage struct {
+	MeasuredAt string `json:"measured_at"`
+	Value      string `json:"value"`
+}
+
+// MetricRange is the lowest and highest value over the last Days days, or
+// every measurement when Days is 0.
+type MetricRange struct {
+	Days  int    `json:"days"`
+	Min   string `json:"min"`
+	Max   string `json:"max"`
+	Count int    `json:"count"`
+}
+
+// MetricProjection estimates when the average will reach Target. Date is set
+// only when the trend is heading toward it.
+type MetricProjection struct {
+	Target  string `json:"target"`
+	Reached bool   `json:"reached"`
+	Date    string `json:"date,omitempty"`
+}
+
+type metricTypeRequest struct {
+	Name     string `json:"name"`
+	Unit     string `json:"unit"`
+	Decimals string `json:"decimals"`
+	Min      string `json:"min"`
+	Max      string `json:"max"`
+}
+
+func metricResponse(t metrics.Type, m database.Measurement, system units.System) Metric {
+	resp := Metric{
+		ID:          m.ID.String(),
+		MetricType:  t.Slug,
+		Measurement: t.Format(m.Value, system),
+		Unit:        t.DisplayUnit(system),
+		MeasuredAt:  m.MeasuredAt.UTC().Format(time.RFC3339),
+		CreatedAt:   m.CreatedAt.Format(time.RFC822),
+		UpdatedAt:   m.UpdatedAt.Format(time.RFC822),
+		UserID:      m.UserID.String(),
+	}
+	if m.SecondaryValue.Valid {
+		resp.SecondaryMeasurement = t.Format(m.SecondaryValue.String, system)
+	}
+	return resp
+}
+
+func metricTypeResponse(mt database.MetricType, system units.System) MetricType {
+	t := metricTypeFrom(mt)
+	bound := func(f float64) string {
+		return t.Format(strconv.FormatFloat(f, 'f', -1, 64), system)
+	}
+	return MetricType{
+		Slug:          t.Slug,
+		Name:          t.Name,
+		Kind:          string(t.Kind),
+		Unit:          t.DisplayUnit(system),
+		Decimals:      strconv.Itoa(t.Decimals),
+		Min:           bound(t.Min),
+		Max:           bound(t.Max),
+		SecondaryName: t.SecondaryName,
+		Custom:        mt.UserID.Valid,
+	}
+}
+
+func metricTrendResponse(t metrics.Type, s trend.Summary, system units.System) MetricTrend {
+	resp := MetricTrend{
+		MetricType: t.Slug,
+		Unit:       t.DisplayUnit(system),
+		Averages:   make([]MetricAverage, len(s.Averages)),
+		Ranges:     make([]MetricRange, len(s.Ranges)),
+	}
+	if len(s.Averages) > 0 {
+		resp.Latest = t.FormatValue(s.Latest, system)
+		resp.Average = t.FormatValue(s.Average, system)
+	}
+	if s.HasRate {
+		resp.WeeklyChange = t.FormatChange(s.WeeklyRate, system)
+	}
+	for i, a := range s.Averages {
+		resp.Averages[i] = MetricAverage{
+			MeasuredAt: a.At.UTC().Format(time.RFC3339),
+			Value:      t.FormatValue(a.Value, system),
+		}
+	}
+	for i, r := range s.Ranges {
+		resp.Ranges[i] = MetricRange{
+			Days:  r.Days,
+			Min:   t.FormatValue(r.Min, system),
+			Max:   t.FormatValue(r.Max, system),
+			Count: r.Count,
+		}
+	}
+	return resp
+}
+
+// metricTypeError responds to a failed metric type lookup.
+func metricTypeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUnknownMetric) {
+		utils.RespondWithError(w, http.StatusNotFound, err.Error(), nil)
+		return
+	}
+	utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving metric type", err)
+}
+
+func (h *Handler) GetMetricTypes(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
 
-	reqParams := Metric{}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	types, err := h.cfg.DB.GetMetricTypes(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving metric types", err)
+		return
+	}
+
+	resp := make([]MetricType, len(types))
+	for i, mt := range types {
+		resp[i] = metricTypeResponse(mt, system)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) CreateMetricType(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := metricTypeRequest{}
 	if err := utils.ParseJSON(r, &reqParams); err != nil {
 		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
 		return
 	}
 
-	if errs := validate.Fields(
-		validate.Required(reqParams.Measurement, "measurement"),
-		validate.Numeric(reqParams.Measurement, "measurement"),
-	); errs != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+	t, err := metrics.NewCustom(reqParams.Name, reqParams.Unit, reqParams.Decimals, reqParams.Min, reqParams.Max)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
 		return
 	}
 
-	metricType := r.PathValue("type")
-	switch metricType {
-	case "body_weights":
-		bodyWeightEntry, err := h.cfg.DB.AddBodyWeight(r.Context(), database.AddBodyWeightParams{
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "Error saving body weight metric", err)
-			return
-		}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
 
-		utils.RespondWithJSON(w, http.StatusCreated, Metric{
-			ID:          bodyWeightEntry.ID.String(),
-			MetricType:  "body_weight",
-			Measurement: bodyWeightEntry.Measurement,
-			CreatedAt:   bodyWeightEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bodyWeightEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
-	case "muscle_masses":
-		muscleMassEntry, err := h.cfg.DB.AddMuscleMass(r.Context(), database.AddMuscleMassParams{
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "Error saving muscle mass metric", err)
+	mt, err := h.createCustomMetric(r.Context(), userID, t)
+	if err != nil {
+		if errors.Is(err, errMetricExists) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
 			return
 		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error creating metric type", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusCreated, metricTypeResponse(mt, system))
+}
+
+func (h *Handler) DeleteMetricType(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("slug"))
+	if err != nil {
+		metricTypeError(w, err)
+		return
+	}
+	if !mt.UserID.Valid {
+		utils.RespondWithError(w, http.StatusForbidden, "built-in metric types cannot be deleted", nil)
+		return
+	}
+
+	err = h.cfg.DB.DeleteMetricType(r.Context(), database.DeleteMetricTypeParams{
+		Slug:   mt.Slug,
+		UserID: mt.UserID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting metric type", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusNoContent, MetricType{})
+}
 
-		utils.RespondWithJSON(w, http.StatusCreated, Metric{
-			ID:          muscleMassEntry.ID.String(),
-			MetricType:  "muscle_mass",
-			Measurement: muscleMassEntry.Measurement,
-			CreatedAt:   muscleMassEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   muscleMassEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
-	case "body_fat_percents":
-		bfPercentEntry, err := h.cfg.DB.AddBodyFatPerc(r.Context(), database.AddBodyFatPercParams{
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "Error saving body fat percentage", err)
+func (h *Handler) GetMetricTrends(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		metricTypeError(w, err)
+		return
+	}
+	t := metricTypeFrom(mt)
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	var target float64
+	reqTarget := r.URL.Query().Get("target")
+	if reqTarget != "" {
+		if target, err = parseTarget(t, reqTarget, system); err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
 			return
 		}
+	}
+
+	measurements, err := h.cfg.DB.GetMeasurements(r.Context(), database.GetMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: mt.ID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving measurements", err)
+		return
+	}
 
-		utils.RespondWithJSON(w, http.StatusCreated, Metric{
-			ID:          bfPercentEntry.ID.String(),
-			MetricType:  "body_fat_percentage",
-			Measurement: bfPercentEntry.Measurement,
-			CreatedAt:   bfPercentEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bfPercentEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
+	summary := metricSummary(measurements)
+	resp := metricTrendResponse(t, summary, system)
+	if reqTarget != "" {
+		p := summary.Project(target, time.Now())
+		resp.Projection = &MetricProjection{Target: t.FormatValue(target, system), Reached: p.Reached}
+		if p.Reachable {
+			resp.Projection.Date = p.At.Format(time.DateOnly)
+		}
 	}
+	utils.RespondWithJSON(w, http.StatusOK, resp)
 }
 
-func (h *Handler) GetAllUserMetrics(w http.ResponseWriter, r *http.Request) {
+func (h *Handler) AddMetric(w http.ResponseWriter, r *http.Request) {
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
 
-	bodyWeightsResp := []Metric{}
-	muscleMassesResp := []Metric{}
-	bfPercentsResp := []Metric{}
+	reqParams := Metric{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		metricTypeError(w, err)
+		return
+	}
+	t := metricTypeFrom(mt)
 
-	bodyWeights, err := h.cfg.DB.GetAllBodyWeights(r.Context(), userID)
+	system, err := h.unitSystem(r.Context(), userID)
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error retrieving body weights", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
 		return
 	}
-	for _, bodyWeight := range bodyWeights {
-		bodyWeightsResp = append(bodyWeightsResp, Metric{
-			ID:          bodyWeight.ID.String(),
-			MetricType:  "body_weight",
-			Measurement: bodyWeight.Measurement,
-			CreatedAt:   bodyWeight.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bodyWeight.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
+
+	value, secondary, errs := parseMeasurement(t, reqParams.Measurement, reqParams.SecondaryMeasurement, system)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+	measuredAt, errs := parseMeasuredAt(reqParams.MeasuredAt)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
 	}
 
-	muscleMasses, err := h.cfg.DB.GetAllMuscleMasses(r.Context(), userID)
+	entry, err := h.cfg.DB.AddMeasurement(r.Context(), database.AddMeasurementParams{
+		UserID:         userID,
+		MetricTypeID:   mt.ID,
+		Value:          value,
+		SecondaryValue: secondary,
+		MeasuredAt:     measuredAt,
+	})
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error retrieving muscle mass metrics", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "error saving measurement", err)
+		return
+	}
+	h.checkGoals(r.Context(), userID)
+	utils.RespondWithJSON(w, http.StatusCreated, metricResponse(t, entry, system))
+}
+
+func (h *Handler) GetAllUserMetrics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
-	for _, muscleMass := range muscleMasses {
-		muscleMassesResp = append(muscleMassesResp, Metric{
-			ID:          muscleMass.ID.String(),
-			MetricType:  "muscle_mass",
-			Measurement: muscleMass.Measurement,
-			CreatedAt:   muscleMass.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   muscleMass.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
 	}
 
-	bfPercents, err := h.cfg.DB.GetAllBodyFatPercs(r.Context(), userID)
+	types, err := h.cfg.DB.GetMetricTypes(r.Context(), uuid.NullUUID{UUID: userID, Valid: true})
 	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error retrieving body fat percentages", err)
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving metric types", err)
 		return
 	}
-	for _, bfPercent := range bfPercents {
-		bfPercentsResp = append(bfPercentsResp, Metric{
-			ID:          bfPercent.ID.String(),
-			MetricType:  "body_fat_percentage",
-			Measurement: bfPercent.Measurement,
-			CreatedAt:   bfPercent.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bfPercent.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
+	measurements, err := h.cfg.DB.GetAllMeasurements(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving measurements", err)
+		return
 	}
 
-	resp := map[string][]Metric{
-		"body_weights":         bodyWeightsResp,
-		"muscle_masses":        muscleMassesResp,
-		"body_fat_percentages": bfPercentsResp,
+	// Every type is listed, even without measurements
+	resp := make(map[string][]Metric, len(types))
+	byID := make(map[uuid.UUID]metrics.Type, len(types))
+	for _, mt := range types {
+		resp[mt.Slug] = []Metric{}
+		byID[mt.ID] = metricTypeFrom(mt)
+	}
+	for _, m := range measurements {
+		t, ok := byID[m.MetricTypeID]
+		if !ok {
+			continue
+		}
+		resp[t.Slug] = append(resp[t.Slug], metricResponse(t, m, system))
 	}
 	utils.RespondWithJSON(w, http.StatusOK, resp)
 }
 
 func (h *Handler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
-	metricType := r.PathValue("type")
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
@@ -186,73 +410,44 @@ func (h *Handler) UpdateMetric(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if errs := validate.Fields(
-		validate.Required(reqParams.Measurement, "measurement"),
-		validate.Numeric(reqParams.Measurement, "measurement"),
-	); errs != nil {
-		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		metricTypeError(w, err)
 		return
 	}
+	t := metricTypeFrom(mt)
 
-	switch metricType {
-	case "body_weights":
-		bodyWeightEntry, err := h.cfg.DB.UpdateBodyWeight(r.Context(), database.UpdateBodyWeightParams{
-			ID:          metricID,
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error updating body weight entry", err)
-			return
-		}
-
-		utils.RespondWithJSON(w, http.StatusAccepted, Metric{
-			ID:          bodyWeightEntry.ID.String(),
-			MetricType:  "body_weight",
-			Measurement: bodyWeightEntry.Measurement,
-			CreatedAt:   bodyWeightEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bodyWeightEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
-	case "muscle_masses":
-		muscleMassEntry, err := h.cfg.DB.UpdateMuscleMass(r.Context(), database.UpdateMuscleMassParams{
-			ID:          metricID,
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error updating body weight entry", err)
-			return
-		}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
 
-		utils.RespondWithJSON(w, http.StatusAccepted, Metric{
-			ID:          muscleMassEntry.ID.String(),
-			MetricType:  "body_weight",
-			Measurement: muscleMassEntry.Measurement,
-			CreatedAt:   muscleMassEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   muscleMassEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      userID.String(),
-		})
-	case "body_fat_percentages":
-		bfPercentEntry, err := h.cfg.DB.UpdateBodyFatPerc(r.Context(), database.UpdateBodyFatPercParams{
-			ID:          metricID,
-			UserID:      userID,
-			Measurement: reqParams.Measurement,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error updating body weight entry", err)
-			return
-		}
+	value, secondary, errs := parseMeasurement(t, reqParams.Measurement, reqParams.SecondaryMeasurement, system)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+	measuredAt, errs := parseMeasuredAt(reqParams.MeasuredAt)
+	if errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
 
-		utils.RespondWithJSON(w, http.StatusAccepted, Metric{
-			ID:          bfPercentEntry.ID.String(),
-			MetricType:  "body_fat_percentage",
-			Measurement: bfPercentEntry.Measurement,
-			CreatedAt:   bfPercentEntry.CreatedAt.Format(time.RFC822),
-			UpdatedAt:   bfPercentEntry.UpdatedAt.Format(time.RFC822),
-			UserID:      bfPercentEntry.UserID.String(),
-		})
+	entry, err := h.cfg.DB.UpdateMeasurement(r.Context(), database.UpdateMeasurementParams{
+		Value:          value,
+		SecondaryValue: secondary,
+		ID:             metricID,
+		MetricTypeID:   mt.ID,
+		UserID:         userID,
+		MeasuredAt:     measuredAt,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error updating measurement", err)
+		return
 	}
+	h.checkGoals(r.Context(), userID)
+	utils.RespondWithJSON(w, http.StatusAccepted, metricResponse(t, entry, system))
 }
 
 func (h *Handler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
@@ -261,74 +456,50 @@ func (h *Handler) DeleteMetric(w http.ResponseWriter, r *http.Request) {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
-	metricType := r.PathValue("type")
 	metricID, err := uuid.Parse(r.PathValue("id"))
 	if err != nil {
 		utils.RespondWithError(w, http.StatusBadRequest, "error parsing metric id", err)
 		return
 	}
 
-	switch metricType {
-	case "body_weights":
-		_, err := h.cfg.DB.DeleteBodyWeight(r.Context(), database.DeleteBodyWeightParams{
-			ID:     metricID,
-			UserID: userID,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting body weight entry", err)
-			return
-		}
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		metricTypeError(w, err)
+		return
+	}
 
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
-	case "muscle_masses":
-		_, err := h.cfg.DB.DeleteMuscleMass(r.Context(), database.DeleteMuscleMassParams{
-			ID:     metricID,
-			UserID: userID,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting muscle mass entry", err)
-			return
-		}
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
-	case "body_fat_percentages":
-		_, err := h.cfg.DB.DeleteBodyFatPerc(r.Context(), database.DeleteBodyFatPercParams{
-			ID:     metricID,
-			UserID: userID,
-		})
-		if err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting body fat percentage entry", err)
-			return
-		}
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
+	_, err = h.cfg.DB.DeleteMeasurement(r.Context(), database.DeleteMeasurementParams{
+		ID:           metricID,
+		MetricTypeID: mt.ID,
+		UserID:       userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting measurement", err)
+		return
 	}
+	utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
 }
 
 func (h *Handler) DeleteAllUserMetrics(w http.ResponseWriter, r *http.Request) {
-	metricType := r.PathValue("type")
 	userID, ok := cntx.UserID(r.Context())
 	if !ok {
 		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
 		return
 	}
 
-	switch metricType {
-	case "body_weights":
-		if err := h.cfg.DB.DeleteAllBodyWeights(r.Context(), userID); err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting body weight entries", err)
-			return
-		}
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
-	case "muscle_masses":
-		if err := h.cfg.DB.DeleteAllMuscleMasses(r.Context(), userID); err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting muscle mass entries", err)
-			return
-		}
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
-	case "body_fat_percentages":
-		if err := h.cfg.DB.DeleteAllBodyFatPercs(r.Context(), userID); err != nil {
-			utils.RespondWithError(w, http.StatusInternalServerError, "error deleting body fat percentage entries", err)
-			return
-		}
-		utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
+	mt, err := h.lookupMetricType(r.Context(), userID, r.PathValue("type"))
+	if err != nil {
+		metricTypeError(w, err)
+		return
+	}
+
+	err = h.cfg.DB.DeleteAllMeasurements(r.Context(), database.DeleteAllMeasurementsParams{
+		UserID:       userID,
+		MetricTypeID: mt.ID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting measurements", err)
+		return
 	}
+	utils.RespondWithJSON(w, http.StatusNoContent, Metric{})
 }
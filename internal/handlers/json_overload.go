@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/overload"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// ProgressionPolicy accepts the weight step either in the user's unit as
+// weight_step or in pounds as weight_step_lbs. weight_step wins when both are
+// set.
+type ProgressionPolicy struct {
+	Policy        string `json:"policy"`
+	WeightStep    string `json:"weight_step"`
+	WeightStepLbs string `json:"weight_step_lbs"`
+	Unit          string `json:"unit"`
+	RepRangeMin   string `json:"rep_range_min"`
+	RepRangeMax   string `json:"rep_range_max"`
+	HoldOnFailure bool   `json:"hold_on_failure"`
+}
+
+type SessionSuggestion struct {
+	ExerciseID    string    `json:"exercise_id"`
+	SetsPlanned   string    `json:"sets_planned"`
+	RepsPerSet    []int32   `json:"reps_per_set"`
+	Weights       []float64 `json:"weights"`
+	WeightsLbs    []int32   `json:"weights_lbs"`
+	Unit          string    `json:"unit"`
+	Reason        string    `json:"reason,omitempty"`
+	HasSuggestion bool      `json:"has_suggestion"`
+}
+
+func policyResponse(policy overload.Policy, system units.System) ProgressionPolicy {
+	return ProgressionPolicy{
+		Policy:        string(policy.Kind),
+		WeightStep:    system.FormatWeight(policy.WeightStep),
+		WeightStepLbs: strconv.Itoa(int(policy.WeightStep)),
+		Unit:          system.Unit(),
+		RepRangeMin:   strconv.Itoa(int(policy.RepMin)),
+		RepRangeMax:   strconv.Itoa(int(policy.RepMax)),
+		HoldOnFailure: policy.HoldOnFailure,
+	}
+}
+
+func (h *Handler) GetProgressionPolicy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	policy, err := h.progressionPolicy(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving progression policy", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, policyResponse(policy, system))
+}
+
+func (h *Handler) UpdateProgressionPolicy(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := ProgressionPolicy{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	current, err := h.progressionPolicy(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving progression policy", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	weightStep := reqParams.WeightStepLbs
+	if reqParams.WeightStep != "" {
+		lbs, err := system.ParseWeight(reqParams.WeightStep)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, "weight step must be a number", err)
+			return
+		}
+		weightStep = strconv.Itoa(int(lbs))
+	}
+
+	policy, err := parsePolicy(
+		current,
+		system,
+		reqParams.Policy,
+		weightStep,
+		reqParams.RepRangeMin,
+		reqParams.RepRangeMax,
+		reqParams.HoldOnFailure,
+	)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	if err := h.saveProgressionPolicy(r.Context(), userID, policy); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error saving progression policy", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, policyResponse(policy, system))
+}
+
+func (h *Handler) GetExerciseSuggestion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	suggestion, err := h.suggestNextSession(r.Context(), userID, exerciseID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error suggesting next session", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	response := SessionSuggestion{
+		ExerciseID:    exerciseID.String(),
+		SetsPlanned:   strconv.Itoa(int(suggestion.Sets())),
+		RepsPerSet:    suggestion.Reps,
+		Weights:       system.Weights(suggestion.Weights),
+		WeightsLbs:    suggestion.Weights,
+		Unit:          system.Unit(),
+		Reason:        suggestion.Basis.Reason(system),
+		HasSuggestion: suggestion.Sets() > 0,
+	}
+	if response.RepsPerSet == nil {
+		response.RepsPerSet = []int32{}
+		response.Weights = []float64{}
+		response.WeightsLbs = []int32{}
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/utils"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+// defaultPasskeyName is used for passkeys added without a name
+const defaultPasskeyName = "Passkey"
+
+type Passkey struct {
+	ID         string `json:"passkey_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+}
+
+// PasskeyCeremony carries a ceremony's session token and the browser's
+// response to it, which is passed on to the webauthn package as is.
+type PasskeyCeremony struct {
+	SessionToken string          `json:"session_token"`
+	Name         string          `json:"name,omitempty"`
+	Credential   json.RawMessage `json:"credential"`
+}
+
+// passkeyLogin is a login response with where the login page should go next.
+type passkeyLogin struct {
+	response
+	Redirect string `json:"redirect"`
+}
+
+type passkeyOptions struct {
+	Options      any    `json:"options"`
+	SessionToken string `json:"session_token"`
+}
+
+func passkeyResponse(p database.Passkey) Passkey {
+	resp := Passkey{
+		ID:        p.ID.String(),
+		Name:      p.Name,
+		CreatedAt: p.CreatedAt.Format(time.RFC822),
+		UpdatedAt: p.UpdatedAt.Format(time.RFC822),
+	}
+	if p.LastUsedAt.Valid {
+		resp.LastUsedAt = p.LastUsedAt.Time.Format(time.RFC822)
+	}
+	return resp
+}
+
+func (h *Handler) GetPasskeys(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	passkeys, err := h.cfg.DB.GetUserPasskeys(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting passkeys", err)
+		return
+	}
+
+	resp := make([]Passkey, 0, len(passkeys))
+	for _, p := range passkeys {
+		resp = append(resp, passkeyResponse(p))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) BeginPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting user", err)
+		return
+	}
+
+	creation, token, err := h.beginPasskeyRegistration(r.Context(), user)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error starting passkey registration", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, passkeyOptions{Options: creation, SessionToken: token})
+}
+
+func (h *Handler) FinishPasskeyRegistration(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := PasskeyCeremony{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Malformed request", err)
+		return
+	}
+	if reqParams.Name == "" {
+		reqParams.Name = defaultPasskeyName
+	}
+	if errs := validate.Fields(
+		validate.Required(reqParams.SessionToken, "session token"),
+		validate.MaxLen(reqParams.Name, 100, "name"),
+	); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	passkey, err := h.finishPasskeyRegistration(r.Context(), userID, reqParams.SessionToken, reqParams.Name, reqParams.Credential)
+	if err != nil {
+		if errors.Is(err, errInvalidCeremony) || errors.Is(err, errPasskeyRejected) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error saving passkey", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, passkeyResponse(passkey))
+}
+
+func (h *Handler) RenamePasskeyJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	passkeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid passkey id", err)
+		return
+	}
+
+	reqParams := Passkey{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Malformed request", err)
+		return
+	}
+	if errs := validate.Fields(
+		validate.Required(reqParams.Name, "name"),
+		validate.MaxLen(reqParams.Name, 100, "name"),
+	); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	passkey, err := h.renamePasskey(r.Context(), userID, passkeyID, reqParams.Name)
+	if err != nil {
+		if errors.Is(err, errPasskeyNotFound) {
+			utils.RespondWithError(w, http.StatusNotFound, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error renaming passkey", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, passkeyResponse(passkey))
+}
+
+func (h *Handler) DeletePasskeyJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	passkeyID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid passkey id", err)
+		return
+	}
+
+	if err := h.cfg.DB.DeletePasskey(r.Context(), database.DeletePasskeyParams{
+		ID:     passkeyID,
+		UserID: userID,
+	}); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error deleting passkey", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) BeginPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	assertion, token, err := h.beginPasskeyLogin(r.Context())
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error starting passkey login", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, passkeyOptions{Options: assertion, SessionToken: token})
+}
+
+// FinishPasskeyLogin issues session tokens for a verified passkey, as cookies
+// as well as in the body, and says where the login page should go next.
+func (h *Handler) FinishPasskeyLogin(w http.ResponseWriter, r *http.Request) {
+	reqParams := PasskeyCeremony{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Malformed request", err)
+		return
+	}
+	if errs := validate.Fields(validate.Required(reqParams.SessionToken, "session token")); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	user, err := h.finishPasskeyLogin(r.Context(), reqParams.SessionToken, reqParams.Credential)
+	if err != nil {
+		if errors.Is(err, errInvalidCeremony) || errors.Is(err, errPasskeyRejected) {
+			utils.RespondWithError(w, http.StatusUnauthorized, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error completing passkey login", err)
+		return
+	}
+
+	if user.DisabledAt.Valid {
+		utils.RespondWithError(w, http.StatusForbidden, "Account disabled", nil)
+		return
+	}
+
+	if h.unverifiedBlocked(user) {
+		utils.RespondWithError(w, http.StatusForbidden, "Email not verified", nil)
+		return
+	}
+
+	accessToken, refreshToken, err := h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error issuing session tokens", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, passkeyLogin{
+		response: response{
+			User: User{
+				ID:            user.ID.String(),
+				FirstName:     user.FirstName,
+				LastName:      user.LastName,
+				Email:         user.Email,
+				EmailVerified: user.EmailVerifiedAt.Valid,
+			},
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		},
+		Redirect: loginDestination(user),
+	})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/utils"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+type PasswordReset struct {
+	Email    string `json:"email,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func (h *Handler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	reqParams := PasswordReset{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	if errs := validate.Fields(
+		validate.Required(reqParams.Email, "email"),
+		validate.MaxLen(reqParams.Email, 255, "email"),
+	); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	if err := h.requestPasswordReset(r.Context(), reqParams.Email); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error sending password reset", err)
+		return
+	}
+
+	// Accepted whether or not the email has an account
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Request) {
+	reqParams := PasswordReset{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	if errs := validateNewPassword(reqParams.Password, reqParams.Password); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	if err := h.resetPassword(r.Context(), reqParams.Token, reqParams.Password); err != nil {
+		if errors.Is(err, errInvalidResetToken) {
+			utils.RespondWithError(w, http.StatusBadRequest, "reset token is invalid or has expired", err)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error resetting password", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
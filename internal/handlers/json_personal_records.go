@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/strength"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// PersonalRecord reports weights in Unit. Rep records keep their value in
+// reps.
+type PersonalRecord struct {
+	ID                string `json:"id,omitempty"`
+	ExerciseID        string `json:"exercise_id,omitempty"`
+	WorkoutExerciseID string `json:"workout_exercise_id,omitempty"`
+	RecordType        string `json:"record_type,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Reps              string `json:"reps,omitempty"`
+	Weight            string `json:"weight,omitempty"`
+	WeightLbs         string `json:"weight_lbs,omitempty"`
+	Unit              string `json:"unit,omitempty"`
+	AchievedAt        string `json:"achieved_at,omitempty"`
+}
+
+// recordValue converts a record's value to the user's unit unless it counts
+// reps.
+func recordValue(pr database.PersonalRecord, system units.System) string {
+	if strength.Kind(pr.RecordType) == strength.RepsAtWeight {
+		return strconv.FormatFloat(pr.Value, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(system.FromLbs(pr.Value), 'f', 1, 64)
+}
+
+func (h *Handler) GetExerciseRecords(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	exerciseID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid exercise id", err)
+		return
+	}
+
+	records, err := h.cfg.DB.GetExerciseRecords(r.Context(), database.GetExerciseRecordsParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving personal records", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	response := []PersonalRecord{}
+	for _, pr := range records {
+		response = append(response, PersonalRecord{
+			ID:                pr.ID.String(),
+			ExerciseID:        pr.ExerciseID.String(),
+			WorkoutExerciseID: pr.WorkoutExerciseID.String(),
+			RecordType:        pr.RecordType,
+			Value:             recordValue(pr, system),
+			Reps:              strconv.Itoa(int(pr.Reps)),
+			Weight:            system.FormatWeight(pr.WeightLbs),
+			WeightLbs:         strconv.Itoa(int(pr.WeightLbs)),
+			Unit:              system.Unit(),
+			AchievedAt:        pr.AchievedAt.Format(time.RFC822),
+		})
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/progression"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+type Program struct {
+	ID          string        `json:"id,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Unit        string        `json:"unit,omitempty"`
+	Weeks       []ProgramWeek `json:"weeks,omitempty"`
+	Days        []ProgramDay  `json:"days,omitempty"`
+	CreatedAt   string        `json:"created_at,omitempty"`
+	UpdatedAt   string        `json:"updated_at,omitempty"`
+}
+
+// ProgramWeek's Amount is in the program's unit for add_weight weeks.
+type ProgramWeek struct {
+	Week        string `json:"week"`
+	Progression string `json:"progression"`
+	Amount      string `json:"amount"`
+}
+
+type ProgramDay struct {
+	ID         string               `json:"id"`
+	Day        string               `json:"day"`
+	Title      string               `json:"title"`
+	TemplateID string               `json:"template_id,omitempty"`
+	Duration   string               `json:"duration"`
+	Exercises  []ProgramDayExercise `json:"exercises,omitempty"`
+}
+
+type ProgramDayExercise struct {
+	ExerciseID string    `json:"exercise_id"`
+	Name       string    `json:"name"`
+	Reps       []int32   `json:"reps_per_set"`
+	Weights    []float64 `json:"weights"`
+	WeightsLbs []int32   `json:"weights_lbs"`
+}
+
+type createProgramRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Weeks       string `json:"weeks"`
+}
+
+type enrollProgramRequest struct {
+	StartDate string `json:"start_date"`
+}
+
+type enrollProgramResponse struct {
+	ProgramID string `json:"program_id"`
+	StartDate string `json:"start_date"`
+	Workouts  string `json:"workouts"`
+}
+
+func programResponse(program database.Program) Program {
+	return Program{
+		ID:          program.ID.String(),
+		Name:        program.Name,
+		Description: program.Description.String,
+		CreatedAt:   program.CreatedAt.Format(time.RFC822),
+		UpdatedAt:   program.UpdatedAt.Format(time.RFC822),
+	}
+}
+
+// programAmount renders a week's progression amount, converting added weight
+// to the user's unit.
+func programAmount(week database.ProgramWeek, system units.System) string {
+	if progression.Kind(week.Progression) == progression.AddWeight {
+		return system.FormatWeight(week.Amount)
+	}
+	return strconv.Itoa(int(week.Amount))
+}
+
+func (h *Handler) GetPrograms(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	programs, err := h.cfg.DB.GetUserPrograms(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving programs", err)
+		return
+	}
+
+	response := []Program{}
+	for _, program := range programs {
+		response = append(response, programResponse(program))
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := createProgramRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	input := programInput{
+		Name:        strings.TrimSpace(reqParams.Name),
+		Description: strings.TrimSpace(reqParams.Description),
+		Weeks:       reqParams.Weeks,
+	}
+	if errs := input.validate(); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	program, err := h.createProgram(r.Context(), userID, input)
+	if err != nil {
+		if errors.Is(err, errProgramMaxWeeks) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error creating program", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, programResponse(program))
+}
+
+func (h *Handler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	programID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid program id", err)
+		return
+	}
+
+	program, err := h.cfg.DB.GetProgram(r.Context(), database.GetProgramParams{ID: programID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "program not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving program", err)
+		return
+	}
+
+	weeks, err := h.cfg.DB.GetProgramWeeks(r.Context(), programID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving program weeks", err)
+		return
+	}
+	days, err := h.programDays(r.Context(), programID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving program days", err)
+		return
+	}
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	response := programResponse(program)
+	response.Unit = system.Unit()
+	for _, week := range weeks {
+		response.Weeks = append(response.Weeks, ProgramWeek{
+			Week:        strconv.Itoa(int(week.WeekNumber)),
+			Progression: week.Progression,
+			Amount:      programAmount(week, system),
+		})
+	}
+	for _, d := range days {
+		day := ProgramDay{
+			ID:       d.Day.ID.String(),
+			Day:      strconv.Itoa(int(d.Day.DayNumber)),
+			Title:    d.Day.Title,
+			Duration: strconv.Itoa(int(d.Day.DurationMinutes)),
+		}
+		if d.Day.TemplateID.Valid {
+			day.TemplateID = d.Day.TemplateID.UUID.String()
+		}
+		for _, ex := range d.Exercises {
+			day.Exercises = append(day.Exercises, ProgramDayExercise{
+				ExerciseID: ex.ExerciseID.String(),
+				Name:       ex.ExerciseName,
+				Reps:       ex.RepsPerSet,
+				Weights:    system.Weights(ex.WeightsLbs),
+				WeightsLbs: ex.WeightsLbs,
+			})
+		}
+		response.Days = append(response.Days, day)
+	}
+	utils.RespondWithJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) EnrollInProgram(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	programID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid program id", err)
+		return
+	}
+
+	reqParams := enrollProgramRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	startDate, err := time.Parse(time.DateOnly, reqParams.StartDate)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "start date must be in YYYY-MM-DD format", err)
+		return
+	}
+
+	count, err := h.enrollInProgram(r.Context(), userID, programID, startDate)
+	if err != nil {
+		if errors.Is(err, errProgramEmpty) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "program not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error enrolling in program", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, enrollProgramResponse{
+		ProgramID: programID.String(),
+		StartDate: startDate.Format(time.DateOnly),
+		Workouts:  strconv.Itoa(count),
+	})
+}
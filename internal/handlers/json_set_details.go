@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/effort"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// SetDetail is the effort logged for one completed set. Set is one-based.
+type SetDetail struct {
+	Set   string `json:"set"`
+	RPE   string `json:"rpe,omitempty"`
+	RIR   string `json:"rir,omitempty"`
+	Tempo string `json:"tempo,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+type setDetailsRequest struct {
+	Sets []SetDetail `json:"sets"`
+}
+
+func setDetailResponse(i int, e effort.Set) SetDetail {
+	detail := SetDetail{Set: strconv.Itoa(i + 1), Tempo: e.Tempo, Notes: e.Notes}
+	if e.RPE > 0 {
+		detail.RPE = strconv.FormatFloat(e.RPE, 'f', -1, 64)
+	}
+	if e.RIR != effort.NoRIR {
+		detail.RIR = strconv.Itoa(int(e.RIR))
+	}
+	return detail
+}
+
+// setDetailsResponse lists the sets that have anything logged.
+func setDetailsResponse(efforts []effort.Set) []SetDetail {
+	details := []SetDetail{}
+	for i, e := range efforts {
+		if !e.Empty() {
+			details = append(details, setDetailResponse(i, e))
+		}
+	}
+	return details
+}
+
+// UpdateSetDetails replaces the effort logged for a workout exercise's
+// completed sets. Sets left out of the request are cleared.
+func (h *Handler) UpdateSetDetails(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+	workoutExerciseID, err := uuid.Parse(r.PathValue("workoutExerciseID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout exercise id", err)
+		return
+	}
+
+	reqParams := setDetailsRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	we, err := h.cfg.DB.GetWorkoutExercise(r.Context(), database.GetWorkoutExerciseParams{
+		ID:        workoutExerciseID,
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching workout exercise", err)
+		return
+	}
+
+	efforts := make([]effort.Set, we.SetsCompleted)
+	for i := range efforts {
+		efforts[i] = effort.Set{RIR: effort.NoRIR}
+	}
+	for _, detail := range reqParams.Sets {
+		n, err := strconv.Atoi(detail.Set)
+		if err != nil || n < 1 || n > len(efforts) {
+			utils.RespondWithError(w, http.StatusBadRequest, errSetNotCompleted.Error(), nil)
+			return
+		}
+		set, err := effort.ParseSet(detail.RPE, detail.RIR, detail.Tempo, detail.Notes)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("set %d: %v", n, err), nil)
+			return
+		}
+		efforts[n-1] = set
+	}
+
+	if err := h.saveSetDetails(r.Context(), we.ID, efforts); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error saving set details", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, setDetailsResponse(efforts))
+}
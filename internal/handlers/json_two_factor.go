@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/utils"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+type TwoFactor struct {
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	Code           string `json:"code,omitempty"`
+}
+
+type twoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
+
+type twoFactorStatus struct {
+	Enabled                bool  `json:"enabled"`
+	RecoveryCodesRemaining int64 `json:"recovery_codes_remaining"`
+}
+
+type twoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type recoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// LoginUserTwoFactor trades the challenge token LoginUser returns for users
+// with a second factor, and a code, for session tokens.
+func (h *Handler) LoginUserTwoFactor(w http.ResponseWriter, r *http.Request) {
+	reqParams := TwoFactor{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "Malformed request", err)
+		return
+	}
+	if errs := validate.Fields(
+		validate.Required(reqParams.ChallengeToken, "challenge token"),
+		validate.Required(reqParams.Code, "code"),
+	); errs != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+		return
+	}
+
+	user, err := h.completeLoginChallenge(r.Context(), reqParams.ChallengeToken, reqParams.Code)
+	if err != nil {
+		if errors.Is(err, errInvalidChallenge) || errors.Is(err, errInvalidCode) {
+			utils.RespondWithError(w, http.StatusUnauthorized, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error completing login", err)
+		return
+	}
+
+	if user.DisabledAt.Valid {
+		utils.RespondWithError(w, http.StatusForbidden, "Account disabled", nil)
+		return
+	}
+
+	accessToken, refreshToken, err := h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error issuing session tokens", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, response{
+		User: User{
+			ID:            user.ID.String(),
+			FirstName:     user.FirstName,
+			LastName:      user.LastName,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt.Valid,
+		},
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+func (h *Handler) GetTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	enabled, remaining, err := h.twoFactorStatus(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting two-factor status", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, twoFactorStatus{Enabled: enabled, RecoveryCodesRemaining: remaining})
+}
+
+// CreateTwoFactor starts setting up a second factor. It isn't used for
+// logins until ConfirmTwoFactorJSON gets a code from it.
+func (h *Handler) CreateTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting user", err)
+		return
+	}
+
+	secret, uri, err := h.beginTOTPEnrollment(r.Context(), user)
+	if err != nil {
+		if errors.Is(err, errTwoFactorEnabled) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error setting up two-factor", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, twoFactorSetup{Secret: secret, ProvisioningURI: uri})
+}
+
+func (h *Handler) ConfirmTwoFactorJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := TwoFactor{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	codes, err := h.confirmTOTPEnrollment(r.Context(), userID, reqParams.Code)
+	if err != nil {
+		if errors.Is(err, errInvalidCode) || errors.Is(err, errTwoFactorNotPending) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, errTwoFactorEnabled) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error confirming two-factor", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, recoveryCodes{RecoveryCodes: codes})
+}
+
+func (h *Handler) RegenerateRecoveryCodesJSON(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := TwoFactor{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	codes, err := h.regenerateRecoveryCodes(r.Context(), userID, reqParams.Code)
+	if err != nil {
+		if errors.Is(err, errInvalidCode) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error regenerating recovery codes", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, recoveryCodes{RecoveryCodes: codes})
+}
+
+func (h *Handler) DeleteTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := TwoFactor{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	if err := h.disableTwoFactor(r.Context(), userID, reqParams.Code); err != nil {
+		if errors.Is(err, errInvalidCode) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error disabling two-factor", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+type UnitPreference struct {
+	Units string `json:"units"`
+	Unit  string `json:"unit,omitempty"`
+}
+
+func unitPreferenceResponse(system units.System) UnitPreference {
+	return UnitPreference{Units: string(system), Unit: system.Unit()}
+}
+
+func (h *Handler) GetUnitPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, unitPreferenceResponse(system))
+}
+
+func (h *Handler) UpdateUnitPreference(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := UnitPreference{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+	if reqParams.Units == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "units is required", nil)
+		return
+	}
+	system, err := units.ParseSystem(reqParams.Units)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "units must be imperial or metric", err)
+		return
+	}
+
+	if err := h.saveUnitSystem(r.Context(), userID, system); err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error saving unit system", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, unitPreferenceResponse(system))
+}
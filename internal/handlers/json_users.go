@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"database/sql"
+	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -19,6 +21,9 @@ type User struct {
 	LastName   string `json:"last_name,omitempty"`
 	Email      string `json:"email,omitempty"`
 	Password   string `json:"password,omitempty"`
+	// EmailVerified and PendingEmail are only ever returned
+	EmailVerified bool   `json:"email_verified"`
+	PendingEmail  string `json:"pending_email,omitempty"`
 }
 
 type response struct {
@@ -72,23 +77,40 @@ func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	accessToken, refreshToken, err := h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
-	if err != nil {
-		utils.RespondWithError(w, http.StatusInternalServerError, "Error issuing session tokens", err)
+	if user.DisabledAt.Valid {
+		utils.RespondWithError(w, http.StatusForbidden, "Account disabled", nil)
 		return
 	}
 
-	utils.RespondWithJSON(w, http.StatusCreated, response{
+	// The account exists either way, so a failed send is left to be resent
+	if err := h.sendEmailVerification(r.Context(), user, user.Email); err != nil {
+		h.cfg.Logger.Error("failed to send email verification", slog.String("error", err.Error()))
+	}
+
+	resp := response{
 		User: User{
-			ID:         user.ID.String(),
-			FirstName:  user.FirstName,
-			MiddleName: user.MiddleName.String,
-			LastName:   user.LastName,
-			Email:      user.Email,
+			ID:            user.ID.String(),
+			FirstName:     user.FirstName,
+			MiddleName:    user.MiddleName.String,
+			LastName:      user.LastName,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt.Valid,
 		},
-		AccessToken:  accessToken,
-		RefreshToken: refreshToken,
-	})
+	}
+
+	// Tokens are withheld until the email is verified
+	if h.unverifiedBlocked(user) {
+		utils.RespondWithJSON(w, http.StatusCreated, resp)
+		return
+	}
+
+	resp.AccessToken, resp.RefreshToken, err = h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error issuing session tokens", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusCreated, resp)
 }
 
 func (h *Handler) LoginUser(w http.ResponseWriter, r *http.Request) {
@@ -127,6 +149,33 @@ func (h *Handler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if user.DisabledAt.Valid {
+		utils.RespondWithError(w, http.StatusForbidden, "Account disabled", nil)
+		return
+	}
+
+	if h.unverifiedBlocked(user) {
+		utils.RespondWithError(w, http.StatusForbidden, "Email not verified", nil)
+		return
+	}
+
+	twoFactor, err := h.twoFactorEnabled(r.Context(), user.ID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting two-factor status", err)
+		return
+	}
+
+	// Tokens wait on a code sent to LoginUserTwoFactor
+	if twoFactor {
+		challenge, err := h.startLoginChallenge(r.Context(), user.ID)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error starting login challenge", err)
+			return
+		}
+		utils.RespondWithJSON(w, http.StatusAccepted, twoFactorChallenge{TwoFactorRequired: true, ChallengeToken: challenge})
+		return
+	}
+
 	accessToken, refreshToken, err := h.issueSessionTokens(r.Context(), w, user.ID, user.IsAdmin)
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "Error issuing session tokens", err)
@@ -135,10 +184,11 @@ func (h *Handler) LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	utils.RespondWithJSON(w, http.StatusOK, response{
 		User: User{
-			ID:        user.ID.String(),
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Email:     user.Email,
+			ID:            user.ID.String(),
+			FirstName:     user.FirstName,
+			LastName:      user.LastName,
+			Email:         user.Email,
+			EmailVerified: user.EmailVerifiedAt.Valid,
 		},
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
@@ -175,9 +225,26 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		userParams.HashedPassword.Valid = true
 	}
 
+	// A new email only replaces the current one once it's verified
 	if reqParams.Email != "" {
-		userParams.Email.String = reqParams.Email
-		userParams.Email.Valid = true
+		if errs := validate.Fields(validate.MaxLen(reqParams.Email, 255, "email")); errs != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, errs[0].Error(), nil)
+			return
+		}
+		user, err := h.cfg.DB.GetUserByID(r.Context(), userID)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error getting user", err)
+			return
+		}
+		err = h.changeEmail(r.Context(), user, reqParams.Email)
+		if errors.Is(err, errEmailTaken) || errors.Is(err, errSameEmail) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "Error sending email verification", err)
+			return
+		}
 	}
 
 	updatedUser, err := h.cfg.DB.UpdateUser(r.Context(), userParams)
@@ -186,11 +253,19 @@ func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pending, err := h.pendingEmail(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "Error getting pending email", err)
+		return
+	}
+
 	utils.RespondWithJSON(w, http.StatusOK, response{User: User{
-		ID:        updatedUser.ID.String(),
-		FirstName: updatedUser.FirstName,
-		LastName:  updatedUser.LastName,
-		Email:     updatedUser.Email,
+		ID:            updatedUser.ID.String(),
+		FirstName:     updatedUser.FirstName,
+		LastName:      updatedUser.LastName,
+		Email:         updatedUser.Email,
+		EmailVerified: updatedUser.EmailVerifiedAt.Valid,
+		PendingEmail:  pending,
 	}})
 }
 
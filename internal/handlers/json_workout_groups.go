@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/effort"
+	"github.com/kairos4213/fithub/internal/grouping"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+type WorkoutGroup struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	Label       string `json:"label"`
+	Rounds      string `json:"rounds"`
+	RestSeconds string `json:"rest_seconds"`
+}
+
+type WorkoutExercise struct {
+	ID               string      `json:"id"`
+	ExerciseID       string      `json:"exercise_id"`
+	Name             string      `json:"name"`
+	Position         string      `json:"position,omitempty"`
+	SortOrder        string      `json:"sort_order"`
+	SetsPlanned      string      `json:"sets_planned"`
+	RepsPlanned      []int32     `json:"reps_per_set_planned"`
+	WeightsPlanned   []float64   `json:"weights_planned"`
+	PlannedLbs       []int32     `json:"weights_planned_lbs"`
+	SetsCompleted    string      `json:"sets_completed"`
+	RepsCompleted    []int32     `json:"reps_per_set_completed"`
+	WeightsCompleted []float64   `json:"weights_completed"`
+	CompletedLbs     []int32     `json:"weights_completed_lbs"`
+	Unit             string      `json:"unit"`
+	SetDetails       []SetDetail `json:"set_details"`
+
+	// Timed exercises are logged by duration, distance and heart rate
+	TrackingType             string    `json:"tracking_type"`
+	DurationsPlanned         []int32   `json:"durations_planned_seconds"`
+	DistancesPlanned         []float64 `json:"distances_planned"`
+	DistancesPlannedMeters   []int32   `json:"distances_planned_meters"`
+	DurationsCompleted       []int32   `json:"durations_completed_seconds"`
+	DistancesCompleted       []float64 `json:"distances_completed"`
+	DistancesCompletedMeters []int32   `json:"distances_completed_meters"`
+	HeartRatesCompleted      []int32   `json:"heart_rates_completed"`
+	Paces                    []string  `json:"paces"`
+	DistanceUnit             string    `json:"distance_unit"`
+}
+
+// WorkoutExerciseBlock is either a single ungrouped exercise or every
+// exercise of a superset or circuit, in the order they're shown.
+type WorkoutExerciseBlock struct {
+	Group     *WorkoutGroup     `json:"group,omitempty"`
+	Exercises []WorkoutExercise `json:"exercises"`
+}
+
+type workoutGroupRequest struct {
+	Kind        string   `json:"kind"`
+	Label       string   `json:"label"`
+	Rounds      string   `json:"rounds"`
+	RestSeconds string   `json:"rest_seconds"`
+	Members     []string `json:"workout_exercise_ids"`
+}
+
+func workoutGroupResponse(g database.WorkoutExerciseGroup) WorkoutGroup {
+	return WorkoutGroup{
+		ID:          g.ID.String(),
+		Kind:        g.Kind,
+		Label:       g.Label,
+		Rounds:      strconv.Itoa(int(g.Rounds)),
+		RestSeconds: strconv.Itoa(int(g.RestSeconds)),
+	}
+}
+
+func workoutExerciseResponse(row database.WorkoutAndExercisesRow, position string, efforts []effort.Set, system units.System) WorkoutExercise {
+	we := row.WorkoutsExercise
+	return WorkoutExercise{
+		ID:               we.ID.String(),
+		ExerciseID:       row.Exercise.ID.String(),
+		Name:             row.Exercise.Name,
+		Position:         position,
+		SortOrder:        strconv.Itoa(int(we.SortOrder)),
+		SetsPlanned:      strconv.Itoa(int(we.SetsPlanned)),
+		RepsPlanned:      we.RepsPerSetPlanned,
+		WeightsPlanned:   system.Weights(we.WeightsPlannedLbs),
+		PlannedLbs:       we.WeightsPlannedLbs,
+		SetsCompleted:    strconv.Itoa(int(we.SetsCompleted)),
+		RepsCompleted:    we.RepsPerSetCompleted,
+		WeightsCompleted: system.Weights(we.WeightsCompletedLbs),
+		CompletedLbs:     we.WeightsCompletedLbs,
+		Unit:             system.Unit(),
+		SetDetails:       setDetailsResponse(efforts),
+
+		TrackingType:             row.Exercise.TrackingType,
+		DurationsPlanned:         we.DurationsPlannedSeconds,
+		DistancesPlanned:         system.Distances(we.DistancesPlannedMeters),
+		DistancesPlannedMeters:   we.DistancesPlannedMeters,
+		DurationsCompleted:       we.DurationsCompletedSeconds,
+		DistancesCompleted:       system.Distances(we.DistancesCompletedMeters),
+		DistancesCompletedMeters: we.DistancesCompletedMeters,
+		HeartRatesCompleted:      we.HeartRatesCompleted,
+		Paces:                    paces(we, system),
+		DistanceUnit:             system.DistanceUnit(),
+	}
+}
+
+// paces lists the pace of each completed timed set, blank where a set has no
+// distance.
+func paces(we database.WorkoutsExercise, system units.System) []string {
+	out := make([]string, len(we.DurationsCompletedSeconds))
+	for i, seconds := range we.DurationsCompletedSeconds {
+		out[i] = tracking.Pace(seconds, tracking.At(we.DistancesCompletedMeters, i), system)
+	}
+	return out
+}
+
+func (h *Handler) GetWorkoutExercises(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+
+	_, err = h.cfg.DB.GetWorkoutByID(r.Context(), database.GetWorkoutByIDParams{ID: workoutID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching workout", err)
+		return
+	}
+
+	rows, err := h.cfg.DB.WorkoutAndExercises(r.Context(), database.WorkoutAndExercisesParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching workout exercises", err)
+		return
+	}
+	groups, err := h.cfg.DB.GetWorkoutExerciseGroups(r.Context(), workoutID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching workout groups", err)
+		return
+	}
+	byID := workoutGroupsByID(groups)
+	details, err := h.cfg.DB.GetWorkoutSetDetails(r.Context(), database.GetWorkoutSetDetailsParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching set details", err)
+		return
+	}
+	efforts := effortByWorkoutExercise(details)
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	blocks := []WorkoutExerciseBlock{}
+	layout := grouping.Layout(rows, func(row database.WorkoutAndExercisesRow) uuid.NullUUID {
+		return row.WorkoutsExercise.GroupID
+	})
+	for _, block := range layout {
+		group, grouped := byID[block.Group.UUID]
+		if !block.Group.Valid || !grouped {
+			for _, row := range block.Items {
+				blocks = append(blocks, WorkoutExerciseBlock{Exercises: []WorkoutExercise{workoutExerciseResponse(row, "", efforts[row.WorkoutsExercise.ID], system)}})
+			}
+			continue
+		}
+
+		resp := workoutGroupResponse(group)
+		exercises := make([]WorkoutExercise, len(block.Items))
+		for i, row := range block.Items {
+			exercises[i] = workoutExerciseResponse(row, grouping.Position(group.Label, i), efforts[row.WorkoutsExercise.ID], system)
+		}
+		blocks = append(blocks, WorkoutExerciseBlock{Group: &resp, Exercises: exercises})
+	}
+	utils.RespondWithJSON(w, http.StatusOK, blocks)
+}
+
+func (h *Handler) CreateWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	h.saveWorkoutGroupJSON(w, r, uuid.NullUUID{}, http.StatusCreated)
+}
+
+func (h *Handler) UpdateWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	groupID, err := uuid.Parse(r.PathValue("groupID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid group id", err)
+		return
+	}
+	h.saveWorkoutGroupJSON(w, r, uuid.NullUUID{UUID: groupID, Valid: true}, http.StatusOK)
+}
+
+func (h *Handler) saveWorkoutGroupJSON(w http.ResponseWriter, r *http.Request, groupID uuid.NullUUID, status int) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+
+	reqParams := workoutGroupRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	others, err := h.otherGroups(r.Context(), workoutID, groupID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error fetching workout groups", err)
+		return
+	}
+	group, members, err := groupInput(reqParams).parse(others)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+		return
+	}
+
+	saved, err := h.saveWorkoutGroup(r.Context(), userID, workoutID, groupID, group, members)
+	if err != nil {
+		if errors.Is(err, errUnknownWorkoutExercise) || errors.Is(err, errExerciseInOtherGroup) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "group not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error saving workout group", err)
+		return
+	}
+	utils.RespondWithJSON(w, status, workoutGroupResponse(saved))
+}
+
+func (h *Handler) DeleteWorkoutGroup(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+	groupID, err := uuid.Parse(r.PathValue("groupID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid group id", err)
+		return
+	}
+
+	err = h.deleteWorkoutGroup(r.Context(), userID, workoutID, groupID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting workout group", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/session"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// logSetRequest takes the weight either in the user's unit or in pounds.
+// Timed exercises send a duration, as seconds or m:ss, instead of reps and
+// weight, with the distance either in the user's unit or in meters.
+type logSetRequest struct {
+	Set            string `json:"set"`
+	Reps           string `json:"reps"`
+	Weight         string `json:"weight"`
+	WeightLbs      string `json:"weight_lbs"`
+	Duration       string `json:"duration"`
+	Distance       string `json:"distance"`
+	DistanceMeters string `json:"distance_meters"`
+	HeartRate      string `json:"heart_rate"`
+}
+
+type SessionSets struct {
+	WorkoutExerciseID string    `json:"workout_exercise_id"`
+	SetsCompleted     string    `json:"sets_completed"`
+	Reps              []int32   `json:"reps_per_set_completed"`
+	Weights           []float64 `json:"weights_completed"`
+	WeightsLbs        []int32   `json:"weights_completed_lbs"`
+	Unit              string    `json:"unit"`
+	DurationsSeconds  []int32   `json:"durations_completed_seconds"`
+	Distances         []float64 `json:"distances_completed"`
+	DistancesMeters   []int32   `json:"distances_completed_meters"`
+	DistanceUnit      string    `json:"distance_unit"`
+	HeartRates        []int32   `json:"heart_rates_completed"`
+	RestSeconds       string    `json:"rest_seconds"`
+}
+
+func (h *Handler) StartWorkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+
+	workout, err := h.startWorkout(r.Context(), userID, workoutID)
+	if err != nil {
+		if errors.Is(err, errSessionFinished) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error starting workout", err)
+		return
+	}
+	utils.RespondWithJSON(w, http.StatusOK, workoutResponse(workout))
+}
+
+func (h *Handler) LogWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+
+	reqParams := logSetRequest{}
+	if err := utils.ParseJSON(r, &reqParams); err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "malformed request", err)
+		return
+	}
+
+	index, err := strconv.Atoi(reqParams.Set)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "set must be a number", err)
+		return
+	}
+
+	if reqParams.Duration != "" {
+		system, err := h.unitSystem(r.Context(), userID)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+			return
+		}
+		set, err := parseTimedSet(reqParams.Duration, reqParams.Distance, reqParams.HeartRate, system)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if reqParams.Distance == "" && reqParams.DistanceMeters != "" {
+			meters, err := strconv.ParseInt(reqParams.DistanceMeters, 10, 32)
+			if err != nil || meters < 0 {
+				utils.RespondWithError(w, http.StatusBadRequest, "distance_meters must be a whole number of at least 0", err)
+				return
+			}
+			set.Meters = int32(meters)
+		}
+		h.updateSessionSetsJSON(w, r, func(sets session.Sets) (session.Sets, error) {
+			return sets.LogTimed(index, set)
+		})
+		return
+	}
+
+	reps, err := strconv.ParseInt(reqParams.Reps, 10, 32)
+	if err != nil || reps < 1 {
+		utils.RespondWithError(w, http.StatusBadRequest, "reps must be a number greater than 0", err)
+		return
+	}
+	system := units.Imperial
+	reqWeight := reqParams.WeightLbs
+	if reqParams.Weight != "" {
+		system, err = h.unitSystem(r.Context(), userID)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+			return
+		}
+		reqWeight = reqParams.Weight
+	}
+	weight, err := system.ParseWeight(reqWeight)
+	if err != nil || reqWeight == "" {
+		utils.RespondWithError(w, http.StatusBadRequest, "weight must be a number of at least 0", err)
+		return
+	}
+
+	h.updateSessionSetsJSON(w, r, func(sets session.Sets) (session.Sets, error) {
+		return sets.Log(index, int32(reps), weight)
+	})
+}
+
+func (h *Handler) UndoWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	h.updateSessionSetsJSON(w, r, session.Sets.Undo)
+}
+
+func (h *Handler) FinishWorkout(w http.ResponseWriter, r *http.Request) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+
+	workout, err := h.finishWorkout(r.Context(), userID, workoutID)
+	if err != nil {
+		if errors.Is(err, errSessionNotStarted) || errors.Is(err, errSessionFinished) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error finishing workout", err)
+		return
+	}
+	h.checkGoals(r.Context(), userID)
+	utils.RespondWithJSON(w, http.StatusOK, workoutResponse(workout))
+}
+
+func (h *Handler) updateSessionSetsJSON(w http.ResponseWriter, r *http.Request, change func(session.Sets) (session.Sets, error)) {
+	userID, ok := cntx.UserID(r.Context())
+	if !ok {
+		utils.RespondWithError(w, http.StatusInternalServerError, "missing user id in context", nil)
+		return
+	}
+	workoutID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout id", err)
+		return
+	}
+	workoutExerciseID, err := uuid.Parse(r.PathValue("workoutExerciseID"))
+	if err != nil {
+		utils.RespondWithError(w, http.StatusBadRequest, "invalid workout exercise id", err)
+		return
+	}
+
+	we, _, err := h.updateSessionSets(r.Context(), userID, workoutID, workoutExerciseID, change)
+	if err != nil {
+		if errors.Is(err, errSessionNotStarted) || errors.Is(err, errSessionFinished) {
+			utils.RespondWithError(w, http.StatusConflict, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, session.ErrSetOutOfOrder) || errors.Is(err, session.ErrNoSets) ||
+			errors.Is(err, session.ErrTimedSets) || errors.Is(err, session.ErrRepSets) {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			utils.RespondWithError(w, http.StatusNotFound, "workout exercise not found", nil)
+			return
+		}
+		utils.RespondWithError(w, http.StatusInternalServerError, "error updating sets", err)
+		return
+	}
+
+	rest, err := h.sessionRest(r.Context(), userID, we)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error getting rest after set", err)
+		return
+	}
+
+	system, err := h.unitSystem(r.Context(), userID)
+	if err != nil {
+		utils.RespondWithError(w, http.StatusInternalServerError, "error retrieving unit system", err)
+		return
+	}
+
+	utils.RespondWithJSON(w, http.StatusOK, SessionSets{
+		WorkoutExerciseID: we.ID.String(),
+		SetsCompleted:     strconv.Itoa(int(we.SetsCompleted)),
+		Reps:              we.RepsPerSetCompleted,
+		Weights:           system.Weights(we.WeightsCompletedLbs),
+		WeightsLbs:        we.WeightsCompletedLbs,
+		Unit:              system.Unit(),
+		DurationsSeconds:  we.DurationsCompletedSeconds,
+		Distances:         system.Distances(we.DistancesCompletedMeters),
+		DistancesMeters:   we.DistancesCompletedMeters,
+		DistanceUnit:      system.DistanceUnit(),
+		HeartRates:        we.HeartRatesCompleted,
+		RestSeconds:       strconv.Itoa(int(rest)),
+	})
+}
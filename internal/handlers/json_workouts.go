@@ -15,15 +15,57 @@ import (
 )
 
 type Workout struct {
-	ID            string `json:"id,omitempty"`
-	UserID        string `json:"user_id,omitempty"`
-	Title         string `json:"title,omitempty"`
-	Description   string `json:"description,omitempty"`
-	Duration      string `json:"duration,omitempty"`
-	PlannedDate   string `json:"planned_date,omitempty"`
-	DateCompleted string `json:"date_completed,omitempty"`
-	CreatedAt     string `json:"created_at,omitempty"`
-	UpdatedAt     string `json:"updated_at,omitempty"`
+	ID            string  `json:"id,omitempty"`
+	UserID        string  `json:"user_id,omitempty"`
+	Title         string  `json:"title,omitempty"`
+	Description   string  `json:"description,omitempty"`
+	Duration      string  `json:"duration,omitempty"`
+	PlannedDate   string  `json:"planned_date,omitempty"`
+	DateCompleted string  `json:"date_completed,omitempty"`
+	SeriesID      string  `json:"series_id,omitempty"`
+	StartedAt     string  `json:"started_at,omitempty"`
+	Repeat        *Repeat `json:"repeat,omitempty"`
+	Scope         string  `json:"scope,omitempty"`
+	CreatedAt     string  `json:"created_at,omitempty"`
+	UpdatedAt     string  `json:"updated_at,omitempty"`
+}
+
+// Repeat is the recurrence rule accepted when creating a workout. Set either
+// Weekdays ("mon", "wed") or IntervalDays, along with Weeks.
+type Repeat struct {
+	Weekdays     []string `json:"weekdays,omitempty"`
+	IntervalDays string   `json:"interval_days,omitempty"`
+	Weeks        string   `json:"weeks,omitempty"`
+}
+
+func workoutResponse(workout database.Workout) Workout {
+	return Workout{
+		ID:            workout.ID.String(),
+		UserID:        workout.UserID.String(),
+		Title:         workout.Title,
+		Description:   workout.Description.String,
+		Duration:      strconv.FormatInt(int64(workout.DurationMinutes), 10),
+		PlannedDate:   workout.PlannedDate.Format(time.DateOnly),
+		DateCompleted: workout.DateCompleted.Time.Format(time.DateOnly),
+		SeriesID:      seriesIDString(workout.SeriesID),
+		StartedAt:     startedAtString(workout.StartedAt),
+		CreatedAt:     workout.CreatedAt.Format(time.RFC822),
+		UpdatedAt:     workout.UpdatedAt.Format(time.RFC822),
+	}
+}
+
+func startedAtString(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
+
+func seriesIDString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
 }
 
 func (h *Handler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
@@ -68,13 +110,25 @@ func (h *Handler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	workout, err := h.cfg.DB.CreateWorkout(r.Context(), database.CreateWorkoutParams{
+	params := database.CreateWorkoutParams{
 		UserID:          userID,
 		Title:           reqParams.Title,
 		Description:     workoutDescription,
 		DurationMinutes: int32(workoutDuration),
 		PlannedDate:     plannedDate,
-	})
+	}
+
+	var workout database.Workout
+	if reqParams.Repeat != nil {
+		rule, err := parseRule(reqParams.Repeat.Weekdays, reqParams.Repeat.IntervalDays, reqParams.Repeat.Weeks)
+		if err != nil {
+			utils.RespondWithError(w, http.StatusBadRequest, err.Error(), nil)
+			return
+		}
+		workout, err = h.createRepeatingWorkout(r.Context(), params, rule)
+	} else {
+		workout, err = h.cfg.DB.CreateWorkout(r.Context(), params)
+	}
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "error creating workout", err)
 		return
@@ -88,6 +142,8 @@ func (h *Handler) CreateWorkout(w http.ResponseWriter, r *http.Request) {
 		Duration:      strconv.FormatInt(int64(workout.DurationMinutes), 10),
 		PlannedDate:   workout.PlannedDate.Format(time.DateOnly),
 		DateCompleted: workout.DateCompleted.Time.Format(time.DateOnly),
+		SeriesID:      seriesIDString(workout.SeriesID),
+		StartedAt:     startedAtString(workout.StartedAt),
 		CreatedAt:     workout.CreatedAt.Format(time.RFC822),
 		UpdatedAt:     workout.UpdatedAt.Format(time.RFC822),
 	})
@@ -117,6 +173,8 @@ func (h *Handler) GetAllUserWorkouts(w http.ResponseWriter, r *http.Request) {
 			Duration:      strconv.FormatInt(int64(workout.DurationMinutes), 10),
 			PlannedDate:   workout.PlannedDate.Format(time.DateOnly),
 			DateCompleted: workout.DateCompleted.Time.Format(time.DateOnly),
+			SeriesID:      seriesIDString(workout.SeriesID),
+			StartedAt:     startedAtString(workout.StartedAt),
 			CreatedAt:     workout.CreatedAt.Format(time.RFC822),
 			UpdatedAt:     workout.UpdatedAt.Format(time.RFC822),
 		})
@@ -194,6 +252,21 @@ func (h *Handler) UpdateWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if reqParams.Scope == scopeFuture && updatedWorkout.SeriesID.Valid {
+		err = h.cfg.DB.UpdateFutureSeriesWorkouts(r.Context(), database.UpdateFutureSeriesWorkoutsParams{
+			Title:           reqParams.Title,
+			Description:     workoutDescription,
+			DurationMinutes: int32(workoutDuration),
+			SeriesID:        updatedWorkout.SeriesID,
+			UserID:          userID,
+			PlannedDate:     updatedWorkout.PlannedDate,
+		})
+		if err != nil {
+			utils.RespondWithError(w, http.StatusInternalServerError, "error updating future workouts", err)
+			return
+		}
+	}
+
 	utils.RespondWithJSON(w, http.StatusOK, Workout{
 		ID:            updatedWorkout.ID.String(),
 		UserID:        updatedWorkout.UserID.String(),
@@ -202,6 +275,8 @@ func (h *Handler) UpdateWorkout(w http.ResponseWriter, r *http.Request) {
 		Duration:      strconv.FormatInt(int64(updatedWorkout.DurationMinutes), 10),
 		PlannedDate:   updatedWorkout.PlannedDate.Format(time.DateOnly),
 		DateCompleted: updatedWorkout.DateCompleted.Time.Format(time.DateOnly),
+		SeriesID:      seriesIDString(updatedWorkout.SeriesID),
+		StartedAt:     startedAtString(updatedWorkout.StartedAt),
 		CreatedAt:     updatedWorkout.CreatedAt.Format(time.RFC822),
 		UpdatedAt:     updatedWorkout.UpdatedAt.Format(time.RFC822),
 	})
@@ -219,9 +294,13 @@ func (h *Handler) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.cfg.DB.DeleteWorkout(r.Context(), database.DeleteWorkoutParams{
-		ID: workoutID, UserID: userID,
-	})
+	if r.URL.Query().Get("scope") == scopeFuture {
+		err = h.deleteFutureOccurrences(r.Context(), userID, workoutID)
+	} else {
+		err = h.cfg.DB.DeleteWorkout(r.Context(), database.DeleteWorkoutParams{
+			ID: workoutID, UserID: userID,
+		})
+	}
 	if err != nil {
 		utils.RespondWithError(w, http.StatusInternalServerError, "error deleting workout", err)
 		return
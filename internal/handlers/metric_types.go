@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/metrics"
+	"github.com/kairos4213/fithub/internal/trend"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var (
+	errUnknownMetric = errors.New("metric type not found")
+	errMetricExists  = errors.New("a metric with this name already exists")
+)
+
+// metricTypeFrom converts a stored metric type into the rules its values
+// follow.
+func metricTypeFrom(mt database.MetricType) metrics.Type {
+	minValue, _ := strconv.ParseFloat(mt.MinValue, 64)
+	maxValue, _ := strconv.ParseFloat(mt.MaxValue, 64)
+	return metrics.Type{
+		Slug:          mt.Slug,
+		Name:          mt.Name,
+		Kind:          metrics.Kind(mt.Kind),
+		Unit:          mt.Unit,
+		Decimals:      int(mt.Decimals),
+		Min:           minValue,
+		Max:           maxValue,
+		SecondaryName: mt.SecondaryName.String,
+	}
+}
+
+// metricTypesFrom converts every stored metric type, keeping their order.
+func metricTypesFrom(mts []database.MetricType) []metrics.Type {
+	out := make([]metrics.Type, len(mts))
+	for i, mt := range mts {
+		out[i] = metricTypeFrom(mt)
+	}
+	return out
+}
+
+// lookupMetricType finds a built-in metric type or one of the user's own by
+// slug. The paths the old per-metric tables were served under still resolve.
+func (h *Handler) lookupMetricType(ctx context.Context, userID uuid.UUID, slug string) (database.MetricType, error) {
+	mt, err := h.cfg.DB.GetMetricTypeBySlug(ctx, database.GetMetricTypeBySlugParams{
+		Slug:   metrics.Canonical(slug),
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.MetricType{}, errUnknownMetric
+	}
+	return mt, err
+}
+
+// createCustomMetric saves a user-defined metric type, refusing names that
+// clash with a built-in type or another of the user's own.
+func (h *Handler) createCustomMetric(ctx context.Context, userID uuid.UUID, t metrics.Type) (database.MetricType, error) {
+	if _, err := h.lookupMetricType(ctx, userID, t.Slug); err == nil {
+		return database.MetricType{}, errMetricExists
+	} else if !errors.Is(err, errUnknownMetric) {
+		return database.MetricType{}, err
+	}
+
+	return h.cfg.DB.CreateMetricType(ctx, database.CreateMetricTypeParams{
+		UserID:   uuid.NullUUID{UUID: userID, Valid: true},
+		Slug:     t.Slug,
+		Name:     t.Name,
+		Unit:     t.Unit,
+		Decimals: int32(t.Decimals),
+		MinValue: strconv.FormatFloat(t.Min, 'f', 2, 64),
+		MaxValue: strconv.FormatFloat(t.Max, 'f', 2, 64),
+	})
+}
+
+// parseMeasurement reads a measurement entered in the user's unit and, for
+// paired types such as blood pressure, its second value.
+func parseMeasurement(t metrics.Type, value, secondary string, system units.System) (string, sql.NullString, []validate.FieldError) {
+	if errs := validate.Fields(
+		validate.Required(value, "measurement"),
+		validate.Numeric(value, "measurement"),
+	); errs != nil {
+		return "", sql.NullString{}, errs
+	}
+	stored, err := t.Parse(value, system)
+	if err != nil {
+		return "", sql.NullString{}, []validate.FieldError{{Field: "measurement", Message: err.Error()}}
+	}
+	if !t.Paired() {
+		return stored, sql.NullString{}, nil
+	}
+
+	if errs := validate.Fields(
+		validate.Required(secondary, "secondary measurement"),
+		validate.Numeric(secondary, "secondary measurement"),
+	); errs != nil {
+		return "", sql.NullString{}, errs
+	}
+	second := t
+	second.Name = t.SecondaryName
+	storedSecond, err := second.Parse(secondary, system)
+	if err != nil {
+		return "", sql.NullString{}, []validate.FieldError{{Field: "secondary measurement", Message: err.Error()}}
+	}
+	return stored, sql.NullString{String: storedSecond, Valid: true}, nil
+}
+
+// parseMeasuredAt reads when a measurement was taken. Blank leaves it unset,
+// so a new measurement is stamped now and an edited one keeps its time.
+func parseMeasuredAt(value string) (sql.NullTime, []validate.FieldError) {
+	if strings.TrimSpace(value) == "" {
+		return sql.NullTime{}, nil
+	}
+	t, err := metrics.ParseMeasuredAt(value, time.Now())
+	if err != nil {
+		return sql.NullTime{}, []validate.FieldError{{Field: "measured at", Message: err.Error()}}
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+// metricSummary computes the trend of a metric's measurements in the stored
+// unit. Paired types follow their first value.
+func metricSummary(measurements []database.Measurement) trend.Summary {
+	points := make([]trend.Point, 0, len(measurements))
+	for _, m := range measurements {
+		v, err := strconv.ParseFloat(m.Value, 64)
+		if err != nil {
+			continue
+		}
+		points = append(points, trend.Point{At: m.MeasuredAt, Value: v})
+	}
+	return trend.Summarize(points, time.Now())
+}
+
+// parseTarget reads a target entered in the user's unit as a stored value.
+func parseTarget(t metrics.Type, target string, system units.System) (float64, error) {
+	stored, err := t.Parse(target, system)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(stored, 64)
+}
@@ -0,0 +1,348 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/config"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/templates"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/github"
+)
+
+// oauthTimeout bounds each request made to a provider
+const oauthTimeout = 10 * time.Second
+
+var (
+	errUnknownOAuthProvider = errors.New("unknown sign in provider")
+	errOAuthEmailUnverified = errors.New("the provider hasn't verified this account's email")
+	errOAuthNonce           = errors.New("id token nonce doesn't match")
+)
+
+// oauthIdentity is who a provider says signed in.
+type oauthIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+	Picture       string
+}
+
+// oauthClient signs users in with one provider. Only OpenID Connect
+// providers have a verifier; others are asked who signed in through their
+// own APIs.
+type oauthClient struct {
+	key      string
+	config   *oauth2.Config
+	provider *oidc.Provider
+	verifier *oidc.IDTokenVerifier
+}
+
+// oauthRegistry builds clients for the configured providers as they're first
+// used, so a provider being down doesn't stop the app starting.
+type oauthRegistry struct {
+	providers map[string]config.OAuthProvider
+
+	mu      sync.Mutex
+	clients map[string]*oauthClient
+}
+
+func newOAuthRegistry(providers map[string]config.OAuthProvider) *oauthRegistry {
+	return &oauthRegistry{providers: providers, clients: make(map[string]*oauthClient)}
+}
+
+// links returns the configured providers for sign in buttons, in key order.
+func (reg *oauthRegistry) links() []templates.OAuthLink {
+	links := make([]templates.OAuthLink, 0, len(reg.providers))
+	for key, p := range reg.providers {
+		links = append(links, templates.OAuthLink{Key: key, Name: p.Name})
+	}
+	slices.SortFunc(links, func(a, b templates.OAuthLink) int { return strings.Compare(a.Key, b.Key) })
+	return links
+}
+
+// client returns the client for the provider named key, discovering its
+// endpoints the first time if it's OpenID Connect.
+func (reg *oauthRegistry) client(key string) (*oauthClient, error) {
+	p, ok := reg.providers[key]
+	if !ok {
+		return nil, errUnknownOAuthProvider
+	}
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if c, ok := reg.clients[key]; ok {
+		return c, nil
+	}
+
+	c := &oauthClient{
+		key: key,
+		config: &oauth2.Config{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			RedirectURL:  p.RedirectURL,
+		},
+	}
+	switch {
+	case p.Issuer != "":
+		// Kept for fetching signing keys long after this request is done
+		ctx := oidc.ClientContext(context.Background(), &http.Client{Timeout: oauthTimeout})
+		provider, err := oidc.NewProvider(ctx, p.Issuer)
+		if err != nil {
+			return nil, fmt.Errorf("discovering %s: %w", key, err)
+		}
+		c.provider = provider
+		c.verifier = provider.Verifier(&oidc.Config{ClientID: p.ClientID})
+		c.config.Endpoint = provider.Endpoint()
+		c.config.Scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	case key == "github":
+		c.config.Endpoint = github.Endpoint
+		c.config.Scopes = []string{"read:user", "user:email"}
+	default:
+		return nil, fmt.Errorf("provider %s has no issuer", key)
+	}
+
+	reg.clients[key] = c
+	return c, nil
+}
+
+// authCodeURL is where to send the user to sign in. The nonce is only used
+// by OpenID Connect providers, which put it in the ID token.
+func (c *oauthClient) authCodeURL(state, verifier, nonce string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if c.verifier != nil {
+		opts = append(opts, oidc.Nonce(nonce))
+	}
+	return c.config.AuthCodeURL(state, opts...)
+}
+
+// identify exchanges code for tokens and returns who signed in.
+func (c *oauthClient) identify(ctx context.Context, code, verifier, nonce string) (oauthIdentity, error) {
+	ctx, cancel := context.WithTimeout(ctx, oauthTimeout)
+	defer cancel()
+
+	token, err := c.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return oauthIdentity{}, err
+	}
+	if c.verifier == nil {
+		return fetchGitHubIdentity(ctx, c.config.Client(ctx, token))
+	}
+	return c.oidcIdentity(ctx, token, nonce)
+}
+
+type oidcClaims struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+	Picture       string `json:"picture"`
+}
+
+// oidcIdentity checks the ID token returned with token and reads the user
+// from its claims, asking the userinfo endpoint for any it leaves out.
+func (c *oauthClient) oidcIdentity(ctx context.Context, token *oauth2.Token, nonce string) (oauthIdentity, error) {
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return oauthIdentity{}, errors.New("no id token in token response")
+	}
+	idToken, err := c.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return oauthIdentity{}, err
+	}
+	if idToken.Nonce != nonce {
+		return oauthIdentity{}, errOAuthNonce
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return oauthIdentity{}, err
+	}
+	if claims.Email == "" && c.provider.UserInfoEndpoint() != "" {
+		info, err := c.provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+		if err != nil {
+			return oauthIdentity{}, err
+		}
+		if info.Subject != idToken.Subject {
+			return oauthIdentity{}, errors.New("userinfo subject doesn't match id token")
+		}
+		if err := info.Claims(&claims); err != nil {
+			return oauthIdentity{}, err
+		}
+		claims.EmailVerified = info.EmailVerified
+	}
+
+	first, last := claims.GivenName, claims.FamilyName
+	if first == "" && last == "" {
+		first, last = splitName(claims.Name)
+	}
+	return oauthIdentity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		FirstName:     first,
+		LastName:      last,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+type githubUser struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+// fetchGitHubIdentity asks GitHub's API who signed in. Their primary email
+// is used, as it's the one GitHub says is theirs.
+func fetchGitHubIdentity(ctx context.Context, client *http.Client) (oauthIdentity, error) {
+	var user githubUser
+	if err := getJSON(ctx, client, "https://api.github.com/user", &user); err != nil {
+		return oauthIdentity{}, err
+	}
+	var emails []githubEmail
+	if err := getJSON(ctx, client, "https://api.github.com/user/emails", &emails); err != nil {
+		return oauthIdentity{}, err
+	}
+
+	identity := oauthIdentity{Subject: strconv.FormatInt(user.ID, 10), Picture: user.AvatarURL}
+	for _, e := range emails {
+		if e.Primary {
+			identity.Email = e.Email
+			identity.EmailVerified = e.Verified
+		}
+	}
+	identity.FirstName, identity.LastName = splitName(user.Name)
+	if identity.FirstName == "" {
+		identity.FirstName = user.Login
+	}
+	return identity, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// splitName splits a full name at its first space.
+func splitName(name string) (string, string) {
+	first, last, _ := strings.Cut(strings.TrimSpace(name), " ")
+	return first, strings.TrimSpace(last)
+}
+
+// findOrCreateOAuthUser returns the user linked to identity at provider,
+// linking or creating one by email the first time they sign in with it. Only
+// emails the provider has verified are trusted, so nobody can take over an
+// account by signing in with an address they don't own. Linking into an
+// account whose email was never verified claims it for the provider's user.
+func (h *Handler) findOrCreateOAuthUser(ctx context.Context, provider string, identity oauthIdentity) (database.User, error) {
+	link, err := h.cfg.DB.GetAuthProvider(ctx, database.GetAuthProviderParams{Provider: provider, ProviderUserID: identity.Subject})
+	if err == nil {
+		return h.cfg.DB.GetUserByID(ctx, link.UserID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return database.User{}, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return database.User{}, errOAuthEmailUnverified
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.User{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	user, err := qtx.GetUser(ctx, identity.Email)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		user, err = qtx.CreateOAuthUser(ctx, database.CreateOAuthUserParams{
+			FirstName:    identity.FirstName,
+			LastName:     identity.LastName,
+			Email:        identity.Email,
+			ProfileImage: sql.NullString{String: identity.Picture, Valid: identity.Picture != ""},
+		})
+		if err != nil {
+			return database.User{}, err
+		}
+	case err != nil:
+		return database.User{}, err
+	case !user.EmailVerifiedAt.Valid:
+		user, err = claimUnverifiedUser(ctx, qtx, user.ID)
+		if err != nil {
+			return database.User{}, err
+		}
+	}
+
+	_, err = qtx.CreateAuthProvider(ctx, database.CreateAuthProviderParams{
+		UserID:         user.ID,
+		Provider:       provider,
+		ProviderUserID: identity.Subject,
+	})
+	if err != nil {
+		return database.User{}, err
+	}
+	return user, tx.Commit()
+}
+
+// claimUnverifiedUser hands an account whose email was never verified to the
+// provider's user, who has proven they own it. Whoever registered the address
+// first may not be them, so their password, sessions, passkeys, second factor
+// and any pending email change go with it.
+func claimUnverifiedUser(ctx context.Context, qtx *database.Queries, userID uuid.UUID) (database.User, error) {
+	user, err := qtx.ClaimUnverifiedUser(ctx, userID)
+	if err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.DeleteUserPasskeys(ctx, userID); err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.DeleteTOTPFactor(ctx, userID); err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return database.User{}, err
+	}
+	if err := qtx.ExpireUserEmailVerificationTokens(ctx, userID); err != nil {
+		return database.User{}, err
+	}
+	return user, nil
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/overload"
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+// progressionSetting is the key of the progression policy in
+// users.preferences.
+const progressionSetting = "progression"
+
+// storedPolicy is a progression policy as saved in users.preferences. The
+// weight step is always in pounds.
+type storedPolicy struct {
+	Policy        string `json:"policy"`
+	WeightStepLbs int32  `json:"weight_step_lbs"`
+	RepRangeMin   int32  `json:"rep_range_min"`
+	RepRangeMax   int32  `json:"rep_range_max"`
+	HoldOnFailure bool   `json:"hold_on_failure"`
+}
+
+// progressionPolicy returns the user's saved progression policy. Users who
+// have never saved one, or whose saved policy is no longer valid, get the
+// default policy.
+func (h *Handler) progressionPolicy(ctx context.Context, userID uuid.UUID) (overload.Policy, error) {
+	settings, err := h.userSettings(ctx, userID)
+	if err != nil {
+		return overload.Policy{}, err
+	}
+	v, ok := settings[progressionSetting]
+	if !ok {
+		return overload.DefaultPolicy, nil
+	}
+
+	var stored storedPolicy
+	if err := json.Unmarshal(v, &stored); err != nil {
+		return overload.DefaultPolicy, nil
+	}
+	kind, err := overload.ParseKind(stored.Policy)
+	if err != nil {
+		return overload.DefaultPolicy, nil
+	}
+	policy := overload.Policy{
+		Kind:          kind,
+		WeightStep:    stored.WeightStepLbs,
+		RepMin:        stored.RepRangeMin,
+		RepMax:        stored.RepRangeMax,
+		HoldOnFailure: stored.HoldOnFailure,
+	}
+	if err := policy.Validate(units.Imperial); err != nil {
+		return overload.DefaultPolicy, nil
+	}
+	return policy, nil
+}
+
+// parsePolicy builds a progression policy from user input. Empty rep range
+// values keep the current range so a linear policy can omit them.
+func parsePolicy(current overload.Policy, system units.System, kind, weightStep, repMin, repMax string, holdOnFailure bool) (overload.Policy, error) {
+	k, err := overload.ParseKind(kind)
+	if err != nil {
+		return overload.Policy{}, err
+	}
+	policy := current
+	policy.Kind = k
+	policy.HoldOnFailure = holdOnFailure
+
+	for _, f := range []struct {
+		value string
+		dst   *int32
+		name  string
+	}{
+		{weightStep, &policy.WeightStep, "weight step"},
+		{repMin, &policy.RepMin, "rep range min"},
+		{repMax, &policy.RepMax, "rep range max"},
+	} {
+		if f.value == "" {
+			continue
+		}
+		n, err := strconv.ParseInt(f.value, 10, 32)
+		if err != nil {
+			return overload.Policy{}, fmt.Errorf("%s must be a number", f.name)
+		}
+		*f.dst = int32(n)
+	}
+
+	if err := policy.Validate(system); err != nil {
+		return overload.Policy{}, err
+	}
+	return policy, nil
+}
+
+// saveProgressionPolicy stores the user's progression policy.
+func (h *Handler) saveProgressionPolicy(ctx context.Context, userID uuid.UUID, policy overload.Policy) error {
+	return h.saveUserSetting(ctx, userID, progressionSetting, storedPolicy{
+		Policy:        string(policy.Kind),
+		WeightStepLbs: policy.WeightStep,
+		RepRangeMin:   policy.RepMin,
+		RepRangeMax:   policy.RepMax,
+		HoldOnFailure: policy.HoldOnFailure,
+	})
+}
+
+// suggestNextSession applies the user's progression policy to the last
+// session in which they completed sets of the exercise. The returned
+// suggestion is empty when there is no such session.
+func (h *Handler) suggestNextSession(ctx context.Context, userID, exerciseID uuid.UUID) (overload.Suggestion, error) {
+	last, err := h.cfg.DB.GetLastExerciseSession(ctx, database.GetLastExerciseSessionParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return overload.Suggestion{}, nil
+		}
+		return overload.Suggestion{}, err
+	}
+
+	policy, err := h.progressionPolicy(ctx, userID)
+	if err != nil {
+		return overload.Suggestion{}, err
+	}
+
+	suggestion := overload.Suggest(policy, overload.Session{
+		PlannedReps:      last.RepsPerSetPlanned,
+		PlannedWeights:   last.WeightsPlannedLbs,
+		CompletedReps:    last.RepsPerSetCompleted,
+		CompletedWeights: last.WeightsCompletedLbs,
+	})
+	if suggestion.Sets() > 0 {
+		suggestion.Basis.From = last.Title
+		suggestion.Basis.On = last.SessionDate
+	}
+	return suggestion, nil
+}
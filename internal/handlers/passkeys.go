@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/database"
+)
+
+const (
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+)
+
+var (
+	errInvalidCeremony = errors.New("this passkey request has expired, please try again")
+	errPasskeyRejected = errors.New("that passkey couldn't be verified")
+	errPasskeyNotFound = errors.New("passkey not found")
+)
+
+// passkeyUser is a user and their passkeys as the webauthn package sees them.
+// The user handle stored on each passkey is the user's ID.
+type passkeyUser struct {
+	user     database.User
+	passkeys []database.Passkey
+}
+
+func (u passkeyUser) WebAuthnID() []byte {
+	return u.user.ID[:]
+}
+
+func (u passkeyUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u passkeyUser) WebAuthnDisplayName() string {
+	return u.user.FirstName + " " + u.user.LastName
+}
+
+func (u passkeyUser) WebAuthnCredentials() []webauthn.Credential {
+	creds := make([]webauthn.Credential, 0, len(u.passkeys))
+	for _, p := range u.passkeys {
+		var cred webauthn.Credential
+		if err := json.Unmarshal(p.Credential, &cred); err != nil {
+			continue
+		}
+		creds = append(creds, cred)
+	}
+	return creds
+}
+
+func (h *Handler) loadPasskeyUser(ctx context.Context, user database.User) (passkeyUser, error) {
+	passkeys, err := h.cfg.DB.GetUserPasskeys(ctx, user.ID)
+	if err != nil {
+		return passkeyUser{}, err
+	}
+	return passkeyUser{user: user, passkeys: passkeys}, nil
+}
+
+// saveCeremony stores a ceremony's session data until it's finished, and
+// returns the token that finds it again. Ceremonies that were never finished
+// are cleared out as new ones start.
+func (h *Handler) saveCeremony(ctx context.Context, userID uuid.NullUUID, ceremony string, session *webauthn.SessionData) (string, error) {
+	if err := h.cfg.DB.DeleteExpiredWebAuthnSessions(ctx); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+	token, err := auth.MakeCeremonyToken()
+	if err != nil {
+		return "", err
+	}
+	err = h.cfg.DB.CreateWebAuthnSession(ctx, database.CreateWebAuthnSessionParams{
+		Token:     auth.HashCeremonyToken(token),
+		UserID:    userID,
+		Ceremony:  ceremony,
+		Data:      data,
+		ExpiresAt: time.Now().UTC().Add(auth.CeremonyExpiry),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// takeCeremony returns and uses up a ceremony's stored session data.
+func (h *Handler) takeCeremony(ctx context.Context, token, ceremony string) (uuid.NullUUID, webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	row, err := h.cfg.DB.UseWebAuthnSession(ctx, database.UseWebAuthnSessionParams{
+		Token:    auth.HashCeremonyToken(token),
+		Ceremony: ceremony,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.NullUUID{}, session, errInvalidCeremony
+	}
+	if err != nil {
+		return uuid.NullUUID{}, session, err
+	}
+	if err := json.Unmarshal(row.Data, &session); err != nil {
+		return uuid.NullUUID{}, session, err
+	}
+	return row.UserID, session, nil
+}
+
+// beginPasskeyRegistration returns the options for the browser to create a
+// passkey with, and the token finishPasskeyRegistration needs back. Passkeys
+// the user already has are excluded so one authenticator isn't added twice.
+func (h *Handler) beginPasskeyRegistration(ctx context.Context, user database.User) (*protocol.CredentialCreation, string, error) {
+	pu, err := h.loadPasskeyUser(ctx, user)
+	if err != nil {
+		return nil, "", err
+	}
+
+	creation, session, err := h.cfg.WebAuthn.BeginRegistration(pu,
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithExclusions(webauthn.Credentials(pu.WebAuthnCredentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := h.saveCeremony(ctx, uuid.NullUUID{UUID: user.ID, Valid: true}, ceremonyRegistration, session)
+	if err != nil {
+		return nil, "", err
+	}
+	return creation, token, nil
+}
+
+// finishPasskeyRegistration checks the browser's response to a registration
+// started for userID and saves the new passkey under name.
+func (h *Handler) finishPasskeyRegistration(ctx context.Context, userID uuid.UUID, token, name string, response []byte) (database.Passkey, error) {
+	owner, session, err := h.takeCeremony(ctx, token, ceremonyRegistration)
+	if err != nil {
+		return database.Passkey{}, err
+	}
+	if !owner.Valid || owner.UUID != userID {
+		return database.Passkey{}, errInvalidCeremony
+	}
+
+	user, err := h.cfg.DB.GetUserByID(ctx, userID)
+	if err != nil {
+		return database.Passkey{}, err
+	}
+	pu, err := h.loadPasskeyUser(ctx, user)
+	if err != nil {
+		return database.Passkey{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return database.Passkey{}, errPasskeyRejected
+	}
+	cred, err := h.cfg.WebAuthn.CreateCredential(pu, session, parsed)
+	if err != nil {
+		return database.Passkey{}, errPasskeyRejected
+	}
+
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return database.Passkey{}, err
+	}
+	return h.cfg.DB.CreatePasskey(ctx, database.CreatePasskeyParams{
+		UserID:       userID,
+		CredentialID: cred.ID,
+		Name:         name,
+		Credential:   data,
+	})
+}
+
+// beginPasskeyLogin returns the options for the browser to sign in with any
+// passkey it has for the site, and the token finishPasskeyLogin needs back.
+func (h *Handler) beginPasskeyLogin(ctx context.Context) (*protocol.CredentialAssertion, string, error) {
+	assertion, session, err := h.cfg.WebAuthn.BeginDiscoverableLogin(
+		webauthn.WithUserVerification(protocol.VerificationRequired),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := h.saveCeremony(ctx, uuid.NullUUID{}, ceremonyLogin, session)
+	if err != nil {
+		return nil, "", err
+	}
+	return assertion, token, nil
+}
+
+// finishPasskeyLogin checks the browser's response to a passkey login and
+// returns the user it belongs to. The passkey verified the user on their
+// device, so it stands in for both the password and any second factor.
+func (h *Handler) finishPasskeyLogin(ctx context.Context, token string, response []byte) (database.User, error) {
+	_, session, err := h.takeCeremony(ctx, token, ceremonyLogin)
+	if err != nil {
+		return database.User{}, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return database.User{}, errPasskeyRejected
+	}
+
+	var pu passkeyUser
+	findUser := func(_, userHandle []byte) (webauthn.User, error) {
+		userID, err := uuid.FromBytes(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		user, err := h.cfg.DB.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		pu, err = h.loadPasskeyUser(ctx, user)
+		return pu, err
+	}
+
+	_, cred, err := h.cfg.WebAuthn.ValidatePasskeyLogin(findUser, session, parsed)
+	if err != nil {
+		return database.User{}, errPasskeyRejected
+	}
+	// A counter that went backwards means the passkey may have been copied
+	if cred.Authenticator.CloneWarning {
+		return database.User{}, errPasskeyRejected
+	}
+
+	data, err := json.Marshal(cred)
+	if err != nil {
+		return database.User{}, err
+	}
+	err = h.cfg.DB.UsePasskey(ctx, database.UsePasskeyParams{CredentialID: cred.ID, Credential: data})
+	if err != nil {
+		return database.User{}, err
+	}
+	return pu.user, nil
+}
+
+// renamePasskey renames one of the user's passkeys.
+func (h *Handler) renamePasskey(ctx context.Context, userID, passkeyID uuid.UUID, name string) (database.Passkey, error) {
+	passkey, err := h.cfg.DB.RenamePasskey(ctx, database.RenamePasskeyParams{
+		ID:     passkeyID,
+		UserID: userID,
+		Name:   name,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.Passkey{}, errPasskeyNotFound
+	}
+	return passkey, err
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/mail"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var errInvalidResetToken = errors.New("this reset link is invalid or has expired")
+
+// validateNewPassword checks a new password the way registration does.
+func validateNewPassword(password, confirm string) []validate.FieldError {
+	if errs := validate.Fields(
+		validate.Required(password, "password"),
+		validate.MinLen(password, 10, "password"),
+	); errs != nil {
+		return errs
+	}
+	if password != confirm {
+		return []validate.FieldError{{Field: "confirm password", Message: "passwords do not match"}}
+	}
+	return nil
+}
+
+// requestPasswordReset mails a reset link to the account with email. Unknown
+// and disabled accounts are skipped without an error, so callers respond the
+// same whether or not the email has an account.
+func (h *Handler) requestPasswordReset(ctx context.Context, email string) error {
+	user, err := h.cfg.DB.GetUser(ctx, strings.TrimSpace(email))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.DisabledAt.Valid {
+		return nil
+	}
+
+	token, err := auth.MakeResetToken()
+	if err != nil {
+		return err
+	}
+	err = h.cfg.DB.CreatePasswordResetToken(ctx, database.CreatePasswordResetTokenParams{
+		Token:     auth.HashResetToken(token),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().UTC().Add(auth.PasswordResetExpiry),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/password/reset?token=%s", h.cfg.BaseURL, url.QueryEscape(token))
+	return h.cfg.Mailer.Send(ctx, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your FitHub password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password for your FitHub account. Use this link to choose a new one:\n\n"+
+			"%s\n\n"+
+			"The link works once and expires in %d minutes. If you didn't ask for this, you can ignore this email.\n",
+			user.FirstName, link, int(auth.PasswordResetExpiry.Minutes())),
+	})
+}
+
+// resetPassword sets a new password with a reset token, using up the token
+// and any others the user was sent, and signs the user out everywhere.
+func (h *Handler) resetPassword(ctx context.Context, token, password string) error {
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	userID, err := qtx.UsePasswordResetToken(ctx, auth.HashResetToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return errInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = qtx.UpdateUser(ctx, database.UpdateUserParams{
+		ID:             userID,
+		HashedPassword: sql.NullString{String: hashedPassword, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if err := qtx.ExpireUserPasswordResetTokens(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.RevokeUserRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
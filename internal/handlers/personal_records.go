@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/strength"
+)
+
+// recordPersonalRecords replaces the records earned by a workout exercise with
+// the ones its completed sets earn against every other logged session of the
+// same exercise, so re-saving or correcting a set never leaves stale records.
+func (h *Handler) recordPersonalRecords(ctx context.Context, userID uuid.UUID, we database.WorkoutsExercise) ([]database.PersonalRecord, error) {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	if err := qtx.DeleteWorkoutExerciseRecords(ctx, we.ID); err != nil {
+		return nil, err
+	}
+
+	records := []database.PersonalRecord{}
+	if we.SetsCompleted == 0 {
+		return records, tx.Commit()
+	}
+
+	workout, err := qtx.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{
+		ID:     we.WorkoutID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	achievedAt := time.Now().UTC()
+	if workout.DateCompleted.Valid {
+		achievedAt = workout.DateCompleted.Time
+	}
+
+	sessions, err := qtx.GetCompletedExerciseSets(ctx, database.GetCompletedExerciseSetsParams{
+		UserID:     userID,
+		ExerciseID: we.ExerciseID,
+		ID:         we.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var prev strength.Bests
+	for _, s := range sessions {
+		prev.Add(strength.Sets(s.RepsPerSetCompleted, s.WeightsCompletedLbs))
+	}
+
+	for _, rec := range strength.Detect(strength.Sets(we.RepsPerSetCompleted, we.WeightsCompletedLbs), prev) {
+		pr, err := qtx.CreatePersonalRecord(ctx, database.CreatePersonalRecordParams{
+			UserID:            userID,
+			ExerciseID:        we.ExerciseID,
+			WorkoutExerciseID: we.ID,
+			RecordType:        string(rec.Kind),
+			Value:             rec.Value,
+			Reps:              rec.Reps,
+			WeightLbs:         rec.Weight,
+			AchievedAt:        achievedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, pr)
+	}
+
+	return records, tx.Commit()
+}
+
+// recordsByWorkoutExercise groups a workout's records for the exercise cards.
+func recordsByWorkoutExercise(records []database.PersonalRecord) map[uuid.UUID][]database.PersonalRecord {
+	grouped := make(map[uuid.UUID][]database.PersonalRecord)
+	for _, pr := range records {
+		grouped[pr.WorkoutExerciseID] = append(grouped[pr.WorkoutExerciseID], pr)
+	}
+	return grouped
+}
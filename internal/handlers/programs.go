@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/progression"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/kairos4213/fithub/internal/validate"
+)
+
+var (
+	errProgramEmpty     = errors.New("add at least one week and one day before enrolling")
+	errProgramLastWeek  = errors.New("a program needs at least one week")
+	errProgramMaxWeeks  = fmt.Errorf("a program can have at most %d weeks", maxProgramWeeks)
+	errUnknownExercise  = errors.New("exercise not found")
+	errUnknownTemplate  = errors.New("workout template not found")
+	errInvalidDayNumber = errors.New("day must be between 1 and 7")
+)
+
+const maxProgramWeeks = 52
+
+// programInput is the editable part of a program as submitted by either the
+// HTML forms or the JSON API.
+type programInput struct {
+	Name        string
+	Description string
+	Weeks       string
+}
+
+func (in programInput) validate() []validate.FieldError {
+	return validate.Fields(
+		validate.Required(in.Name, "name"),
+		validate.Required(in.Weeks, "weeks"),
+		validate.MaxLen(in.Name, 100, "name"),
+		validate.MaxLen(in.Description, 500, "description"),
+		validate.Numeric(in.Weeks, "weeks"),
+	)
+}
+
+// programDayInput describes one training day of the program week.
+type programDayInput struct {
+	Title      string
+	DayNumber  string
+	TemplateID string
+	Duration   string
+}
+
+func (in programDayInput) validate() []validate.FieldError {
+	return validate.Fields(
+		validate.Required(in.Title, "title"),
+		validate.Required(in.DayNumber, "day"),
+		validate.Required(in.Duration, "duration"),
+		validate.MaxLen(in.Title, 80, "title"),
+		validate.Numeric(in.DayNumber, "day"),
+		validate.Numeric(in.Duration, "duration"),
+	)
+}
+
+// createProgram creates a program with the given number of weeks, all without
+// progression until the user sets a rule for them.
+func (h *Handler) createProgram(ctx context.Context, userID uuid.UUID, in programInput) (database.Program, error) {
+	weeks, err := strconv.Atoi(in.Weeks)
+	if err != nil || weeks < 1 || weeks > maxProgramWeeks {
+		return database.Program{}, errProgramMaxWeeks
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Program{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	program, err := qtx.CreateProgram(ctx, database.CreateProgramParams{
+		UserID:      userID,
+		Name:        in.Name,
+		Description: optionalString(in.Description),
+	})
+	if err != nil {
+		return database.Program{}, err
+	}
+
+	for i := 1; i <= weeks; i++ {
+		_, err := qtx.CreateProgramWeek(ctx, database.CreateProgramWeekParams{
+			ProgramID:   program.ID,
+			WeekNumber:  int32(i),
+			Progression: string(progression.None),
+		})
+		if err != nil {
+			return database.Program{}, err
+		}
+	}
+	return program, tx.Commit()
+}
+
+// createProgramDay adds a training day. TemplateID may be empty for a day
+// made up only of fixed exercises.
+func (h *Handler) createProgramDay(ctx context.Context, programID uuid.UUID, in programDayInput) (database.ProgramDay, error) {
+	dayNumber, err := strconv.Atoi(in.DayNumber)
+	if err != nil || dayNumber < 1 || dayNumber > 7 {
+		return database.ProgramDay{}, errInvalidDayNumber
+	}
+	duration, err := strconv.ParseInt(in.Duration, 10, 32)
+	if err != nil {
+		return database.ProgramDay{}, errors.New("duration must be a number")
+	}
+
+	templateID := uuid.NullUUID{}
+	if in.TemplateID != "" {
+		id, err := uuid.Parse(in.TemplateID)
+		if err != nil {
+			return database.ProgramDay{}, errUnknownTemplate
+		}
+		if _, err := h.cfg.DB.GetWorkoutTemplateByID(ctx, id); err != nil {
+			return database.ProgramDay{}, errUnknownTemplate
+		}
+		templateID = uuid.NullUUID{UUID: id, Valid: true}
+	}
+
+	return h.cfg.DB.CreateProgramDay(ctx, database.CreateProgramDayParams{
+		ProgramID:       programID,
+		DayNumber:       int32(dayNumber),
+		Title:           in.Title,
+		TemplateID:      templateID,
+		DurationMinutes: int32(duration),
+	})
+}
+
+// addProgramDayExercise adds a fixed exercise to a program day, planned as
+// sets of the same reps and weight.
+func (h *Handler) addProgramDayExercise(ctx context.Context, userID, dayID uuid.UUID, name, sets, reps, weight string, system units.System) error {
+	exercise, err := h.cfg.DB.GetExerciseByName(ctx, database.GetExerciseByNameParams{
+		Name:   strings.ToLower(strings.TrimSpace(name)),
+		UserID: userID,
+	})
+	if err != nil {
+		return errUnknownExercise
+	}
+
+	n, err := strconv.Atoi(sets)
+	if err != nil || n < 1 || n > 20 {
+		return errors.New("sets must be between 1 and 20")
+	}
+	r, err := strconv.ParseInt(reps, 10, 32)
+	if err != nil || r < 1 {
+		return errors.New("reps must be a positive number")
+	}
+	wt, err := system.ParseWeight(weight)
+	if err != nil {
+		return errors.New("weight must be a number")
+	}
+
+	repsPerSet := make([]int32, n)
+	weights := make([]int32, n)
+	for i := range n {
+		repsPerSet[i] = int32(r)
+		weights[i] = wt
+	}
+
+	_, err = h.cfg.DB.AddProgramDayExercise(ctx, database.AddProgramDayExerciseParams{
+		ProgramDayID: dayID,
+		ExerciseID:   exercise.ID,
+		RepsPerSet:   repsPerSet,
+		WeightsLbs:   weights,
+	})
+	return err
+}
+
+// addProgramWeek appends a week without progression to the program.
+func (h *Handler) addProgramWeek(ctx context.Context, programID uuid.UUID) error {
+	weeks, err := h.cfg.DB.GetProgramWeeks(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if len(weeks) >= maxProgramWeeks {
+		return errProgramMaxWeeks
+	}
+	_, err = h.cfg.DB.CreateProgramWeek(ctx, database.CreateProgramWeekParams{
+		ProgramID:   programID,
+		WeekNumber:  int32(len(weeks) + 1),
+		Progression: string(progression.None),
+	})
+	return err
+}
+
+// removeProgramWeek drops the program's final week.
+func (h *Handler) removeProgramWeek(ctx context.Context, programID uuid.UUID) error {
+	weeks, err := h.cfg.DB.GetProgramWeeks(ctx, programID)
+	if err != nil {
+		return err
+	}
+	if len(weeks) <= 1 {
+		return errProgramLastWeek
+	}
+	return h.cfg.DB.DeleteProgramWeek(ctx, database.DeleteProgramWeekParams{
+		ProgramID:  programID,
+		WeekNumber: weeks[len(weeks)-1].WeekNumber,
+	})
+}
+
+// programRule parses a week's progression from user input. Added weight is
+// entered in the user's unit.
+func programRule(kind, amount string, system units.System) (progression.Rule, error) {
+	k, err := progression.ParseKind(kind)
+	if err != nil {
+		return progression.Rule{}, err
+	}
+	rule := progression.Rule{Kind: k}
+	if k == progression.AddWeight {
+		n, err := system.ParseWeight(amount)
+		if err != nil {
+			return progression.Rule{}, errors.New("amount must be a number")
+		}
+		rule.Amount = n
+	} else if k != progression.None {
+		n, err := strconv.ParseInt(strings.TrimSpace(amount), 10, 32)
+		if err != nil {
+			return progression.Rule{}, errors.New("amount must be a number")
+		}
+		rule.Amount = int32(n)
+	}
+	return rule, rule.Validate()
+}
+
+// programRules converts stored weeks into progression rules, one per week in
+// program order.
+func programRules(weeks []database.ProgramWeek) []progression.Rule {
+	rules := make([]progression.Rule, len(weeks))
+	for i, w := range weeks {
+		rules[i] = progression.Rule{Kind: progression.Kind(w.Progression), Amount: w.Amount}
+	}
+	return rules
+}
+
+// plannedExercise is an exercise of a program day before weekly progression.
+type plannedExercise struct {
+	exerciseID uuid.UUID
+	base       progression.Prescription
+}
+
+// programDayExercises resolves what a day trains: exercises picked from its
+// template, if any, followed by its fixed exercises. Template picks are made
+// once per enrollment so every week of the program repeats the same lifts.
+func (h *Handler) programDayExercises(ctx context.Context, day database.ProgramDay, fixed []database.GetProgramDayExercisesRow) ([]plannedExercise, error) {
+	planned := []plannedExercise{}
+	if day.TemplateID.Valid {
+		tmpl, err := h.cfg.DB.GetWorkoutTemplateByID(ctx, day.TemplateID.UUID)
+		if err != nil {
+			return nil, err
+		}
+		var exerciseData map[string][]exerciseSlot
+		if err := json.Unmarshal(tmpl.ExerciseSetReps, &exerciseData); err != nil {
+			return nil, err
+		}
+		picks, err := h.resolveTemplateExercises(ctx, exerciseData)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range picks {
+			reps := make([]int32, p.Sets)
+			for i := range reps {
+				reps[i] = p.RepsPerSet
+			}
+			planned = append(planned, plannedExercise{
+				exerciseID: p.ExerciseID,
+				base:       progression.Prescription{Reps: reps, Weights: make([]int32, p.Sets)},
+			})
+		}
+	}
+	for _, ex := range fixed {
+		if ex.ProgramDayID != day.ID {
+			continue
+		}
+		planned = append(planned, plannedExercise{
+			exerciseID: ex.ExerciseID,
+			base:       progression.Prescription{Reps: ex.RepsPerSet, Weights: ex.WeightsLbs},
+		})
+	}
+	return planned, nil
+}
+
+// enrollInProgram schedules every workout of the program for the user, with
+// week one starting on start. Day N of week W falls N-1 days after the start
+// of that week, and each exercise is planned with the progression accumulated
+// up to its week. It returns the number of workouts created.
+func (h *Handler) enrollInProgram(ctx context.Context, userID, programID uuid.UUID, start time.Time) (int, error) {
+	program, err := h.cfg.DB.GetProgram(ctx, database.GetProgramParams{ID: programID, UserID: userID})
+	if err != nil {
+		return 0, err
+	}
+	weeks, err := h.cfg.DB.GetProgramWeeks(ctx, programID)
+	if err != nil {
+		return 0, err
+	}
+	days, err := h.cfg.DB.GetProgramDays(ctx, programID)
+	if err != nil {
+		return 0, err
+	}
+	if len(weeks) == 0 || len(days) == 0 {
+		return 0, errProgramEmpty
+	}
+	fixed, err := h.cfg.DB.GetProgramDayExercises(ctx, programID)
+	if err != nil {
+		return 0, err
+	}
+
+	planned := make(map[uuid.UUID][]plannedExercise, len(days))
+	for _, day := range days {
+		exercises, err := h.programDayExercises(ctx, day, fixed)
+		if err != nil {
+			return 0, err
+		}
+		planned[day.ID] = exercises
+	}
+	rules := programRules(weeks)
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	created := 0
+	for w := range weeks {
+		for _, day := range days {
+			workout, err := qtx.CreateWorkout(ctx, database.CreateWorkoutParams{
+				UserID:          userID,
+				Title:           fmt.Sprintf("%s (Week %d)", day.Title, w+1),
+				Description:     optionalString(program.Name),
+				DurationMinutes: day.DurationMinutes,
+				PlannedDate:     start.AddDate(0, 0, 7*w+int(day.DayNumber)-1),
+			})
+			if err != nil {
+				return 0, err
+			}
+			for _, ex := range planned[day.ID] {
+				p := progression.Week(ex.base, rules, w)
+				_, err := qtx.AddExerciseToWorkout(ctx, database.AddExerciseToWorkoutParams{
+					WorkoutID:                 workout.ID,
+					ExerciseID:                ex.exerciseID,
+					SetsPlanned:               int32(len(p.Reps)),
+					RepsPerSetPlanned:         p.Reps,
+					SetsCompleted:             0,
+					RepsPerSetCompleted:       []int32{},
+					WeightsPlannedLbs:         p.Weights,
+					WeightsCompletedLbs:       []int32{},
+					DurationsPlannedSeconds:   []int32{},
+					DurationsCompletedSeconds: []int32{},
+					DistancesPlannedMeters:    []int32{},
+					DistancesCompletedMeters:  []int32{},
+					HeartRatesCompleted:       []int32{},
+				})
+				if err != nil {
+					return 0, err
+				}
+			}
+			created++
+		}
+	}
+
+	_, err = qtx.CreateProgramEnrollment(ctx, database.CreateProgramEnrollmentParams{
+		ProgramID: programID,
+		UserID:    userID,
+		StartDate: start,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return created, tx.Commit()
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/effort"
+)
+
+var errSetNotCompleted = errors.New("details can only be logged for completed sets")
+
+// setEffort converts a stored set detail into its effort ratings.
+func setEffort(d database.WorkoutSetDetail) effort.Set {
+	set := effort.Set{RPE: d.Rpe.Float64, RIR: effort.NoRIR, Tempo: d.Tempo.String, Notes: d.Notes.String}
+	if d.Rir.Valid {
+		set.RIR = d.Rir.Int32
+	}
+	return set
+}
+
+// effortBySet spreads stored details over their set numbers. Sets without a
+// detail are left empty.
+func effortBySet(details []database.WorkoutSetDetail) []effort.Set {
+	var sets []effort.Set
+	for _, d := range details {
+		for len(sets) < int(d.SetNumber) {
+			sets = append(sets, effort.Set{RIR: effort.NoRIR})
+		}
+		sets[d.SetNumber-1] = setEffort(d)
+	}
+	return sets
+}
+
+// effortByWorkoutExercise groups a workout's set details by exercise.
+func effortByWorkoutExercise(details []database.WorkoutSetDetail) map[uuid.UUID][]effort.Set {
+	grouped := make(map[uuid.UUID][]database.WorkoutSetDetail)
+	for _, d := range details {
+		grouped[d.WorkoutExerciseID] = append(grouped[d.WorkoutExerciseID], d)
+	}
+	byID := make(map[uuid.UUID][]effort.Set, len(grouped))
+	for id, ds := range grouped {
+		byID[id] = effortBySet(ds)
+	}
+	return byID
+}
+
+// effortFromForm reads the per-set effort inputs of the workout exercise
+// form, which run parallel to its completed reps.
+func effortFromForm(r *http.Request, completed int) ([]effort.Set, error) {
+	rpe := r.PostForm["completed-rpe[]"]
+	rir := r.PostForm["completed-rir[]"]
+	tempo := r.PostForm["completed-tempo[]"]
+	notes := r.PostForm["completed-notes[]"]
+
+	at := func(values []string, i int) string {
+		if i < len(values) {
+			return values[i]
+		}
+		return ""
+	}
+
+	sets := make([]effort.Set, completed)
+	for i := range sets {
+		set, err := effort.ParseSet(at(rpe, i), at(rir, i), at(tempo, i), at(notes, i))
+		if err != nil {
+			return nil, fmt.Errorf("set %d: %w", i+1, err)
+		}
+		sets[i] = set
+	}
+	return sets, nil
+}
+
+// saveSetDetails replaces the effort logged for a workout exercise's
+// completed sets. Empty sets have their detail removed.
+func (h *Handler) saveSetDetails(ctx context.Context, workoutExerciseID uuid.UUID, sets []effort.Set) error {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	for i, set := range sets {
+		setNumber := int32(i + 1)
+		if set.Empty() {
+			err = qtx.DeleteWorkoutSetDetail(ctx, database.DeleteWorkoutSetDetailParams{
+				WorkoutExerciseID: workoutExerciseID,
+				SetNumber:         setNumber,
+			})
+		} else {
+			err = qtx.UpsertWorkoutSetDetail(ctx, database.UpsertWorkoutSetDetailParams{
+				WorkoutExerciseID: workoutExerciseID,
+				SetNumber:         setNumber,
+				Rpe:               sql.NullFloat64{Float64: set.RPE, Valid: set.RPE > 0},
+				Rir:               sql.NullInt32{Int32: set.RIR, Valid: set.RIR != effort.NoRIR},
+				Tempo:             sql.NullString{String: set.Tempo, Valid: set.Tempo != ""},
+				Notes:             sql.NullString{String: set.Notes, Valid: set.Notes != ""},
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	err = qtx.TrimWorkoutSetDetails(ctx, database.TrimWorkoutSetDetailsParams{
+		WorkoutExerciseID: workoutExerciseID,
+		SetNumber:         int32(len(sets)),
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
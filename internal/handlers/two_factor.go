@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/auth"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/totp"
+)
+
+const (
+	// totpIssuer names the account in authenticator apps
+	totpIssuer = "FitHub"
+	// maxChallengeAttempts is how many wrong codes a login challenge takes
+	// before the password has to be entered again
+	maxChallengeAttempts = 5
+)
+
+var (
+	errInvalidChallenge    = errors.New("this login has expired, please log in again")
+	errInvalidCode         = errors.New("that code is incorrect")
+	errTwoFactorEnabled    = errors.New("two-factor authentication is already on")
+	errTwoFactorNotPending = errors.New("two-factor authentication hasn't been set up")
+)
+
+// twoFactorEnabled reports whether the user has confirmed a second factor.
+func (h *Handler) twoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	factor, err := h.cfg.DB.GetTOTPFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return factor.ConfirmedAt.Valid, nil
+}
+
+// twoFactorStatus reports whether the user has a second factor and how many
+// unused recovery codes they have left.
+func (h *Handler) twoFactorStatus(ctx context.Context, userID uuid.UUID) (bool, int64, error) {
+	enabled, err := h.twoFactorEnabled(ctx, userID)
+	if err != nil || !enabled {
+		return false, 0, err
+	}
+	remaining, err := h.cfg.DB.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return false, 0, err
+	}
+	return true, remaining, nil
+}
+
+// startLoginChallenge records that userID's password checked out and returns
+// the token that completeLoginChallenge trades, with a code, for the login.
+func (h *Handler) startLoginChallenge(ctx context.Context, userID uuid.UUID) (string, error) {
+	token, err := auth.MakeLoginChallenge()
+	if err != nil {
+		return "", err
+	}
+	err = h.cfg.DB.CreateLoginChallenge(ctx, database.CreateLoginChallengeParams{
+		Token:     auth.HashLoginChallenge(token),
+		UserID:    userID,
+		ExpiresAt: time.Now().UTC().Add(auth.LoginChallengeExpiry),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// completeLoginChallenge checks code against the user a login challenge was
+// started for and returns them. Each code claims one of the challenge's
+// maxChallengeAttempts before it's checked, so concurrent guesses can't get
+// past the limit.
+func (h *Handler) completeLoginChallenge(ctx context.Context, token, code string) (database.User, error) {
+	hashed := auth.HashLoginChallenge(token)
+	userID, err := h.cfg.DB.ClaimLoginChallengeAttempt(ctx, database.ClaimLoginChallengeAttemptParams{
+		Token:    hashed,
+		Attempts: maxChallengeAttempts,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.User{}, errInvalidChallenge
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+
+	ok, err := h.checkSecondFactor(ctx, userID, code)
+	if err != nil {
+		return database.User{}, err
+	}
+	if !ok {
+		return database.User{}, errInvalidCode
+	}
+
+	// Used up here so a challenge can't be completed twice
+	_, err = h.cfg.DB.UseLoginChallenge(ctx, hashed)
+	if errors.Is(err, sql.ErrNoRows) {
+		return database.User{}, errInvalidChallenge
+	}
+	if err != nil {
+		return database.User{}, err
+	}
+	return h.cfg.DB.GetUserByID(ctx, userID)
+}
+
+// checkSecondFactor reports whether code is a current authenticator code or
+// an unused recovery code for the user, using it up if so.
+func (h *Handler) checkSecondFactor(ctx context.Context, userID uuid.UUID, code string) (bool, error) {
+	factor, err := h.cfg.DB.GetTOTPFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !factor.ConfirmedAt.Valid {
+		return false, nil
+	}
+
+	if step, ok := totp.Validate(factor.Secret, code, time.Now(), factor.LastUsedStep); ok {
+		_, err := h.cfg.DB.UseTOTPStep(ctx, database.UseTOTPStepParams{UserID: userID, LastUsedStep: step})
+		if errors.Is(err, sql.ErrNoRows) {
+			// Another request used this code first
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	_, err = h.cfg.DB.UseRecoveryCode(ctx, database.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: totp.HashRecoveryCode(code),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// beginTOTPEnrollment makes a new secret for the user to add to their
+// authenticator app, replacing any they didn't confirm, and returns it with
+// its provisioning URI.
+func (h *Handler) beginTOTPEnrollment(ctx context.Context, user database.User) (string, string, error) {
+	enabled, err := h.twoFactorEnabled(ctx, user.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if enabled {
+		return "", "", errTwoFactorEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	err = h.cfg.DB.UpsertTOTPFactor(ctx, database.UpsertTOTPFactorParams{UserID: user.ID, Secret: secret})
+	if err != nil {
+		return "", "", err
+	}
+	return secret, totp.URI(totpIssuer, user.Email, secret), nil
+}
+
+// pendingTOTPEnrollment returns the secret and provisioning URI of an
+// enrollment the user hasn't confirmed yet.
+func (h *Handler) pendingTOTPEnrollment(ctx context.Context, user database.User) (string, string, error) {
+	factor, err := h.cfg.DB.GetTOTPFactor(ctx, user.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", "", errTwoFactorNotPending
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if factor.ConfirmedAt.Valid {
+		return "", "", errTwoFactorEnabled
+	}
+	return factor.Secret, totp.URI(totpIssuer, user.Email, factor.Secret), nil
+}
+
+// confirmTOTPEnrollment turns on the user's pending second factor once they
+// enter a code from it, and returns their recovery codes.
+func (h *Handler) confirmTOTPEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	factor, err := h.cfg.DB.GetTOTPFactor(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errTwoFactorNotPending
+	}
+	if err != nil {
+		return nil, err
+	}
+	if factor.ConfirmedAt.Valid {
+		return nil, errTwoFactorEnabled
+	}
+
+	step, ok := totp.Validate(factor.Secret, code, time.Now(), factor.LastUsedStep)
+	if !ok {
+		return nil, errInvalidCode
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	err = qtx.ConfirmTOTPFactor(ctx, database.ConfirmTOTPFactorParams{UserID: userID, LastUsedStep: step})
+	if err != nil {
+		return nil, err
+	}
+	codes, err := replaceRecoveryCodes(ctx, qtx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, tx.Commit()
+}
+
+// regenerateRecoveryCodes replaces the user's recovery codes once they enter
+// a code, so a stolen session alone can't read out new ones.
+func (h *Handler) regenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	ok, err := h.checkSecondFactor(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errInvalidCode
+	}
+
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	codes, err := replaceRecoveryCodes(ctx, h.cfg.DB.WithTx(tx), userID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, tx.Commit()
+}
+
+func replaceRecoveryCodes(ctx context.Context, qtx *database.Queries, userID uuid.UUID) ([]string, error) {
+	codes, err := totp.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := qtx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	for _, c := range codes {
+		err := qtx.CreateRecoveryCode(ctx, database.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: totp.HashRecoveryCode(c),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// disableTwoFactor turns off the user's second factor once they enter a code.
+func (h *Handler) disableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	ok, err := h.checkSecondFactor(ctx, userID, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errInvalidCode
+	}
+	return h.removeTwoFactor(ctx, userID)
+}
+
+// removeTwoFactor deletes the user's second factor and recovery codes, and
+// ends any logins waiting on them.
+func (h *Handler) removeTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	if err := qtx.DeleteTOTPFactor(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteUserRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.ExpireUserLoginChallenges(ctx, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/units"
+	"github.com/sqlc-dev/pqtype"
+)
+
+// unitsSetting is the key of the unit system in users.preferences.
+const unitsSetting = "units"
+
+// userSettings reads the users.preferences document. Users who have never
+// saved a setting have an empty one.
+func (h *Handler) userSettings(ctx context.Context, userID uuid.UUID) (map[string]json.RawMessage, error) {
+	raw, err := h.cfg.DB.GetUserSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	settings := map[string]json.RawMessage{}
+	if raw.Valid && len(raw.RawMessage) > 0 {
+		if err := json.Unmarshal(raw.RawMessage, &settings); err != nil {
+			return nil, err
+		}
+	}
+	return settings, nil
+}
+
+// unitSystem returns the unit system the user sees weights in. Users who
+// haven't chosen one, or whose saved value is no longer valid, get imperial.
+func (h *Handler) unitSystem(ctx context.Context, userID uuid.UUID) (units.System, error) {
+	settings, err := h.userSettings(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	var s string
+	if v, ok := settings[unitsSetting]; ok {
+		if err := json.Unmarshal(v, &s); err != nil {
+			return units.Imperial, nil
+		}
+	}
+	system, err := units.ParseSystem(s)
+	if err != nil {
+		return units.Imperial, nil
+	}
+	return system, nil
+}
+
+// saveUnitSystem stores the user's unit system.
+func (h *Handler) saveUnitSystem(ctx context.Context, userID uuid.UUID, system units.System) error {
+	return h.saveUserSetting(ctx, userID, unitsSetting, system)
+}
+
+// saveUserSetting stores value under key in users.preferences, keeping any
+// other settings in the document.
+func (h *Handler) saveUserSetting(ctx context.Context, userID uuid.UUID, key string, value any) error {
+	settings, err := h.userSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	settings[key], err = json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	return h.cfg.DB.UpdateUserSettings(ctx, database.UpdateUserSettingsParams{
+		Preferences: pqtype.NullRawMessage{RawMessage: raw, Valid: true},
+		ID:          userID,
+	})
+}
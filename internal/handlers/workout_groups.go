@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/grouping"
+)
+
+var (
+	errUnknownWorkoutExercise = errors.New("exercises must belong to this workout")
+	errDuplicateGroupLabel    = errors.New("another group in this workout already uses that label")
+	errExerciseInOtherGroup   = errors.New("an exercise can only belong to one group")
+)
+
+// groupInput is a superset or circuit as submitted by a form or JSON body.
+type groupInput struct {
+	Kind        string
+	Label       string
+	Rounds      string
+	RestSeconds string
+	Members     []string
+}
+
+// parse validates the input. An empty label is replaced with the next free
+// letter among the workout's other groups.
+func (in groupInput) parse(others []database.WorkoutExerciseGroup) (grouping.Group, []uuid.UUID, error) {
+	kind, err := grouping.ParseKind(in.Kind)
+	if err != nil {
+		return grouping.Group{}, nil, err
+	}
+
+	used := make([]string, len(others))
+	for i, g := range others {
+		used[i] = g.Label
+	}
+	group := grouping.Group{Kind: kind, Label: strings.ToUpper(strings.TrimSpace(in.Label)), Rounds: 1}
+	if group.Label == "" {
+		group.Label = grouping.NextLabel(used)
+	} else if slices.Contains(used, group.Label) {
+		return grouping.Group{}, nil, errDuplicateGroupLabel
+	}
+
+	if in.Rounds != "" {
+		rounds, err := strconv.ParseInt(in.Rounds, 10, 32)
+		if err != nil {
+			return grouping.Group{}, nil, errors.New("rounds must be a number")
+		}
+		group.Rounds = int32(rounds)
+	}
+	if in.RestSeconds != "" {
+		rest, err := strconv.ParseInt(in.RestSeconds, 10, 32)
+		if err != nil {
+			return grouping.Group{}, nil, errors.New("rest must be a number of seconds")
+		}
+		group.RestSeconds = int32(rest)
+	}
+
+	var members []uuid.UUID
+	for _, m := range in.Members {
+		id, err := uuid.Parse(m)
+		if err != nil {
+			return grouping.Group{}, nil, errUnknownWorkoutExercise
+		}
+		if !slices.Contains(members, id) {
+			members = append(members, id)
+		}
+	}
+
+	if err := group.Validate(len(members)); err != nil {
+		return grouping.Group{}, nil, err
+	}
+	return group, members, nil
+}
+
+// otherGroups returns the workout's groups other than the one being edited.
+func (h *Handler) otherGroups(ctx context.Context, workoutID uuid.UUID, except uuid.NullUUID) ([]database.WorkoutExerciseGroup, error) {
+	groups, err := h.cfg.DB.GetWorkoutExerciseGroups(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(groups, func(g database.WorkoutExerciseGroup) bool {
+		return except.Valid && g.ID == except.UUID
+	}), nil
+}
+
+// saveWorkoutGroup creates a group, or updates the one given, and makes
+// members its exact set of exercises in one transaction.
+func (h *Handler) saveWorkoutGroup(ctx context.Context, userID, workoutID uuid.UUID, groupID uuid.NullUUID, group grouping.Group, members []uuid.UUID) (database.WorkoutExerciseGroup, error) {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.WorkoutExerciseGroup{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	exercises, err := qtx.WorkoutAndExercises(ctx, database.WorkoutAndExercisesParams{
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return database.WorkoutExerciseGroup{}, err
+	}
+	for _, id := range members {
+		i := slices.IndexFunc(exercises, func(e database.WorkoutAndExercisesRow) bool {
+			return e.WorkoutsExercise.ID == id
+		})
+		if i < 0 {
+			return database.WorkoutExerciseGroup{}, errUnknownWorkoutExercise
+		}
+		current := exercises[i].WorkoutsExercise.GroupID
+		if current.Valid && current != groupID {
+			return database.WorkoutExerciseGroup{}, errExerciseInOtherGroup
+		}
+	}
+
+	var saved database.WorkoutExerciseGroup
+	if groupID.Valid {
+		saved, err = qtx.UpdateWorkoutExerciseGroup(ctx, database.UpdateWorkoutExerciseGroupParams{
+			Kind:        string(group.Kind),
+			Label:       group.Label,
+			Rounds:      group.Rounds,
+			RestSeconds: group.RestSeconds,
+			ID:          groupID.UUID,
+			WorkoutID:   workoutID,
+		})
+		if err != nil {
+			return database.WorkoutExerciseGroup{}, err
+		}
+		err = qtx.ClearWorkoutExerciseGroup(ctx, database.ClearWorkoutExerciseGroupParams{
+			GroupID:   groupID,
+			WorkoutID: workoutID,
+		})
+	} else {
+		saved, err = qtx.CreateWorkoutExerciseGroup(ctx, database.CreateWorkoutExerciseGroupParams{
+			WorkoutID:   workoutID,
+			Kind:        string(group.Kind),
+			Label:       group.Label,
+			Rounds:      group.Rounds,
+			RestSeconds: group.RestSeconds,
+		})
+	}
+	if err != nil {
+		return database.WorkoutExerciseGroup{}, err
+	}
+
+	err = qtx.SetWorkoutExercisesGroup(ctx, database.SetWorkoutExercisesGroupParams{
+		GroupID:   saved.ID,
+		WorkoutID: workoutID,
+		Ids:       members,
+	})
+	if err != nil {
+		return database.WorkoutExerciseGroup{}, err
+	}
+	return saved, tx.Commit()
+}
+
+// deleteWorkoutGroup ungroups a group's exercises and removes the group.
+// Exercises drop out of the group through its ON DELETE SET NULL key.
+func (h *Handler) deleteWorkoutGroup(ctx context.Context, userID, workoutID, groupID uuid.UUID) error {
+	_, err := h.cfg.DB.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{
+		ID:     workoutID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+	return h.cfg.DB.DeleteWorkoutExerciseGroup(ctx, database.DeleteWorkoutExerciseGroupParams{
+		ID:        groupID,
+		WorkoutID: workoutID,
+	})
+}
+
+// groupMember places a workout exercise within its group.
+type groupMember struct {
+	Group grouping.Group
+	Index int
+	Count int
+}
+
+// Position labels the exercise within its group, e.g. "A2".
+func (m groupMember) Position() string {
+	return grouping.Position(m.Group.Label, m.Index)
+}
+
+// findGroupMember looks up the group of a workout exercise. ok is false when
+// the exercise isn't grouped.
+func (h *Handler) findGroupMember(ctx context.Context, userID uuid.UUID, we database.WorkoutsExercise) (member groupMember, ok bool, err error) {
+	if !we.GroupID.Valid {
+		return groupMember{}, false, nil
+	}
+
+	groups, err := h.cfg.DB.GetWorkoutExerciseGroups(ctx, we.WorkoutID)
+	if err != nil {
+		return groupMember{}, false, err
+	}
+	group, ok := workoutGroupsByID(groups)[we.GroupID.UUID]
+	if !ok {
+		return groupMember{}, false, nil
+	}
+
+	exercises, err := h.cfg.DB.WorkoutAndExercises(ctx, database.WorkoutAndExercisesParams{
+		WorkoutID: we.WorkoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return groupMember{}, false, err
+	}
+	var members []uuid.UUID
+	for _, e := range exercises {
+		if e.WorkoutsExercise.GroupID == we.GroupID {
+			members = append(members, e.WorkoutsExercise.ID)
+		}
+	}
+	i := slices.Index(members, we.ID)
+	if i < 0 {
+		return groupMember{}, false, fmt.Errorf("workout exercise %v missing from its group", we.ID)
+	}
+	return groupMember{Group: groupSettings(group), Index: i, Count: len(members)}, true, nil
+}
+
+// workoutGroupsByID indexes a workout's groups by id.
+func workoutGroupsByID(groups []database.WorkoutExerciseGroup) map[uuid.UUID]database.WorkoutExerciseGroup {
+	byID := make(map[uuid.UUID]database.WorkoutExerciseGroup, len(groups))
+	for _, g := range groups {
+		byID[g.ID] = g
+	}
+	return byID
+}
+
+// sessionRest returns the rest to start after logging a set: the server's
+// rest timer, or for a grouped exercise nothing until the last exercise of
+// the round and then the group's rest.
+func (h *Handler) sessionRest(ctx context.Context, userID uuid.UUID, we database.WorkoutsExercise) (int32, error) {
+	member, ok, err := h.findGroupMember(ctx, userID, we)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return int32(h.cfg.RestTimer.Seconds()), nil
+	}
+	return grouping.RestAfter(member.Group, member.Index, member.Count), nil
+}
+
+func groupSettings(g database.WorkoutExerciseGroup) grouping.Group {
+	return grouping.Group{
+		Kind:        grouping.Kind(g.Kind),
+		Label:       g.Label,
+		Rounds:      g.Rounds,
+		RestSeconds: g.RestSeconds,
+	}
+}
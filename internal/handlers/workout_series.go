@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/schedule"
+)
+
+// scopeFuture applies an edit or delete to a workout and every later workout
+// in its series that hasn't been completed.
+const scopeFuture = "future"
+
+var errAlreadyRepeating = errors.New("workout already repeats")
+
+// parseRule builds a recurrence rule from raw input. Weekdays take precedence;
+// without them the rule repeats every interval days.
+func parseRule(days []string, interval, weeks string) (schedule.Rule, error) {
+	rule := schedule.Rule{}
+	if len(days) > 0 {
+		weekdays, err := schedule.ParseWeekdays(days)
+		if err != nil {
+			return schedule.Rule{}, err
+		}
+		rule.Weekdays = weekdays
+	} else if interval != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(interval))
+		if err != nil {
+			return schedule.Rule{}, errors.New("day interval must be a number")
+		}
+		rule.IntervalDays = n
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(weeks))
+	if err != nil {
+		return schedule.Rule{}, errors.New("weeks must be a number")
+	}
+	rule.Weeks = n
+
+	if err := rule.Validate(); err != nil {
+		return schedule.Rule{}, err
+	}
+	return rule, nil
+}
+
+// ruleFromForm reads the repeat fields shared by the workout forms. ok is false
+// when the user chose not to repeat the workout.
+func ruleFromForm(r *http.Request) (rule schedule.Rule, ok bool, err error) {
+	switch r.FormValue("repeat") {
+	case "weekly":
+		days := r.Form["repeat-days"]
+		if len(days) == 0 {
+			return schedule.Rule{}, false, errors.New("choose at least one day to repeat on")
+		}
+		rule, err = parseRule(days, "", r.FormValue("repeat-weeks"))
+	case "interval":
+		rule, err = parseRule(nil, r.FormValue("repeat-interval"), r.FormValue("repeat-weeks"))
+	default:
+		return schedule.Rule{}, false, nil
+	}
+	if err != nil {
+		return schedule.Rule{}, false, err
+	}
+	return rule, true, nil
+}
+
+// seriesRule converts a stored series back into the rule that generated it.
+func seriesRule(series database.WorkoutSeries) schedule.Rule {
+	rule := schedule.Rule{IntervalDays: int(series.IntervalDays), Weeks: int(series.Weeks)}
+	for _, day := range series.Weekdays {
+		rule.Weekdays = append(rule.Weekdays, time.Weekday(day))
+	}
+	return rule
+}
+
+// repeatWorkout makes source the first workout of a new series and schedules
+// a copy of it, exercises included, on every later date the rule falls on.
+// The copies keep their planned sets but start with nothing completed.
+func repeatWorkout(ctx context.Context, qtx *database.Queries, userID uuid.UUID, source database.Workout, rule schedule.Rule) ([]database.Workout, error) {
+	if source.SeriesID.Valid {
+		return nil, errAlreadyRepeating
+	}
+
+	weekdays := make([]int32, len(rule.Weekdays))
+	for i, day := range rule.Weekdays {
+		weekdays[i] = int32(day)
+	}
+	series, err := qtx.CreateWorkoutSeries(ctx, database.CreateWorkoutSeriesParams{
+		UserID:       userID,
+		Weekdays:     weekdays,
+		IntervalDays: int32(rule.IntervalDays),
+		Weeks:        int32(rule.Weeks),
+		StartDate:    source.PlannedDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	seriesID := uuid.NullUUID{UUID: series.ID, Valid: true}
+
+	err = qtx.SetWorkoutSeries(ctx, database.SetWorkoutSeriesParams{
+		SeriesID: seriesID,
+		ID:       source.ID,
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Date(source.PlannedDate.Year(), source.PlannedDate.Month(), source.PlannedDate.Day(), 0, 0, 0, 0, time.UTC)
+	occurrences := []database.Workout{}
+	for _, date := range rule.Dates(start) {
+		if date.Equal(start) {
+			continue
+		}
+		workout, err := qtx.CreateSeriesWorkout(ctx, database.CreateSeriesWorkoutParams{
+			UserID:          userID,
+			Title:           source.Title,
+			Description:     source.Description,
+			DurationMinutes: source.DurationMinutes,
+			PlannedDate:     date,
+			SeriesID:        seriesID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Groups first, so copied exercises can find their group by label
+		err = qtx.CopyWorkoutExerciseGroups(ctx, database.CopyWorkoutExerciseGroupsParams{
+			TargetID: workout.ID,
+			SourceID: source.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = qtx.CopyWorkoutExercises(ctx, database.CopyWorkoutExercisesParams{
+			TargetID: workout.ID,
+			SourceID: source.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, workout)
+	}
+	return occurrences, nil
+}
+
+// repeatExistingWorkout turns a saved workout into a series in one transaction.
+func (h *Handler) repeatExistingWorkout(ctx context.Context, userID, workoutID uuid.UUID, rule schedule.Rule) ([]database.Workout, error) {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	source, err := qtx.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{
+		ID:     workoutID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := repeatWorkout(ctx, qtx, userID, source, rule)
+	if err != nil {
+		return nil, err
+	}
+	return occurrences, tx.Commit()
+}
+
+// createRepeatingWorkout creates a workout and its future occurrences in one
+// transaction.
+func (h *Handler) createRepeatingWorkout(ctx context.Context, params database.CreateWorkoutParams, rule schedule.Rule) (database.Workout, error) {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return database.Workout{}, err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	workout, err := qtx.CreateWorkout(ctx, params)
+	if err != nil {
+		return database.Workout{}, err
+	}
+	if _, err := repeatWorkout(ctx, qtx, params.UserID, workout, rule); err != nil {
+		return database.Workout{}, err
+	}
+	updated, err := qtx.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{ID: workout.ID, UserID: params.UserID})
+	if err != nil {
+		return database.Workout{}, err
+	}
+	return updated, tx.Commit()
+}
+
+// describeSeries returns the rule text for a workout's series, or "" when the
+// workout does not repeat.
+func (h *Handler) describeSeries(ctx context.Context, userID uuid.UUID, workout database.Workout) (string, error) {
+	if !workout.SeriesID.Valid {
+		return "", nil
+	}
+	series, err := h.cfg.DB.GetWorkoutSeries(ctx, database.GetWorkoutSeriesParams{
+		ID:     workout.SeriesID.UUID,
+		UserID: userID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return seriesRule(series).String(), nil
+}
+
+// deleteFutureOccurrences deletes a workout along with every later workout in
+// its series that hasn't been completed. Workouts outside a series are simply
+// deleted.
+func (h *Handler) deleteFutureOccurrences(ctx context.Context, userID, workoutID uuid.UUID) error {
+	tx, err := h.cfg.RawDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	qtx := h.cfg.DB.WithTx(tx)
+
+	workout, err := qtx.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{
+		ID:     workoutID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = qtx.DeleteWorkout(ctx, database.DeleteWorkoutParams{ID: workoutID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if workout.SeriesID.Valid {
+		err = qtx.DeleteFutureSeriesWorkouts(ctx, database.DeleteFutureSeriesWorkoutsParams{
+			SeriesID:    workout.SeriesID,
+			UserID:      userID,
+			PlannedDate: workout.PlannedDate,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/session"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+var (
+	errSessionNotStarted = errors.New("start the workout before logging sets")
+	errSessionFinished   = errors.New("workout is already completed")
+)
+
+// activeWorkout returns the user's workout if it has been started and not
+// yet finished.
+func (h *Handler) activeWorkout(ctx context.Context, userID, workoutID uuid.UUID) (database.Workout, error) {
+	workout, err := h.cfg.DB.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{
+		ID:     workoutID,
+		UserID: userID,
+	})
+	if err != nil {
+		return database.Workout{}, err
+	}
+	if workout.DateCompleted.Valid {
+		return database.Workout{}, errSessionFinished
+	}
+	if !workout.StartedAt.Valid {
+		return database.Workout{}, errSessionNotStarted
+	}
+	return workout, nil
+}
+
+// startWorkout stamps the session start time. Starting an already started
+// workout keeps its original start time.
+func (h *Handler) startWorkout(ctx context.Context, userID, workoutID uuid.UUID) (database.Workout, error) {
+	workout, err := h.cfg.DB.StartWorkout(ctx, database.StartWorkoutParams{
+		StartedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		ID:        workoutID,
+		UserID:    userID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Either the workout does not exist or it was already completed
+		if _, err := h.cfg.DB.GetWorkoutByID(ctx, database.GetWorkoutByIDParams{ID: workoutID, UserID: userID}); err != nil {
+			return database.Workout{}, err
+		}
+		return database.Workout{}, errSessionFinished
+	}
+	return workout, err
+}
+
+// updateSessionSets applies change to the completed sets of a workout
+// exercise in an active workout, saves them and refreshes the personal
+// records they earn.
+func (h *Handler) updateSessionSets(ctx context.Context, userID, workoutID, workoutExerciseID uuid.UUID, change func(session.Sets) (session.Sets, error)) (database.WorkoutsExercise, []database.PersonalRecord, error) {
+	if _, err := h.activeWorkout(ctx, userID, workoutID); err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	we, err := h.cfg.DB.GetWorkoutExercise(ctx, database.GetWorkoutExerciseParams{
+		ID:        workoutExerciseID,
+		WorkoutID: workoutID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	exercise, err := h.cfg.DB.GetExerciseByID(ctx, we.ExerciseID)
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	sets, err := change(session.Sets{
+		Reps:       we.RepsPerSetCompleted,
+		Weights:    we.WeightsCompletedLbs,
+		Timed:      tracking.Type(exercise.TrackingType).Timed(),
+		Durations:  we.DurationsCompletedSeconds,
+		Distances:  we.DistancesCompletedMeters,
+		HeartRates: we.HeartRatesCompleted,
+	})
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	we, err = h.cfg.DB.UpdateCompletedSets(ctx, database.UpdateCompletedSetsParams{
+		SetsCompleted:             sets.Count(),
+		RepsPerSetCompleted:       orEmpty(sets.Reps),
+		WeightsCompletedLbs:       orEmpty(sets.Weights),
+		DurationsCompletedSeconds: orEmpty(sets.Durations),
+		DistancesCompletedMeters:  orEmpty(sets.Distances),
+		HeartRatesCompleted:       orEmpty(sets.HeartRates),
+		ID:                        workoutExerciseID,
+		WorkoutID:                 workoutID,
+		UserID:                    userID,
+	})
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	// Undone sets take their effort ratings and notes with them
+	err = h.cfg.DB.TrimWorkoutSetDetails(ctx, database.TrimWorkoutSetDetailsParams{
+		WorkoutExerciseID: we.ID,
+		SetNumber:         we.SetsCompleted,
+	})
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+
+	records, err := h.recordPersonalRecords(ctx, userID, we)
+	if err != nil {
+		return database.WorkoutsExercise{}, nil, err
+	}
+	return we, records, nil
+}
+
+// parseTimedSet reads one logged set of a timed exercise. The distance is in
+// the user's unit; distance and heart rate may be blank.
+func parseTimedSet(duration, distance, heartRate string, system units.System) (session.TimedSet, error) {
+	seconds, err := tracking.ParseDuration(duration)
+	if err != nil {
+		return session.TimedSet{}, err
+	}
+	if seconds < 1 {
+		return session.TimedSet{}, errors.New("duration must be at least 1 second")
+	}
+	meters, err := system.ParseDistance(distance)
+	if err != nil {
+		return session.TimedSet{}, err
+	}
+	bpm, err := tracking.ParseHeartRate(heartRate)
+	if err != nil {
+		return session.TimedSet{}, err
+	}
+	return session.TimedSet{Seconds: seconds, Meters: meters, HeartRate: bpm}, nil
+}
+
+// orEmpty stands in an empty slice for nil so timed set columns, which can't
+// be null, are cleared rather than nulled.
+func orEmpty(values []int32) []int32 {
+	if values == nil {
+		return []int32{}
+	}
+	return values
+}
+
+// finishWorkout completes an active workout, recording how long it took.
+func (h *Handler) finishWorkout(ctx context.Context, userID, workoutID uuid.UUID) (database.Workout, error) {
+	workout, err := h.activeWorkout(ctx, userID, workoutID)
+	if err != nil {
+		return database.Workout{}, err
+	}
+
+	now := time.Now().UTC()
+	return h.cfg.DB.FinishWorkout(ctx, database.FinishWorkoutParams{
+		DateCompleted:   sql.NullTime{Time: now, Valid: true},
+		DurationMinutes: session.Minutes(workout.StartedAt.Time, now),
+		ID:              workoutID,
+		UserID:          userID,
+	})
+}
@@ -0,0 +1,114 @@
+// Package mail sends the emails FitHub sends its users, such as password reset
+// links, over SMTP or to a local outbox in development
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// SMTP delivers messages through an SMTP server, authenticating with PLAIN
+// auth when a username is set.
+type SMTP struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (s SMTP) Send(ctx context.Context, msg Message) error {
+	data, err := format(s.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+	return smtp.SendMail(net.JoinHostPort(s.Host, s.Port), auth, s.From, []string{msg.To}, data)
+}
+
+// Outbox keeps messages instead of delivering them, for development and
+// tests. Each message is written to its own file in Dir, or only logged when
+// Dir is empty.
+type Outbox struct {
+	Dir    string
+	From   string
+	Logger *slog.Logger
+
+	sent atomic.Int64
+}
+
+func (o *Outbox) Send(ctx context.Context, msg Message) error {
+	data, err := format(o.From, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	if o.Dir == "" {
+		o.Logger.Info("mail outbox", slog.String("to", msg.To), slog.String("subject", msg.Subject), slog.String("body", msg.Body))
+		return nil
+	}
+
+	if err := os.MkdirAll(o.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%d-%03d.eml", time.Now().UnixNano(), o.sent.Add(1))
+	path := filepath.Join(o.Dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	o.Logger.Info("mail written to outbox", slog.String("to", msg.To), slog.String("subject", msg.Subject), slog.String("path", path))
+	return nil
+}
+
+// format renders a message with its headers. Addresses and subjects with line
+// breaks are rejected so they can't add headers of their own.
+func format(from string, msg Message, now time.Time) ([]byte, error) {
+	for _, v := range []string{from, msg.To, msg.Subject} {
+		if strings.ContainsAny(v, "\r\n") {
+			return nil, errors.New("mail headers cannot contain line breaks")
+		}
+	}
+	if msg.To == "" {
+		return nil, errors.New("mail needs a recipient")
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes(), nil
+}
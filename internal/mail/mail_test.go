@@ -0,0 +1,89 @@
+package mail
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		msg     Message
+		want    []string
+		wantErr bool
+	}{
+		"plain": {
+			msg: Message{To: "a@example.com", Subject: "Reset your password", Body: "Hi\nthere"},
+			want: []string{
+				"From: FitHub <noreply@example.com>\r\n",
+				"To: a@example.com\r\n",
+				"Subject: Reset your password\r\n",
+				"Date: Mon, 10 Mar 2025 09:30:00 +0000\r\n",
+				"\r\n\r\nHi\r\nthere",
+			},
+		},
+		"non ascii subject": {
+			msg:  Message{To: "a@example.com", Subject: "Réinitialiser", Body: "x"},
+			want: []string{"Subject: =?utf-8?q?R=C3=A9initialiser?=\r\n"},
+		},
+		"header injection": {
+			msg:     Message{To: "a@example.com\r\nBcc: b@example.com", Subject: "x", Body: "x"},
+			wantErr: true,
+		},
+		"subject injection": {
+			msg:     Message{To: "a@example.com", Subject: "x\nBcc: b@example.com", Body: "x"},
+			wantErr: true,
+		},
+		"no recipient": {
+			msg:     Message{Subject: "x", Body: "x"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := format("FitHub <noreply@example.com>", tc.msg, now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(string(got), w) {
+					t.Errorf("expected message to contain %q, got %q", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxSend(t *testing.T) {
+	dir := t.TempDir()
+	outbox := &Outbox{Dir: dir, From: "noreply@example.com", Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	for _, to := range []string{"a@example.com", "b@example.com"} {
+		if err := outbox.Send(context.Background(), Message{To: to, Subject: "Hello", Body: "Body"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(files))
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), "Subject: Hello\r\n") {
+		t.Errorf("expected subject header, got %q", data)
+	}
+}
@@ -0,0 +1,306 @@
+// Package metrics describes the body measurements users track: built-in types
+// such as body weight, waist and blood pressure, and custom types users define
+// for themselves
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+// Kind decides how a metric's values are converted and shown.
+type Kind string
+
+const (
+	Mass          Kind = "mass"
+	Length        Kind = "length"
+	Percent       Kind = "percent"
+	HeartRate     Kind = "heart_rate"
+	BloodPressure Kind = "blood_pressure"
+	Custom        Kind = "custom"
+)
+
+const (
+	// MaxValue is the largest value a measurement column can hold.
+	MaxValue     = 99999999.99
+	maxNameLen   = 50
+	maxUnitLen   = 20
+	maxDecimals  = 2
+	reservedSlug = "types"
+	// clockSkew is how far ahead of the server's clock a measured-at time may
+	// be, so a device running slightly fast can still log "now".
+	clockSkew = 5 * time.Minute
+	// localLayout is the value of an HTML datetime-local input.
+	localLayout = "2006-01-02T15:04"
+)
+
+// Type is one kind of measurement and the rules its values follow. Mass is
+// stored in pounds and length in inches so they follow the user's unit
+// system; every other kind is stored as entered. Min and Max are in the
+// stored unit.
+type Type struct {
+	Slug          string
+	Name          string
+	Kind          Kind
+	Unit          string
+	Decimals      int
+	Min           float64
+	Max           float64
+	SecondaryName string
+}
+
+// aliases maps the paths the three original metric tables were served under
+// to the slugs of the types that replaced them.
+var aliases = map[string]string{
+	"bodyweights":          "body_weight",
+	"body_weights":         "body_weight",
+	"muscleMasses":         "muscle_mass",
+	"muscle_masses":        "muscle_mass",
+	"bfPercents":           "body_fat",
+	"body_fat_percents":    "body_fat",
+	"body_fat_percentages": "body_fat",
+}
+
+// Canonical resolves an old metric path to its type's slug. Any other slug is
+// returned unchanged.
+func Canonical(slug string) string {
+	if s, ok := aliases[slug]; ok {
+		return s
+	}
+	return slug
+}
+
+// Slug derives a URL-safe key from a metric name, e.g. "Neck (relaxed)"
+// becomes "neck_relaxed".
+func Slug(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		if b.Len() > 0 && !underscore {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// NewCustom validates a user-defined metric. Decimals default to 2 and the
+// range to any value from 0 up.
+func NewCustom(name, unit, decimals, min, max string) (Type, error) {
+	name = strings.TrimSpace(name)
+	unit = strings.TrimSpace(unit)
+	if name == "" {
+		return Type{}, errors.New("name is required")
+	}
+	if len(name) > maxNameLen {
+		return Type{}, fmt.Errorf("name must be %d characters or fewer", maxNameLen)
+	}
+	if len(unit) > maxUnitLen {
+		return Type{}, fmt.Errorf("unit must be %d characters or fewer", maxUnitLen)
+	}
+	slug := Slug(name)
+	if slug == "" {
+		return Type{}, errors.New("name must contain a letter or number")
+	}
+	if slug == reservedSlug || aliases[slug] != "" {
+		return Type{}, errors.New("a metric with this name already exists")
+	}
+
+	t := Type{Slug: slug, Name: name, Kind: Custom, Unit: unit, Decimals: maxDecimals, Max: MaxValue}
+	if decimals = strings.TrimSpace(decimals); decimals != "" {
+		d, err := strconv.Atoi(decimals)
+		if err != nil || d < 0 || d > maxDecimals {
+			return Type{}, fmt.Errorf("decimals must be between 0 and %d", maxDecimals)
+		}
+		t.Decimals = d
+	}
+	var err error
+	if t.Min, err = parseBound(min, 0, "minimum"); err != nil {
+		return Type{}, err
+	}
+	if t.Max, err = parseBound(max, MaxValue, "maximum"); err != nil {
+		return Type{}, err
+	}
+	if t.Min >= t.Max {
+		return Type{}, errors.New("minimum must be less than maximum")
+	}
+	return t, nil
+}
+
+func parseBound(v string, fallback float64, field string) (float64, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return fallback, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.Abs(f) > MaxValue {
+		return 0, fmt.Errorf("%s must be a number", field)
+	}
+	return f, nil
+}
+
+// Paired reports whether each measurement has a second value, such as the
+// diastolic reading of a blood pressure.
+func (t Type) Paired() bool {
+	return t.SecondaryName != ""
+}
+
+// DisplayUnit is the unit values are entered and shown in.
+func (t Type) DisplayUnit(system units.System) string {
+	switch t.Kind {
+	case Mass:
+		return system.Unit()
+	case Length:
+		return system.LengthUnit()
+	}
+	return t.Unit
+}
+
+// Format renders a stored value in the user's unit without the unit.
+func (t Type) Format(stored string, system units.System) string {
+	switch t.Kind {
+	case Mass:
+		return system.FormatMass(stored)
+	case Length:
+		return system.FormatLength(stored)
+	}
+	f, err := strconv.ParseFloat(stored, 64)
+	if err != nil {
+		return stored
+	}
+	return strconv.FormatFloat(f, 'f', t.Decimals, 64)
+}
+
+// Display converts a stored value into the user's unit without rounding.
+func (t Type) Display(stored float64, system units.System) float64 {
+	switch t.Kind {
+	case Mass:
+		return system.FromLbs(stored)
+	case Length:
+		return system.FromInches(stored)
+	}
+	return stored
+}
+
+// FormatValue renders a value in the stored unit, such as an average, in the
+// user's unit without the unit.
+func (t Type) FormatValue(v float64, system units.System) string {
+	return t.Format(strconv.FormatFloat(v, 'f', 2, 64), system)
+}
+
+// FormatChange renders a difference between two stored values in the user's
+// unit with its sign, e.g. "+0.5" or "-1.2". Changes that round to zero have
+// no sign.
+func (t Type) FormatChange(delta float64, system units.System) string {
+	shown := t.FormatValue(math.Abs(delta), system)
+	if f, _ := strconv.ParseFloat(shown, 64); f == 0 {
+		return shown
+	}
+	if delta < 0 {
+		return "-" + shown
+	}
+	return "+" + shown
+}
+
+// Show renders a measurement with its unit, e.g. "80.0 kg", "18.5%" or
+// "120/80 mmHg". Secondary is ignored unless the type is paired.
+func (t Type) Show(value, secondary string, system units.System) string {
+	shown := t.Format(value, system)
+	if t.Paired() && secondary != "" {
+		shown += "/" + t.Format(secondary, system)
+	}
+	return withUnit(shown, t.DisplayUnit(system))
+}
+
+// Parse reads a value entered in the user's unit, checks it against the
+// type's range and returns it as stored.
+func (t Type) Parse(v string, system units.System) (string, error) {
+	notNumber := fmt.Errorf("%s must be a number", strings.ToLower(t.Name))
+	v = strings.TrimSpace(v)
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", notNumber
+	}
+
+	var stored string
+	switch t.Kind {
+	case Mass:
+		stored, err = system.ParseMass(v)
+	case Length:
+		stored, err = system.ParseLength(v)
+	default:
+		stored = strconv.FormatFloat(f, 'f', t.Decimals, 64)
+	}
+	if err != nil {
+		return "", notNumber
+	}
+
+	f, _ = strconv.ParseFloat(stored, 64)
+	if f < t.Min || f > t.Max {
+		return "", fmt.Errorf("%s must be between %s", strings.ToLower(t.Name), t.rangeLabel(system))
+	}
+	return stored, nil
+}
+
+// rangeLabel renders the type's range in the user's unit, e.g. "9.1 and
+// 453.1 kg".
+func (t Type) rangeLabel(system units.System) string {
+	bound := func(f float64) string {
+		return t.Format(strconv.FormatFloat(f, 'f', -1, 64), system)
+	}
+	return bound(t.Min) + " and " + withUnit(bound(t.Max), t.DisplayUnit(system))
+}
+
+func withUnit(value, unit string) string {
+	switch unit {
+	case "":
+		return value
+	case "%":
+		return value + unit
+	}
+	return value + " " + unit
+}
+
+// ParseMeasuredAt reads when a measurement was taken. Blank means now. Values
+// carrying a zone, such as RFC 3339, are taken as given; a datetime-local
+// value or a bare date without one is read as UTC. Times in the future are
+// rejected.
+func ParseMeasuredAt(v string, now time.Time) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return now, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		if t, err = parseLocal(v); err != nil {
+			return time.Time{}, errors.New("measured at must be a date and time")
+		}
+	}
+	if t.After(now.Add(clockSkew)) {
+		return time.Time{}, errors.New("measured at cannot be in the future")
+	}
+	return t, nil
+}
+
+func parseLocal(v string) (time.Time, error) {
+	var err error
+	for _, layout := range []string{localLayout, localLayout + ":05", time.DateOnly} {
+		var t time.Time
+		if t, err = time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,202 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+var (
+	bodyWeight    = Type{Slug: "body_weight", Name: "Body Weight", Kind: Mass, Decimals: 2, Min: 20, Max: 999}
+	waist         = Type{Slug: "waist", Name: "Waist", Kind: Length, Decimals: 1, Min: 10, Max: 100}
+	bodyFat       = Type{Slug: "body_fat", Name: "Body Fat", Kind: Percent, Unit: "%", Decimals: 2, Min: 1, Max: 75}
+	bloodPressure = Type{Slug: "blood_pressure", Name: "Blood Pressure", Kind: BloodPressure, Unit: "mmHg", Min: 30, Max: 250, SecondaryName: "diastolic"}
+)
+
+func TestCanonical(t *testing.T) {
+	tests := map[string]struct {
+		slug string
+		want string
+	}{
+		"html path":  {slug: "bodyweights", want: "body_weight"},
+		"api path":   {slug: "body_fat_percentages", want: "body_fat"},
+		"slug":       {slug: "waist", want: "waist"},
+		"custom":     {slug: "neck", want: "neck"},
+		"camel case": {slug: "muscleMasses", want: "muscle_mass"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Canonical(tc.slug); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		want string
+	}{
+		"simple":      {name: "Neck", want: "neck"},
+		"spaces":      {name: "  Left Thigh ", want: "left_thigh"},
+		"punctuation": {name: "Neck (relaxed)", want: "neck_relaxed"},
+		"digits":      {name: "VO2 max", want: "vo2_max"},
+		"symbols":     {name: "%%", want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Slug(tc.name); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewCustom(t *testing.T) {
+	tests := map[string]struct {
+		name, unit, decimals, min, max string
+		want                           Type
+		wantErr                        bool
+	}{
+		"defaults": {
+			name: "Neck", unit: "cm",
+			want: Type{Slug: "neck", Name: "Neck", Kind: Custom, Unit: "cm", Decimals: 2, Max: MaxValue},
+		},
+		"range": {
+			name: "VO2 max", decimals: "1", min: "10", max: "90",
+			want: Type{Slug: "vo2_max", Name: "VO2 max", Kind: Custom, Decimals: 1, Min: 10, Max: 90},
+		},
+		"no name":        {name: " ", wantErr: true},
+		"reserved":       {name: "Types", wantErr: true},
+		"old path":       {name: "Bodyweights", wantErr: true},
+		"bad decimals":   {name: "Neck", decimals: "3", wantErr: true},
+		"inverted range": {name: "Neck", min: "50", max: "10", wantErr: true},
+		"bad bound":      {name: "Neck", max: "lots", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewCustom(tc.name, tc.unit, tc.decimals, tc.min, tc.max)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		metric  Type
+		system  units.System
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"imperial mass": {metric: bodyWeight, system: units.Imperial, input: "180.5", want: "180.5"},
+		"metric mass":   {metric: bodyWeight, system: units.Metric, input: "80", want: "176.37"},
+		"metric length": {metric: waist, system: units.Metric, input: "81", want: "31.89"},
+		"percent":       {metric: bodyFat, system: units.Metric, input: "18.456", want: "18.46"},
+		"whole numbers": {metric: bloodPressure, system: units.Imperial, input: "120.4", want: "120"},
+		"below range":   {metric: bodyWeight, system: units.Imperial, input: "5", wantErr: true},
+		"above range":   {metric: bodyFat, system: units.Imperial, input: "80", wantErr: true},
+		"not a number":  {metric: waist, system: units.Imperial, input: "wide", wantErr: true},
+		"metric below":  {metric: bodyWeight, system: units.Metric, input: "9", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.metric.Parse(tc.input, tc.system)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestShow(t *testing.T) {
+	tests := map[string]struct {
+		metric    Type
+		system    units.System
+		value     string
+		secondary string
+		want      string
+	}{
+		"imperial mass":  {metric: bodyWeight, system: units.Imperial, value: "180.50", want: "180.50 lbs"},
+		"metric mass":    {metric: bodyWeight, system: units.Metric, value: "176.37", want: "80.0 kg"},
+		"metric length":  {metric: waist, system: units.Metric, value: "31.89", want: "81.0 cm"},
+		"percent":        {metric: bodyFat, system: units.Imperial, value: "18.50", want: "18.50%"},
+		"blood pressure": {metric: bloodPressure, system: units.Imperial, value: "120.00", secondary: "80.00", want: "120/80 mmHg"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.metric.Show(tc.value, tc.secondary, tc.system); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatChange(t *testing.T) {
+	tests := map[string]struct {
+		metric Type
+		system units.System
+		delta  float64
+		want   string
+	}{
+		"imperial loss": {metric: bodyWeight, system: units.Imperial, delta: -1.234, want: "-1.23"},
+		"metric loss":   {metric: bodyWeight, system: units.Metric, delta: -2.2, want: "-1.0"},
+		"gain":          {metric: waist, system: units.Imperial, delta: 0.5, want: "+0.50"},
+		"rounds to 0":   {metric: bloodPressure, system: units.Imperial, delta: -0.2, want: "0"},
+		"none":          {metric: bodyFat, system: units.Imperial, want: "0.00"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.metric.FormatChange(tc.delta, tc.system); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseMeasuredAt(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		"blank":          {want: now},
+		"rfc3339":        {value: "2025-03-09T07:30:00-05:00", want: time.Date(2025, 3, 9, 12, 30, 0, 0, time.UTC)},
+		"browser iso":    {value: "2025-03-09T12:30:00.000Z", want: time.Date(2025, 3, 9, 12, 30, 0, 0, time.UTC)},
+		"datetime-local": {value: "2025-03-09T07:30", want: time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC)},
+		"seconds":        {value: "2025-03-09T07:30:15", want: time.Date(2025, 3, 9, 7, 30, 15, 0, time.UTC)},
+		"date only":      {value: "2025-03-09", want: time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC)},
+		"slightly ahead": {value: "2025-03-10T12:03:00Z", want: time.Date(2025, 3, 10, 12, 3, 0, 0, time.UTC)},
+		"future":         {value: "2025-03-11", wantErr: true},
+		"garbage":        {value: "yesterday", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseMeasuredAt(tc.value, now)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
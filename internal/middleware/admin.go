@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/kairos4213/fithub/internal/cntx"
+	"github.com/kairos4213/fithub/internal/utils"
+)
+
+// Admin restricts a route to users whose JWT carries the admin claim. It must
+// be wrapped by Auth so the claims are already on the request context.
+func (mw *Middleware) Admin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cntx.IsAdmin(r.Context()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, _ := cntx.UserID(r.Context())
+		mw.cfg.Logger.Info("non-admin access attempt", slog.String("user_id", userID.String()), slog.String("path", r.URL.Path))
+
+		if r.Header.Get("Accept") == "application/json" {
+			utils.RespondWithError(w, http.StatusForbidden, "Admin access required", nil)
+			return
+		}
+		http.Redirect(w, r, "/forbidden", http.StatusSeeOther)
+	})
+}
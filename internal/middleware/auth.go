@@ -28,7 +28,7 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), cntx.UserIDKey, claims.UserID)
+			ctx := withClaims(r.Context(), claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
@@ -40,6 +40,7 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 			accessToken, errReason := mw.refreshAccessToken(w, r)
 			if errReason != "" {
 				http.Redirect(w, r, fmt.Sprintf("/unauthorized?reason=%v", errReason), http.StatusSeeOther)
+				return
 			}
 			// Successful access token refresh
 			utils.SetAccessCookie(w, accessToken)
@@ -52,7 +53,7 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 				mw.cfg.Logger.Error("unable to validate JWT", slog.String("error", err.Error()))
 				return
 			}
-			ctx := context.WithValue(r.Context(), cntx.UserIDKey, claims.UserID)
+			ctx := withClaims(r.Context(), claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
@@ -65,6 +66,7 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 				accessToken, errReason := mw.refreshAccessToken(w, r)
 				if errReason != "" {
 					http.Redirect(w, r, fmt.Sprintf("/unauthorized?reason=%v", errReason), http.StatusSeeOther)
+					return
 				}
 				// Successful access token refresh
 				utils.SetAccessCookie(w, accessToken)
@@ -77,7 +79,7 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 					mw.cfg.Logger.Error("unable to validate JWT", slog.String("error", err.Error()))
 					return
 				}
-				ctx := context.WithValue(r.Context(), cntx.UserIDKey, claims.UserID)
+				ctx := withClaims(r.Context(), claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
@@ -89,11 +91,18 @@ func (mw *Middleware) Auth(next http.Handler) http.Handler {
 		}
 
 		// Valid access token
-		ctx := context.WithValue(r.Context(), cntx.UserIDKey, claims.UserID)
+		ctx := withClaims(r.Context(), claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// withClaims stores the identity carried by validated JWT claims on the
+// request context for downstream handlers and middleware.
+func withClaims(ctx context.Context, claims *auth.CustomClaims) context.Context {
+	ctx = context.WithValue(ctx, cntx.UserIDKey, claims.UserID)
+	return context.WithValue(ctx, cntx.IsAdminKey, claims.IsAdmin)
+}
+
 func (mw *Middleware) refreshAccessToken(w http.ResponseWriter, r *http.Request) (accessToken string, errReason string) {
 	// Check for refresh token existence
 	refreshCookie, err := r.Cookie("refresh_token")
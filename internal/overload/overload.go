@@ -0,0 +1,301 @@
+// Package overload suggests the next session's sets from the last one logged
+package overload
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+// Kind selects how the next session progresses after a successful one.
+type Kind string
+
+const (
+	// Linear adds a fixed weight to every loaded set each session.
+	Linear Kind = "linear"
+	// Double adds reps within a range and only adds weight once every set
+	// reaches the top of it, dropping back to the bottom of the range.
+	Double Kind = "double"
+)
+
+// Kinds lists every progression kind in display order.
+var Kinds = []Kind{Linear, Double}
+
+// ParseKind returns the named kind. An empty string is Linear.
+func ParseKind(s string) (Kind, error) {
+	if s == "" {
+		return Linear, nil
+	}
+	for _, k := range Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown progression policy %q", s)
+}
+
+// Label returns a human-readable name for the progression kind.
+func (k Kind) Label() string {
+	if k == Double {
+		return "Double Progression"
+	}
+	return "Linear Weight Increase"
+}
+
+// Policy is a user's progression configuration.
+type Policy struct {
+	Kind          Kind
+	WeightStep    int32
+	RepMin        int32
+	RepMax        int32
+	HoldOnFailure bool
+}
+
+// DefaultPolicy is used until a user saves their own.
+var DefaultPolicy = Policy{Kind: Linear, WeightStep: 5, RepMin: 8, RepMax: 12, HoldOnFailure: true}
+
+// The weight step is bounded in pounds, as it's stored.
+const (
+	MinWeightStep = 1
+	MaxWeightStep = 100
+)
+
+// Validate reports whether the policy can produce suggestions. Errors give
+// the weight step's bounds in the user's unit system.
+func (p Policy) Validate(system units.System) error {
+	if p.WeightStep < MinWeightStep || p.WeightStep > MaxWeightStep {
+		return fmt.Errorf("weight step must be between %s and %s %s",
+			system.FormatWeight(MinWeightStep), system.FormatWeight(MaxWeightStep), system.Unit())
+	}
+	if p.RepMin < 1 || p.RepMax > 100 || p.RepMin >= p.RepMax {
+		return errors.New("rep range must have a minimum below its maximum")
+	}
+	return nil
+}
+
+// Session is the planned and completed sets of an exercise in one workout.
+type Session struct {
+	PlannedReps      []int32
+	PlannedWeights   []int32
+	CompletedReps    []int32
+	CompletedWeights []int32
+}
+
+// Rule is the progression rule a suggestion was made by.
+type Rule string
+
+const (
+	// Hold repeats a plan the last session fell short of.
+	Hold Rule = "hold"
+	// AddBodyweightReps adds a rep to every set done at bodyweight.
+	AddBodyweightReps Rule = "bodyweight"
+	// AddWeight adds the weight step to every loaded set.
+	AddWeight Rule = "add_weight"
+	// AddReps adds a rep to every set until each reaches the top of the range.
+	AddReps Rule = "add_reps"
+	// AddWeightResetReps adds the weight step once every set reached the top
+	// of the range and drops back to the bottom of it.
+	AddWeightResetReps Rule = "add_weight_reset_reps"
+)
+
+// Basis is how a suggestion was made. It's stored rather than its reason so
+// the reason can be shown in whichever unit system the user has when they
+// read it. The step is in pounds.
+type Basis struct {
+	Rule    Rule      `json:"rule"`
+	Missed  string    `json:"missed,omitempty"`
+	StepLbs int32     `json:"step_lbs,omitempty"`
+	RepMin  int32     `json:"rep_min,omitempty"`
+	RepMax  int32     `json:"rep_max,omitempty"`
+	From    string    `json:"from,omitempty"`
+	On      time.Time `json:"on,omitzero"`
+}
+
+// Reason describes the basis in the given unit system, e.g. "All planned
+// reps completed: +2.5 kg (from Push Day, Mar 3)".
+func (b Basis) Reason(system units.System) string {
+	var reason string
+	switch b.Rule {
+	case Hold:
+		reason = "Holding last session's plan: " + b.Missed
+	case AddBodyweightReps:
+		reason = "Bodyweight sets: add one rep per set"
+	case AddWeight:
+		reason = fmt.Sprintf("All planned reps completed: +%s %s", system.FormatWeight(b.StepLbs), system.Unit())
+	case AddReps:
+		reason = fmt.Sprintf("Same weight, one more rep per set until every set reaches %d", b.RepMax)
+	case AddWeightResetReps:
+		reason = fmt.Sprintf("Every set reached %d reps: +%s %s and back to %d reps",
+			b.RepMax, system.FormatWeight(b.StepLbs), system.Unit(), b.RepMin)
+	default:
+		return ""
+	}
+	if b.From != "" {
+		reason = fmt.Sprintf("%s (from %s, %s)", reason, b.From, b.On.Format("Jan 2"))
+	}
+	return reason
+}
+
+// Suggestion is the prescription for the next session and how it was made.
+type Suggestion struct {
+	Reps    []int32
+	Weights []int32
+	Basis   Basis
+}
+
+// Sets returns the number of suggested sets.
+func (s Suggestion) Sets() int32 {
+	return int32(len(s.Reps))
+}
+
+// Suggest derives the next session from the last one. A session that missed
+// any planned rep or set is repeated as planned when the policy holds on
+// failure; otherwise the completed sets progress according to the policy.
+func Suggest(p Policy, last Session) Suggestion {
+	reps, weights := completedSets(last)
+	if len(reps) == 0 {
+		return Suggestion{}
+	}
+
+	if p.HoldOnFailure && len(last.PlannedReps) > 0 {
+		if missed, ok := missedReps(last); ok {
+			return Suggestion{
+				Reps:    clone(last.PlannedReps),
+				Weights: padWeights(last.PlannedWeights, len(last.PlannedReps)),
+				Basis:   Basis{Rule: Hold, Missed: missed},
+			}
+		}
+	}
+
+	if allBodyweight(weights) {
+		return Suggestion{
+			Reps:    addEach(reps, 1),
+			Weights: weights,
+			Basis:   Basis{Rule: AddBodyweightReps},
+		}
+	}
+
+	if p.Kind == Double {
+		if minOf(reps) >= p.RepMax {
+			return Suggestion{
+				Reps:    fill(len(reps), p.RepMin),
+				Weights: addLoaded(weights, p.WeightStep),
+				Basis:   Basis{Rule: AddWeightResetReps, StepLbs: p.WeightStep, RepMin: p.RepMin, RepMax: p.RepMax},
+			}
+		}
+		next := make([]int32, len(reps))
+		for i, r := range reps {
+			next[i] = min(max(r+1, p.RepMin), p.RepMax)
+		}
+		return Suggestion{
+			Reps:    next,
+			Weights: weights,
+			Basis:   Basis{Rule: AddReps, RepMax: p.RepMax},
+		}
+	}
+
+	return Suggestion{
+		Reps:    reps,
+		Weights: addLoaded(weights, p.WeightStep),
+		Basis:   Basis{Rule: AddWeight, StepLbs: p.WeightStep},
+	}
+}
+
+// completedSets returns the completed sets that have reps, with weights
+// aligned to them.
+func completedSets(s Session) ([]int32, []int32) {
+	var reps, weights []int32
+	for i, r := range s.CompletedReps {
+		if r <= 0 {
+			continue
+		}
+		var w int32
+		if i < len(s.CompletedWeights) && s.CompletedWeights[i] > 0 {
+			w = s.CompletedWeights[i]
+		}
+		reps = append(reps, r)
+		weights = append(weights, w)
+	}
+	return reps, weights
+}
+
+// missedReps describes how the session fell short of its plan.
+func missedReps(s Session) (string, bool) {
+	done := 0
+	for _, r := range s.CompletedReps {
+		if r > 0 {
+			done++
+		}
+	}
+	if done < len(s.PlannedReps) {
+		return fmt.Sprintf("completed %d of %d planned sets", done, len(s.PlannedReps)), true
+	}
+
+	var short []string
+	for i, planned := range s.PlannedReps {
+		if i < len(s.CompletedReps) && s.CompletedReps[i] < planned {
+			short = append(short, fmt.Sprintf("set %d %d/%d", i+1, s.CompletedReps[i], planned))
+		}
+	}
+	if len(short) > 0 {
+		return "missed reps on " + strings.Join(short, ", "), true
+	}
+	return "", false
+}
+
+func allBodyweight(weights []int32) bool {
+	for _, w := range weights {
+		if w > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func addLoaded(weights []int32, step int32) []int32 {
+	out := make([]int32, len(weights))
+	for i, w := range weights {
+		if w > 0 {
+			w += step
+		}
+		out[i] = w
+	}
+	return out
+}
+
+func addEach(values []int32, n int32) []int32 {
+	out := make([]int32, len(values))
+	for i, v := range values {
+		out[i] = v + n
+	}
+	return out
+}
+
+func fill(n int, v int32) []int32 {
+	out := make([]int32, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func minOf(values []int32) int32 {
+	m := values[0]
+	for _, v := range values[1:] {
+		m = min(m, v)
+	}
+	return m
+}
+
+func clone(values []int32) []int32 {
+	return append([]int32(nil), values...)
+}
+
+func padWeights(weights []int32, n int) []int32 {
+	out := make([]int32, n)
+	copy(out, weights)
+	return out
+}
@@ -0,0 +1,194 @@
+package overload
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Kind
+		wantErr bool
+	}{
+		"empty":   {input: "", want: Linear},
+		"linear":  {input: "linear", want: Linear},
+		"double":  {input: "double", want: Double},
+		"unknown": {input: "deload", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseKind(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPolicyValidate(t *testing.T) {
+	tests := map[string]struct {
+		policy  Policy
+		wantErr bool
+	}{
+		"default":        {policy: DefaultPolicy},
+		"zero step":      {policy: Policy{Kind: Linear, WeightStep: 0, RepMin: 8, RepMax: 12}, wantErr: true},
+		"inverted range": {policy: Policy{Kind: Double, WeightStep: 5, RepMin: 12, RepMax: 8}, wantErr: true},
+		"empty range":    {policy: Policy{Kind: Double, WeightStep: 5, RepMin: 10, RepMax: 10}, wantErr: true},
+		"zero min":       {policy: Policy{Kind: Double, WeightStep: 5, RepMin: 0, RepMax: 10}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.policy.Validate(units.Imperial)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	linear := Policy{Kind: Linear, WeightStep: 5, RepMin: 8, RepMax: 12, HoldOnFailure: true}
+	double := Policy{Kind: Double, WeightStep: 10, RepMin: 8, RepMax: 12, HoldOnFailure: true}
+
+	tests := map[string]struct {
+		policy      Policy
+		last        Session
+		wantReps    []int32
+		wantWeights []int32
+		wantRule    Rule
+	}{
+		"nothing completed": {
+			policy: linear,
+			last:   Session{PlannedReps: []int32{5, 5}, PlannedWeights: []int32{100, 100}, CompletedReps: []int32{}},
+		},
+		"linear success": {
+			policy:      linear,
+			last:        Session{PlannedReps: []int32{5, 5}, PlannedWeights: []int32{100, 100}, CompletedReps: []int32{5, 5}, CompletedWeights: []int32{100, 100}},
+			wantReps:    []int32{5, 5},
+			wantWeights: []int32{105, 105},
+			wantRule:    AddWeight,
+		},
+		"linear missed reps holds": {
+			policy:      linear,
+			last:        Session{PlannedReps: []int32{5, 5}, PlannedWeights: []int32{100, 100}, CompletedReps: []int32{5, 3}, CompletedWeights: []int32{100, 100}},
+			wantReps:    []int32{5, 5},
+			wantWeights: []int32{100, 100},
+			wantRule:    Hold,
+		},
+		"missed set holds": {
+			policy:      linear,
+			last:        Session{PlannedReps: []int32{5, 5, 5}, PlannedWeights: []int32{100}, CompletedReps: []int32{5, 5}, CompletedWeights: []int32{100, 100}},
+			wantReps:    []int32{5, 5, 5},
+			wantWeights: []int32{100, 0, 0},
+			wantRule:    Hold,
+		},
+		"failure without hold progresses completed sets": {
+			policy:      Policy{Kind: Linear, WeightStep: 5, RepMin: 8, RepMax: 12},
+			last:        Session{PlannedReps: []int32{5, 5}, PlannedWeights: []int32{100, 100}, CompletedReps: []int32{5, 3}, CompletedWeights: []int32{100, 100}},
+			wantReps:    []int32{5, 3},
+			wantWeights: []int32{105, 105},
+			wantRule:    AddWeight,
+		},
+		"bodyweight adds reps": {
+			policy:      linear,
+			last:        Session{CompletedReps: []int32{10, 8}, CompletedWeights: []int32{0, 0}},
+			wantReps:    []int32{11, 9},
+			wantWeights: []int32{0, 0},
+			wantRule:    AddBodyweightReps,
+		},
+		"double adds reps within range": {
+			policy:      double,
+			last:        Session{CompletedReps: []int32{10, 12, 6}, CompletedWeights: []int32{50, 50, 50}},
+			wantReps:    []int32{11, 12, 8},
+			wantWeights: []int32{50, 50, 50},
+			wantRule:    AddReps,
+		},
+		"double top of range adds weight": {
+			policy:      double,
+			last:        Session{CompletedReps: []int32{12, 12}, CompletedWeights: []int32{50, 0}},
+			wantReps:    []int32{8, 8},
+			wantWeights: []int32{60, 0},
+			wantRule:    AddWeightResetReps,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Suggest(tc.policy, tc.last)
+			if !reflect.DeepEqual(got.Reps, tc.wantReps) {
+				t.Errorf("expected reps %v, got %v", tc.wantReps, got.Reps)
+			}
+			if !reflect.DeepEqual(got.Weights, tc.wantWeights) {
+				t.Errorf("expected weights %v, got %v", tc.wantWeights, got.Weights)
+			}
+			if got.Basis.Rule != tc.wantRule {
+				t.Errorf("expected rule %q, got %q", tc.wantRule, got.Basis.Rule)
+			}
+		})
+	}
+}
+
+func TestBasisReason(t *testing.T) {
+	on := time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		basis  Basis
+		system units.System
+		want   string
+	}{
+		"add weight in pounds": {
+			basis:  Basis{Rule: AddWeight, StepLbs: 5},
+			system: units.Imperial,
+			want:   "All planned reps completed: +5 lbs",
+		},
+		"add weight in kilograms": {
+			basis:  Basis{Rule: AddWeight, StepLbs: 5},
+			system: units.Metric,
+			want:   "All planned reps completed: +2.5 kg",
+		},
+		"reset reps in kilograms": {
+			basis:  Basis{Rule: AddWeightResetReps, StepLbs: 10, RepMin: 8, RepMax: 12},
+			system: units.Metric,
+			want:   "Every set reached 12 reps: +4.5 kg and back to 8 reps",
+		},
+		"hold with source": {
+			basis:  Basis{Rule: Hold, Missed: "completed 1 of 2 planned sets", From: "Push Day", On: on},
+			system: units.Metric,
+			want:   "Holding last session's plan: completed 1 of 2 planned sets (from Push Day, Mar 3)",
+		},
+		"no rule": {
+			basis:  Basis{},
+			system: units.Imperial,
+			want:   "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.basis.Reason(tc.system); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateWeightStepUnits(t *testing.T) {
+	policy := Policy{Kind: Linear, WeightStep: 0, RepMin: 8, RepMax: 12}
+
+	err := policy.Validate(units.Metric)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if want := "weight step must be between 0.5 and 45.5 kg"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err.Error())
+	}
+}
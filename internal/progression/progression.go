@@ -0,0 +1,133 @@
+// Package progression adjusts planned sets week over week for training programs
+package progression
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind identifies how a program week changes the planned sets.
+type Kind string
+
+const (
+	None      Kind = "none"
+	AddWeight Kind = "add_weight"
+	AddReps   Kind = "add_reps"
+	Deload    Kind = "deload"
+)
+
+// Kinds lists every progression kind in display order.
+var Kinds = []Kind{None, AddWeight, AddReps, Deload}
+
+// ParseKind returns the named kind. An empty string is None.
+func ParseKind(s string) (Kind, error) {
+	if s == "" {
+		return None, nil
+	}
+	for _, k := range Kinds {
+		if string(k) == s {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown progression %q", s)
+}
+
+// Label returns a human-readable name for the progression kind.
+func (k Kind) Label() string {
+	switch k {
+	case None:
+		return "No Change"
+	case AddWeight:
+		return "Add Weight"
+	case AddReps:
+		return "Add Reps"
+	case Deload:
+		return "Deload"
+	}
+	return string(k)
+}
+
+// Rule is the progression applied in one program week. Amount is pounds for
+// AddWeight, reps per set for AddReps and a percentage off for Deload.
+type Rule struct {
+	Kind   Kind
+	Amount int32
+}
+
+// Validate reports whether the amount makes sense for the rule's kind.
+func (r Rule) Validate() error {
+	switch r.Kind {
+	case None:
+		return nil
+	case AddWeight, AddReps:
+		if r.Amount < 1 {
+			return errors.New("progression amount must be at least 1")
+		}
+	case Deload:
+		if r.Amount < 1 || r.Amount > 90 {
+			return errors.New("deload must be between 1 and 90 percent")
+		}
+	default:
+		return fmt.Errorf("unknown progression %q", r.Kind)
+	}
+	return nil
+}
+
+// String describes the rule, e.g. "+5 lbs" or "Deload 40%".
+func (r Rule) String() string {
+	switch r.Kind {
+	case AddWeight:
+		return fmt.Sprintf("+%d lbs", r.Amount)
+	case AddReps:
+		if r.Amount == 1 {
+			return "+1 rep"
+		}
+		return fmt.Sprintf("+%d reps", r.Amount)
+	case Deload:
+		return fmt.Sprintf("Deload %d%%", r.Amount)
+	}
+	return "No change"
+}
+
+// Prescription is the planned reps and weight for each set of an exercise.
+type Prescription struct {
+	Reps    []int32
+	Weights []int32
+}
+
+// Week returns base as planned for the given zero-based week. Weight and rep
+// increases accumulate from the first week through week; a deload only
+// lightens its own week, so the following week picks up where the last
+// loaded week left off. Bodyweight sets (weight 0) never gain weight.
+func Week(base Prescription, rules []Rule, week int) Prescription {
+	var addWeight, addReps int32
+	for i := 0; i <= week && i < len(rules); i++ {
+		switch rules[i].Kind {
+		case AddWeight:
+			addWeight += rules[i].Amount
+		case AddReps:
+			addReps += rules[i].Amount
+		}
+	}
+
+	out := Prescription{
+		Reps:    make([]int32, len(base.Reps)),
+		Weights: make([]int32, len(base.Weights)),
+	}
+	for i, r := range base.Reps {
+		out.Reps[i] = r + addReps
+	}
+	for i, w := range base.Weights {
+		if w > 0 {
+			w += addWeight
+		}
+		out.Weights[i] = w
+	}
+
+	if week < len(rules) && rules[week].Kind == Deload {
+		for i, w := range out.Weights {
+			out.Weights[i] = w * (100 - rules[week].Amount) / 100
+		}
+	}
+	return out
+}
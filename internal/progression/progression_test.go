@@ -0,0 +1,97 @@
+package progression
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Kind
+		wantErr bool
+	}{
+		"empty":      {input: "", want: None},
+		"add weight": {input: "add_weight", want: AddWeight},
+		"deload":     {input: "deload", want: Deload},
+		"unknown":    {input: "double", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseKind(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := map[string]struct {
+		rule    Rule
+		wantErr bool
+	}{
+		"none":             {rule: Rule{Kind: None}},
+		"add weight":       {rule: Rule{Kind: AddWeight, Amount: 5}},
+		"add zero weight":  {rule: Rule{Kind: AddWeight}, wantErr: true},
+		"add reps":         {rule: Rule{Kind: AddReps, Amount: 1}},
+		"deload":           {rule: Rule{Kind: Deload, Amount: 40}},
+		"deload too far":   {rule: Rule{Kind: Deload, Amount: 95}, wantErr: true},
+		"unknown kind":     {rule: Rule{Kind: "double", Amount: 1}, wantErr: true},
+		"negative amount":  {rule: Rule{Kind: AddReps, Amount: -1}, wantErr: true},
+		"deload no amount": {rule: Rule{Kind: Deload}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.rule.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWeek(t *testing.T) {
+	base := Prescription{Reps: []int32{8, 8, 8}, Weights: []int32{100, 100, 0}}
+	rules := []Rule{
+		{Kind: None},
+		{Kind: AddWeight, Amount: 10},
+		{Kind: AddReps, Amount: 1},
+		{Kind: Deload, Amount: 50},
+		{Kind: AddWeight, Amount: 5},
+	}
+
+	tests := map[string]struct {
+		week int
+		want Prescription
+	}{
+		"first week":         {week: 0, want: Prescription{Reps: []int32{8, 8, 8}, Weights: []int32{100, 100, 0}}},
+		"adds weight":        {week: 1, want: Prescription{Reps: []int32{8, 8, 8}, Weights: []int32{110, 110, 0}}},
+		"adds reps":          {week: 2, want: Prescription{Reps: []int32{9, 9, 9}, Weights: []int32{110, 110, 0}}},
+		"deload":             {week: 3, want: Prescription{Reps: []int32{9, 9, 9}, Weights: []int32{55, 55, 0}}},
+		"resumes after":      {week: 4, want: Prescription{Reps: []int32{9, 9, 9}, Weights: []int32{115, 115, 0}}},
+		"past defined weeks": {week: 7, want: Prescription{Reps: []int32{9, 9, 9}, Weights: []int32{115, 115, 0}}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Week(base, rules, tc.week)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestWeekLeavesBaseUntouched(t *testing.T) {
+	base := Prescription{Reps: []int32{5}, Weights: []int32{200}}
+	Week(base, []Rule{{Kind: AddWeight, Amount: 10}}, 0)
+	if base.Reps[0] != 5 || base.Weights[0] != 200 {
+		t.Errorf("base was modified: %v", base)
+	}
+}
@@ -0,0 +1,109 @@
+// Package schedule expands recurrence rules into workout dates
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxWeeks caps how far ahead a rule may generate workouts.
+const MaxWeeks = 52
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Rule describes when a workout repeats. Exactly one of Weekdays or
+// IntervalDays is set; the series runs for Weeks weeks from its start date.
+type Rule struct {
+	Weekdays     []time.Weekday
+	IntervalDays int
+	Weeks        int
+}
+
+// ParseWeekdays converts three-letter day names ("mon", "wed") to weekdays.
+func ParseWeekdays(names []string) ([]time.Weekday, error) {
+	seen := make(map[time.Weekday]bool)
+	var days []time.Weekday
+	for _, name := range names {
+		day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// Validate reports whether the rule can generate a schedule.
+func (r Rule) Validate() error {
+	if len(r.Weekdays) == 0 && r.IntervalDays <= 0 {
+		return errors.New("choose weekdays or a day interval to repeat on")
+	}
+	if len(r.Weekdays) > 0 && r.IntervalDays > 0 {
+		return errors.New("repeat on weekdays or a day interval, not both")
+	}
+	if r.IntervalDays > 7*MaxWeeks {
+		return fmt.Errorf("day interval must be at most %d", 7*MaxWeeks)
+	}
+	if r.Weeks < 1 || r.Weeks > MaxWeeks {
+		return fmt.Errorf("repeat for between 1 and %d weeks", MaxWeeks)
+	}
+	return nil
+}
+
+// Dates returns every date the rule falls on from start (inclusive) until
+// Weeks weeks after it, at midnight UTC.
+func (r Rule) Dates(start time.Time) []time.Time {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7*r.Weeks)
+
+	var dates []time.Time
+	if r.IntervalDays > 0 {
+		for d := start; d.Before(end); d = d.AddDate(0, 0, r.IntervalDays) {
+			dates = append(dates, d)
+		}
+		return dates
+	}
+
+	on := make(map[time.Weekday]bool)
+	for _, day := range r.Weekdays {
+		on[day] = true
+	}
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if on[d.Weekday()] {
+			dates = append(dates, d)
+		}
+	}
+	return dates
+}
+
+// String describes the rule, e.g. "Every Mon, Wed, Fri for 4 weeks".
+func (r Rule) String() string {
+	weeks := fmt.Sprintf("%d weeks", r.Weeks)
+	if r.Weeks == 1 {
+		weeks = "1 week"
+	}
+	if r.IntervalDays > 0 {
+		if r.IntervalDays == 1 {
+			return "Every day for " + weeks
+		}
+		return fmt.Sprintf("Every %d days for %s", r.IntervalDays, weeks)
+	}
+	days := make([]string, len(r.Weekdays))
+	for i, day := range r.Weekdays {
+		days[i] = day.String()[:3]
+	}
+	return fmt.Sprintf("Every %s for %s", strings.Join(days, ", "), weeks)
+}
@@ -0,0 +1,118 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, time.June, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseWeekdays(t *testing.T) {
+	tests := map[string]struct {
+		names   []string
+		want    []time.Weekday
+		wantErr bool
+	}{
+		"mon wed fri": {names: []string{"mon", "wed", "fri"}, want: []time.Weekday{time.Monday, time.Wednesday, time.Friday}},
+		"mixed case":  {names: []string{"Sat", " SUN "}, want: []time.Weekday{time.Saturday, time.Sunday}},
+		"duplicates":  {names: []string{"tue", "tue"}, want: []time.Weekday{time.Tuesday}},
+		"unknown day": {names: []string{"monday"}, wantErr: true},
+		"empty":       {names: nil, want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseWeekdays(tc.names)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		rule    Rule
+		wantErr bool
+	}{
+		"weekdays":          {rule: Rule{Weekdays: []time.Weekday{time.Monday}, Weeks: 4}, wantErr: false},
+		"interval":          {rule: Rule{IntervalDays: 3, Weeks: 4}, wantErr: false},
+		"nothing to repeat": {rule: Rule{Weeks: 4}, wantErr: true},
+		"both set":          {rule: Rule{Weekdays: []time.Weekday{time.Monday}, IntervalDays: 3, Weeks: 4}, wantErr: true},
+		"zero weeks":        {rule: Rule{IntervalDays: 3}, wantErr: true},
+		"too many weeks":    {rule: Rule{IntervalDays: 3, Weeks: MaxWeeks + 1}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.rule.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDates(t *testing.T) {
+	// June 2 2025 is a Monday
+	tests := map[string]struct {
+		rule  Rule
+		start time.Time
+		want  []time.Time
+	}{
+		"mon wed fri for one week": {
+			rule:  Rule{Weekdays: []time.Weekday{time.Monday, time.Wednesday, time.Friday}, Weeks: 1},
+			start: date(2),
+			want:  []time.Time{date(2), date(4), date(6)},
+		},
+		"start mid week": {
+			rule:  Rule{Weekdays: []time.Weekday{time.Monday, time.Friday}, Weeks: 1},
+			start: date(4),
+			want:  []time.Time{date(6), date(9)},
+		},
+		"every three days for one week": {
+			rule:  Rule{IntervalDays: 3, Weeks: 1},
+			start: date(2),
+			want:  []time.Time{date(2), date(5), date(8)},
+		},
+		"time of day is dropped": {
+			rule:  Rule{IntervalDays: 7, Weeks: 2},
+			start: date(2).Add(18 * time.Hour),
+			want:  []time.Time{date(2), date(9)},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.rule.Dates(tc.start)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := map[string]struct {
+		rule Rule
+		want string
+	}{
+		"weekdays": {rule: Rule{Weekdays: []time.Weekday{time.Monday, time.Wednesday}, Weeks: 4}, want: "Every Mon, Wed for 4 weeks"},
+		"interval": {rule: Rule{IntervalDays: 3, Weeks: 1}, want: "Every 3 days for 1 week"},
+		"daily":    {rule: Rule{IntervalDays: 1, Weeks: 2}, want: "Every day for 2 weeks"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.rule.String(); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -20,14 +20,23 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	// FIX: /login & /register not showing up in browser network dev tool
 	mux.HandleFunc("GET /login", s.handler.Login)
 	mux.Handle("POST /login", authLimit(http.HandlerFunc(s.handler.Login)))
+	mux.HandleFunc("GET /login/two-factor", s.handler.LoginTwoFactor)
+	mux.Handle("POST /login/two-factor", authLimit(http.HandlerFunc(s.handler.LoginTwoFactor)))
 	mux.HandleFunc("POST /logout", s.handler.Logout)
 	mux.HandleFunc("GET /register", s.handler.Register)
 	mux.Handle("POST /register", authLimit(http.HandlerFunc(s.handler.Register)))
 	mux.HandleFunc("POST /users/email", s.handler.CheckUserEmail)
+	mux.HandleFunc("GET /password/forgot", s.handler.ForgotPassword)
+	mux.Handle("POST /password/forgot", authLimit(http.HandlerFunc(s.handler.ForgotPassword)))
+	mux.HandleFunc("GET /password/reset", s.handler.ResetPassword)
+	mux.Handle("POST /password/reset", authLimit(http.HandlerFunc(s.handler.ResetPassword)))
+	mux.HandleFunc("GET /email/verify", s.handler.VerifyEmail)
+	mux.HandleFunc("GET /email/verification", s.handler.EmailVerification)
+	mux.Handle("POST /email/verification", authLimit(http.HandlerFunc(s.handler.EmailVerification)))
 
-	// Google OAuth
-	mux.HandleFunc("GET /auth/google/login", s.handler.GoogleLogin)
-	mux.Handle("GET /auth/google/callback", authLimit(http.HandlerFunc(s.handler.GoogleCallback)))
+	// OAuth and OpenID Connect sign in
+	mux.HandleFunc("GET /auth/{provider}/login", s.handler.OAuthLogin)
+	mux.Handle("GET /auth/{provider}/callback", authLimit(http.HandlerFunc(s.handler.OAuthCallback)))
 
 	// Error pages
 	mux.Handle("GET /unauthorized", http.HandlerFunc(handlers.GetUnauthorizedPage))
@@ -36,8 +45,11 @@ func (s *Server) registerRoutes(mux *http.ServeMux) {
 	s.registerWorkoutRoutes(mux)
 	s.registerExerciseRoutes(mux)
 	s.registerTemplateRoutes(mux)
+	s.registerProgramRoutes(mux)
 	s.registerMetricRoutes(mux)
 	s.registerGoalRoutes(mux)
+	s.registerSettingsRoutes(mux)
+	s.registerAdminRoutes(mux)
 	s.registerAPIRoutes(mux)
 }
 
@@ -53,13 +65,29 @@ func (s *Server) registerWorkoutRoutes(mux *http.ServeMux) {
 	mux.Handle("DELETE /workouts/{workoutID}/{workoutExerciseID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteExerciseFromWorkout)))
 
 	mux.Handle("PUT /workouts/{id}/sort", s.mw.Auth(http.HandlerFunc(s.handler.UpdateWorkoutExercisesSortOrder)))
+	mux.Handle("POST /workouts/{id}/repeat", s.mw.Auth(http.HandlerFunc(s.handler.RepeatUserWorkout)))
+	mux.Handle("POST /workouts/{id}/groups", s.mw.Auth(http.HandlerFunc(s.handler.CreateUserWorkoutGroup)))
+	mux.Handle("PUT /workouts/{id}/groups/{groupID}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateUserWorkoutGroup)))
+	mux.Handle("DELETE /workouts/{id}/groups/{groupID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteUserWorkoutGroup)))
+
+	mux.Handle("POST /workouts/{id}/start", s.mw.Auth(http.HandlerFunc(s.handler.StartUserWorkout)))
+	mux.Handle("GET /workouts/{id}/session", s.mw.Auth(http.HandlerFunc(s.handler.GetWorkoutSessionPage)))
+	mux.Handle("POST /workouts/{id}/session/{workoutExerciseID}/sets", s.mw.Auth(http.HandlerFunc(s.handler.LogUserWorkoutSet)))
+	mux.Handle("DELETE /workouts/{id}/session/{workoutExerciseID}/sets", s.mw.Auth(http.HandlerFunc(s.handler.UndoUserWorkoutSet)))
+	mux.Handle("POST /workouts/{id}/finish", s.mw.Auth(http.HandlerFunc(s.handler.FinishUserWorkout)))
 }
 
 func (s *Server) registerExerciseRoutes(mux *http.ServeMux) {
 	mux.Handle("GET /exercises/groups", s.mw.Auth(http.HandlerFunc(s.handler.GetExercisesPage)))
 	mux.Handle("GET /exercises/groups/{group}", s.mw.Auth(http.HandlerFunc(s.handler.GetMGExercisesPage)))
 	mux.Handle("GET /exercises/{id}", s.mw.Auth(http.HandlerFunc(s.handler.GetSpecificExercisePage)))
+	mux.Handle("GET /exercises/records/{id}", s.mw.Auth(http.HandlerFunc(s.handler.GetExerciseRecordsPage)))
 	mux.Handle("POST /exercises", s.mw.Auth(http.HandlerFunc(s.handler.GetExerciseByKeyword)))
+
+	mux.Handle("GET /exercises/custom", s.mw.Auth(http.HandlerFunc(s.handler.GetCustomExercisesPage)))
+	mux.Handle("POST /exercises/custom", s.mw.Auth(http.HandlerFunc(s.handler.CreateCustomExercise)))
+	mux.Handle("PUT /exercises/custom/{id}", s.mw.Auth(http.HandlerFunc(s.handler.EditCustomExercise)))
+	mux.Handle("DELETE /exercises/custom/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteCustomExercise)))
 }
 
 func (s *Server) registerTemplateRoutes(mux *http.ServeMux) {
@@ -69,8 +97,28 @@ func (s *Server) registerTemplateRoutes(mux *http.ServeMux) {
 	mux.Handle("POST /templates/{id}/apply", s.mw.Auth(http.HandlerFunc(s.handler.ApplyTemplate)))
 }
 
+func (s *Server) registerProgramRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /programs", s.mw.Auth(http.HandlerFunc(s.handler.GetProgramsPage)))
+	mux.Handle("POST /programs", s.mw.Auth(http.HandlerFunc(s.handler.CreateUserProgram)))
+	mux.Handle("GET /programs/{id}", s.mw.Auth(http.HandlerFunc(s.handler.GetProgramPage)))
+	mux.Handle("DELETE /programs/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteUserProgram)))
+	mux.Handle("POST /programs/{id}/enroll", s.mw.Auth(http.HandlerFunc(s.handler.EnrollInUserProgram)))
+
+	mux.Handle("POST /programs/{id}/weeks", s.mw.Auth(http.HandlerFunc(s.handler.AddUserProgramWeek)))
+	mux.Handle("DELETE /programs/{id}/weeks", s.mw.Auth(http.HandlerFunc(s.handler.RemoveUserProgramWeek)))
+	mux.Handle("PUT /programs/{id}/weeks/{week}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateUserProgramWeek)))
+
+	mux.Handle("POST /programs/{id}/days", s.mw.Auth(http.HandlerFunc(s.handler.CreateUserProgramDay)))
+	mux.Handle("DELETE /programs/{id}/days/{dayID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteUserProgramDay)))
+	mux.Handle("POST /programs/{id}/days/{dayID}/exercises", s.mw.Auth(http.HandlerFunc(s.handler.AddUserProgramDayExercise)))
+	mux.Handle("DELETE /programs/{id}/days/{dayID}/exercises/{exerciseID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteUserProgramDayExercise)))
+}
+
 func (s *Server) registerMetricRoutes(mux *http.ServeMux) {
 	mux.Handle("GET /metrics", s.mw.Auth(http.HandlerFunc(s.handler.GetAllMetrics)))
+	mux.Handle("POST /metrics/types", s.mw.Auth(http.HandlerFunc(s.handler.AddCustomMetric)))
+	mux.Handle("DELETE /metrics/types/{slug}", s.mw.Auth(http.HandlerFunc(s.handler.RemoveCustomMetric)))
+	mux.Handle("GET /metrics/{type}/trends", s.mw.Auth(http.HandlerFunc(s.handler.GetUserMetricTrends)))
 	mux.Handle("POST /metrics/{type}", s.mw.Auth(http.HandlerFunc(s.handler.LogMetrics)))
 	mux.Handle("PUT /metrics/{type}/{id}", s.mw.Auth(http.HandlerFunc(s.handler.EditMetrics)))
 	mux.Handle("DELETE /metrics/{type}/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteMetrics)))
@@ -81,6 +129,54 @@ func (s *Server) registerGoalRoutes(mux *http.ServeMux) {
 	mux.Handle("POST /goals", s.mw.Auth(http.HandlerFunc(s.handler.AddNewGoal)))
 	mux.Handle("PUT /goals/{id}", s.mw.Auth(http.HandlerFunc(s.handler.EditGoal)))
 	mux.Handle("DELETE /goals/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoal)))
+	mux.Handle("POST /goals/{id}/milestones", s.mw.Auth(http.HandlerFunc(s.handler.AddGoalMilestone)))
+	mux.Handle("PUT /goals/{id}/milestones/sort", s.mw.Auth(http.HandlerFunc(s.handler.SortGoalMilestones)))
+	mux.Handle("PUT /goals/{id}/milestones/{milestoneID}/completion", s.mw.Auth(http.HandlerFunc(s.handler.SetGoalMilestoneCompletion)))
+	mux.Handle("DELETE /goals/{id}/milestones/{milestoneID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoalMilestone)))
+	mux.Handle("POST /goals/{id}/checkins", s.mw.Auth(http.HandlerFunc(s.handler.AddGoalCheckin)))
+	mux.Handle("DELETE /goals/{id}/checkins/{checkinID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoalCheckin)))
+}
+
+func (s *Server) registerSettingsRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /settings", s.mw.Auth(http.HandlerFunc(s.handler.GetSettingsPage)))
+	mux.Handle("PUT /settings/progression", s.mw.Auth(http.HandlerFunc(s.handler.UpdateProgressionSettings)))
+	mux.Handle("PUT /settings/units", s.mw.Auth(http.HandlerFunc(s.handler.UpdateUnitSettings)))
+	mux.Handle("PUT /settings/email", s.mw.Auth(http.HandlerFunc(s.handler.ChangeEmail)))
+	mux.Handle("POST /settings/email/verification", s.mw.Auth(http.HandlerFunc(s.handler.ResendSettingsVerification)))
+	mux.Handle("POST /settings/two-factor", s.mw.Auth(http.HandlerFunc(s.handler.SetUpTwoFactor)))
+	mux.Handle("POST /settings/two-factor/confirm", s.mw.Auth(http.HandlerFunc(s.handler.ConfirmTwoFactor)))
+	mux.Handle("POST /settings/two-factor/recovery-codes", s.mw.Auth(http.HandlerFunc(s.handler.RegenerateRecoveryCodes)))
+	mux.Handle("DELETE /settings/two-factor", s.mw.Auth(http.HandlerFunc(s.handler.DisableTwoFactor)))
+	mux.Handle("PUT /settings/passkeys/{id}", s.mw.Auth(http.HandlerFunc(s.handler.RenamePasskey)))
+	mux.Handle("DELETE /settings/passkeys/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeletePasskey)))
+}
+
+func (s *Server) registerAdminRoutes(mux *http.ServeMux) {
+	admin := func(h http.HandlerFunc) http.Handler {
+		return s.mw.Auth(s.mw.Admin(h))
+	}
+
+	mux.Handle("GET /admin", admin(s.handler.GetAdminDashboard))
+	mux.Handle("GET /admin/users", admin(s.handler.GetAdminUsers))
+	mux.Handle("GET /admin/users/{id}", admin(s.handler.GetAdminUser))
+	mux.Handle("POST /admin/users/{id}/disable", admin(s.handler.DisableUserAccount))
+	mux.Handle("POST /admin/users/{id}/enable", admin(s.handler.EnableUserAccount))
+	mux.Handle("POST /admin/users/{id}/two-factor/reset", admin(s.handler.ResetUserTwoFactor))
+
+	mux.Handle("GET /admin/exercises", admin(s.handler.GetAdminExercises))
+	mux.Handle("POST /admin/exercises", admin(s.handler.CreateAdminExercise))
+	mux.Handle("GET /admin/exercises/{id}", admin(s.handler.GetAdminExercise))
+	mux.Handle("PUT /admin/exercises/{id}", admin(s.handler.UpdateAdminExercise))
+	mux.Handle("POST /admin/exercises/{id}/retire", admin(s.handler.RetireAdminExercise))
+	mux.Handle("POST /admin/exercises/{id}/restore", admin(s.handler.RestoreAdminExercise))
+	mux.Handle("POST /admin/exercises/{id}/merge", admin(s.handler.MergeAdminExercise))
+
+	mux.Handle("GET /api/v1/admin/exercises", admin(s.handler.GetCatalogExercisesJSON))
+	mux.Handle("POST /api/v1/admin/exercises", admin(s.handler.CreateCatalogExercise))
+	mux.Handle("PUT /api/v1/admin/exercises/{id}", admin(s.handler.UpdateCatalogExercise))
+	mux.Handle("POST /api/v1/admin/exercises/{id}/retire", admin(s.handler.RetireCatalogExercise))
+	mux.Handle("POST /api/v1/admin/exercises/{id}/restore", admin(s.handler.RestoreCatalogExercise))
+	mux.Handle("POST /api/v1/admin/exercises/{id}/merge", admin(s.handler.MergeCatalogExercise))
 }
 
 func (s *Server) registerAPIRoutes(mux *http.ServeMux) {
@@ -90,9 +186,32 @@ func (s *Server) registerAPIRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/v1/refresh", s.handler.RefreshToken)
 	mux.HandleFunc("POST /api/v1/revoke", s.handler.RevokeToken)
 
+	// Password resets, email verification, second factors and passkey
+	// logins: 10 requests per minute per IP
+	resetLimit := s.mw.RateLimit(10, time.Minute)
+	mux.Handle("POST /api/v1/password/forgot", resetLimit(http.HandlerFunc(s.handler.RequestPasswordReset)))
+	mux.Handle("POST /api/v1/password/reset", resetLimit(http.HandlerFunc(s.handler.ConfirmPasswordReset)))
+	mux.Handle("POST /api/v1/email/verification", resetLimit(http.HandlerFunc(s.handler.RequestEmailVerification)))
+	mux.Handle("POST /api/v1/email/verify", resetLimit(http.HandlerFunc(s.handler.ConfirmEmailVerification)))
+	mux.Handle("POST /api/v1/login/two-factor", resetLimit(http.HandlerFunc(s.handler.LoginUserTwoFactor)))
+	mux.Handle("POST /api/v1/passkeys/login/begin", resetLimit(http.HandlerFunc(s.handler.BeginPasskeyLogin)))
+	mux.Handle("POST /api/v1/passkeys/login/finish", resetLimit(http.HandlerFunc(s.handler.FinishPasskeyLogin)))
+
 	// Users
 	mux.Handle("PUT /api/v1/users", s.mw.Auth(http.HandlerFunc(s.handler.UpdateUser)))
 	mux.Handle("DELETE /api/v1/users", s.mw.Auth(http.HandlerFunc(s.handler.DeleteUser)))
+	mux.Handle("GET /api/v1/users/two-factor", s.mw.Auth(http.HandlerFunc(s.handler.GetTwoFactor)))
+	mux.Handle("POST /api/v1/users/two-factor", s.mw.Auth(http.HandlerFunc(s.handler.CreateTwoFactor)))
+	mux.Handle("POST /api/v1/users/two-factor/confirm", s.mw.Auth(http.HandlerFunc(s.handler.ConfirmTwoFactorJSON)))
+	mux.Handle("POST /api/v1/users/two-factor/recovery-codes", s.mw.Auth(http.HandlerFunc(s.handler.RegenerateRecoveryCodesJSON)))
+	mux.Handle("DELETE /api/v1/users/two-factor", s.mw.Auth(http.HandlerFunc(s.handler.DeleteTwoFactor)))
+
+	// Passkeys
+	mux.Handle("GET /api/v1/passkeys", s.mw.Auth(http.HandlerFunc(s.handler.GetPasskeys)))
+	mux.Handle("POST /api/v1/passkeys/register/begin", s.mw.Auth(http.HandlerFunc(s.handler.BeginPasskeyRegistration)))
+	mux.Handle("POST /api/v1/passkeys/register/finish", s.mw.Auth(http.HandlerFunc(s.handler.FinishPasskeyRegistration)))
+	mux.Handle("PUT /api/v1/passkeys/{id}", s.mw.Auth(http.HandlerFunc(s.handler.RenamePasskeyJSON)))
+	mux.Handle("DELETE /api/v1/passkeys/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeletePasskeyJSON)))
 
 	// Goals
 	mux.Handle("POST /api/v1/goals", s.mw.Auth(http.HandlerFunc(s.handler.CreateGoal)))
@@ -100,8 +219,20 @@ func (s *Server) registerAPIRoutes(mux *http.ServeMux) {
 	mux.Handle("PUT /api/v1/goals/{id}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateGoal)))
 	mux.Handle("DELETE /api/v1/goals/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoalJSON)))
 	mux.Handle("DELETE /api/v1/goals", s.mw.Auth(http.HandlerFunc(s.handler.DeleteAllUserGoals)))
+	mux.Handle("GET /api/v1/goals/{id}/milestones", s.mw.Auth(http.HandlerFunc(s.handler.GetGoalMilestones)))
+	mux.Handle("POST /api/v1/goals/{id}/milestones", s.mw.Auth(http.HandlerFunc(s.handler.CreateGoalMilestone)))
+	mux.Handle("PUT /api/v1/goals/{id}/milestones/order", s.mw.Auth(http.HandlerFunc(s.handler.ReorderGoalMilestones)))
+	mux.Handle("PUT /api/v1/goals/{id}/milestones/{milestoneID}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateGoalMilestone)))
+	mux.Handle("DELETE /api/v1/goals/{id}/milestones/{milestoneID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoalMilestoneJSON)))
+	mux.Handle("GET /api/v1/goals/{id}/checkins", s.mw.Auth(http.HandlerFunc(s.handler.GetGoalCheckins)))
+	mux.Handle("POST /api/v1/goals/{id}/checkins", s.mw.Auth(http.HandlerFunc(s.handler.CreateGoalCheckin)))
+	mux.Handle("DELETE /api/v1/goals/{id}/checkins/{checkinID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteGoalCheckinJSON)))
 
 	// Metrics
+	mux.Handle("GET /api/v1/metrics/types", s.mw.Auth(http.HandlerFunc(s.handler.GetMetricTypes)))
+	mux.Handle("POST /api/v1/metrics/types", s.mw.Auth(http.HandlerFunc(s.handler.CreateMetricType)))
+	mux.Handle("DELETE /api/v1/metrics/types/{slug}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteMetricType)))
+	mux.Handle("GET /api/v1/metrics/{type}/trends", s.mw.Auth(http.HandlerFunc(s.handler.GetMetricTrends)))
 	mux.Handle("POST /api/v1/metrics/{type}", s.mw.Auth(http.HandlerFunc(s.handler.AddMetric)))
 	mux.Handle("GET /api/v1/metrics", s.mw.Auth(http.HandlerFunc(s.handler.GetAllUserMetrics)))
 	mux.Handle("PUT /api/v1/metrics/{type}/{id}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateMetric)))
@@ -114,6 +245,36 @@ func (s *Server) registerAPIRoutes(mux *http.ServeMux) {
 	mux.Handle("PUT /api/v1/workouts/{id}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateWorkout)))
 	mux.Handle("DELETE /api/v1/workouts/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteWorkout)))
 	mux.Handle("DELETE /api/v1/workouts", s.mw.Auth(http.HandlerFunc(s.handler.DeleteAllUserWorkouts)))
+	mux.Handle("POST /api/v1/workouts/{id}/start", s.mw.Auth(http.HandlerFunc(s.handler.StartWorkout)))
+	mux.Handle("POST /api/v1/workouts/{id}/exercises/{workoutExerciseID}/sets", s.mw.Auth(http.HandlerFunc(s.handler.LogWorkoutSet)))
+	mux.Handle("DELETE /api/v1/workouts/{id}/exercises/{workoutExerciseID}/sets", s.mw.Auth(http.HandlerFunc(s.handler.UndoWorkoutSet)))
+	mux.Handle("PUT /api/v1/workouts/{id}/exercises/{workoutExerciseID}/details", s.mw.Auth(http.HandlerFunc(s.handler.UpdateSetDetails)))
+	mux.Handle("POST /api/v1/workouts/{id}/finish", s.mw.Auth(http.HandlerFunc(s.handler.FinishWorkout)))
+	mux.Handle("GET /api/v1/workouts/{id}/exercises", s.mw.Auth(http.HandlerFunc(s.handler.GetWorkoutExercises)))
+	mux.Handle("POST /api/v1/workouts/{id}/groups", s.mw.Auth(http.HandlerFunc(s.handler.CreateWorkoutGroup)))
+	mux.Handle("PUT /api/v1/workouts/{id}/groups/{groupID}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateWorkoutGroup)))
+	mux.Handle("DELETE /api/v1/workouts/{id}/groups/{groupID}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteWorkoutGroup)))
+
+	// Programs
+	mux.Handle("GET /api/v1/programs", s.mw.Auth(http.HandlerFunc(s.handler.GetPrograms)))
+	mux.Handle("POST /api/v1/programs", s.mw.Auth(http.HandlerFunc(s.handler.CreateProgram)))
+	mux.Handle("GET /api/v1/programs/{id}", s.mw.Auth(http.HandlerFunc(s.handler.GetProgram)))
+	mux.Handle("POST /api/v1/programs/{id}/enroll", s.mw.Auth(http.HandlerFunc(s.handler.EnrollInProgram)))
+
+	// Exercises
+	mux.Handle("GET /api/v1/exercises/custom", s.mw.Auth(http.HandlerFunc(s.handler.GetCustomExercisesJSON)))
+	mux.Handle("POST /api/v1/exercises/custom", s.mw.Auth(http.HandlerFunc(s.handler.CreateCustomExerciseJSON)))
+	mux.Handle("PUT /api/v1/exercises/custom/{id}", s.mw.Auth(http.HandlerFunc(s.handler.UpdateCustomExerciseJSON)))
+	mux.Handle("DELETE /api/v1/exercises/custom/{id}", s.mw.Auth(http.HandlerFunc(s.handler.DeleteCustomExerciseJSON)))
+	mux.Handle("GET /api/v1/exercises/{id}/records", s.mw.Auth(http.HandlerFunc(s.handler.GetExerciseRecords)))
+	mux.Handle("GET /api/v1/exercises/{id}/history", s.mw.Auth(http.HandlerFunc(s.handler.GetExerciseHistory)))
+	mux.Handle("GET /api/v1/exercises/{id}/suggestion", s.mw.Auth(http.HandlerFunc(s.handler.GetExerciseSuggestion)))
+
+	// Preferences
+	mux.Handle("GET /api/v1/preferences/progression", s.mw.Auth(http.HandlerFunc(s.handler.GetProgressionPolicy)))
+	mux.Handle("PUT /api/v1/preferences/progression", s.mw.Auth(http.HandlerFunc(s.handler.UpdateProgressionPolicy)))
+	mux.Handle("GET /api/v1/preferences/units", s.mw.Auth(http.HandlerFunc(s.handler.GetUnitPreference)))
+	mux.Handle("PUT /api/v1/preferences/units", s.mw.Auth(http.HandlerFunc(s.handler.UpdateUnitPreference)))
 
 	// Health
 	mux.HandleFunc("GET /api/v1/healthz", s.handler.Readiness)
@@ -0,0 +1,153 @@
+// Package session tracks sets logged during a live workout
+package session
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"time"
+)
+
+// DefaultRest is the rest between sets when the server does not configure one.
+const DefaultRest = 90 * time.Second
+
+var (
+	ErrSetOutOfOrder = errors.New("sets must be logged in order")
+	ErrNoSets        = errors.New("no sets have been logged")
+	ErrTimedSets     = errors.New("this exercise is logged by time")
+	ErrRepSets       = errors.New("this exercise is logged by reps and weight")
+)
+
+// ParseRest reads a rest duration in whole seconds. An empty value is
+// DefaultRest.
+func ParseRest(s string) (time.Duration, error) {
+	if s == "" {
+		return DefaultRest, nil
+	}
+	secs, err := strconv.Atoi(s)
+	if err != nil || secs < 0 || secs > 3600 {
+		return 0, errors.New("rest must be between 0 and 3600 seconds")
+	}
+	return time.Duration(secs) * time.Second, nil
+}
+
+// Sets is the completed sets of one exercise in a session. Timed sets are
+// logged by duration, with distance and heart rate alongside, instead of by
+// reps and weight.
+type Sets struct {
+	Reps    []int32
+	Weights []int32
+
+	Timed      bool
+	Durations  []int32
+	Distances  []int32
+	HeartRates []int32
+}
+
+// TimedSet is one logged set of a timed exercise. Distance and heart rate are
+// zero when they aren't tracked.
+type TimedSet struct {
+	Seconds   int32
+	Meters    int32
+	HeartRate int32
+}
+
+// Log records set index (zero-based) with the given reps and weight. The next
+// unlogged set is appended; an already logged set is corrected in place.
+func (s Sets) Log(index int, reps, weight int32) (Sets, error) {
+	if s.Timed {
+		return Sets{}, ErrTimedSets
+	}
+	if index < 0 || index > len(s.Reps) {
+		return Sets{}, ErrSetOutOfOrder
+	}
+	out := Sets{
+		Reps:    append([]int32(nil), s.Reps...),
+		Weights: make([]int32, len(s.Reps)),
+	}
+	copy(out.Weights, s.Weights)
+	if index == len(out.Reps) {
+		out.Reps = append(out.Reps, reps)
+		out.Weights = append(out.Weights, weight)
+		return out, nil
+	}
+	out.Reps[index] = reps
+	out.Weights[index] = weight
+	return out, nil
+}
+
+// LogTimed records set index (zero-based) of a timed exercise the same way
+// Log does for reps and weight.
+func (s Sets) LogTimed(index int, set TimedSet) (Sets, error) {
+	if !s.Timed {
+		return Sets{}, ErrRepSets
+	}
+	n := len(s.Durations)
+	if index < 0 || index > n {
+		return Sets{}, ErrSetOutOfOrder
+	}
+	out := Sets{
+		Reps:       resize(s.Reps, len(s.Reps)),
+		Weights:    resize(s.Weights, len(s.Weights)),
+		Timed:      true,
+		Durations:  resize(s.Durations, n),
+		Distances:  resize(s.Distances, n),
+		HeartRates: resize(s.HeartRates, n),
+	}
+	if index == n {
+		out.Durations = append(out.Durations, set.Seconds)
+		out.Distances = append(out.Distances, set.Meters)
+		out.HeartRates = append(out.HeartRates, set.HeartRate)
+		return out, nil
+	}
+	out.Durations[index] = set.Seconds
+	out.Distances[index] = set.Meters
+	out.HeartRates[index] = set.HeartRate
+	return out, nil
+}
+
+// resize copies values into a new slice of length n, padding with zeros. It's
+// never nil, as the completed set columns are NOT NULL.
+func resize(values []int32, n int) []int32 {
+	out := make([]int32, n)
+	copy(out, values)
+	return out
+}
+
+// Undo removes the most recently logged set.
+func (s Sets) Undo() (Sets, error) {
+	if s.Timed {
+		n := len(s.Durations) - 1
+		if n < 0 {
+			return Sets{}, ErrNoSets
+		}
+		return Sets{
+			Reps:       resize(s.Reps, len(s.Reps)),
+			Weights:    resize(s.Weights, len(s.Weights)),
+			Timed:      true,
+			Durations:  resize(s.Durations, n),
+			Distances:  resize(s.Distances, n),
+			HeartRates: resize(s.HeartRates, n),
+		}, nil
+	}
+	if len(s.Reps) == 0 {
+		return Sets{}, ErrNoSets
+	}
+	n := len(s.Reps) - 1
+	return Sets{Reps: resize(s.Reps, n), Weights: resize(s.Weights, n)}, nil
+}
+
+// Count returns the number of logged sets.
+func (s Sets) Count() int32 {
+	if s.Timed {
+		return int32(len(s.Durations))
+	}
+	return int32(len(s.Reps))
+}
+
+// Minutes returns the session length rounded to the nearest minute, and at
+// least one minute.
+func Minutes(start, end time.Time) int32 {
+	m := math.Round(end.Sub(start).Minutes())
+	return int32(max(m, 1))
+}
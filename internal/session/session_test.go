@@ -0,0 +1,218 @@
+package session
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRest(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		"empty":    {input: "", want: DefaultRest},
+		"seconds":  {input: "120", want: 2 * time.Minute},
+		"zero":     {input: "0", want: 0},
+		"negative": {input: "-5", wantErr: true},
+		"too long": {input: "7200", wantErr: true},
+		"garbage":  {input: "90s", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseRest(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	logged := Sets{Reps: []int32{8, 8}, Weights: []int32{100, 100}}
+
+	tests := map[string]struct {
+		sets    Sets
+		index   int
+		reps    int32
+		weight  int32
+		want    Sets
+		wantErr bool
+	}{
+		"first set": {
+			sets:  Sets{},
+			index: 0, reps: 10, weight: 50,
+			want: Sets{Reps: []int32{10}, Weights: []int32{50}},
+		},
+		"next set": {
+			sets:  logged,
+			index: 2, reps: 7, weight: 100,
+			want: Sets{Reps: []int32{8, 8, 7}, Weights: []int32{100, 100, 100}},
+		},
+		"correct logged set": {
+			sets:  logged,
+			index: 0, reps: 9, weight: 105,
+			want: Sets{Reps: []int32{9, 8}, Weights: []int32{105, 100}},
+		},
+		"short weights": {
+			sets:  Sets{Reps: []int32{5}},
+			index: 1, reps: 5, weight: 0,
+			want: Sets{Reps: []int32{5, 5}, Weights: []int32{0, 0}},
+		},
+		"skipped set": {sets: logged, index: 3, wantErr: true},
+		"negative":    {sets: logged, index: -1, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.sets.Log(tc.index, tc.reps, tc.weight)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if !reflect.DeepEqual(logged.Reps, []int32{8, 8}) {
+		t.Errorf("Log modified its receiver: %v", logged.Reps)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	got, err := Sets{Reps: []int32{8, 6}, Weights: []int32{100, 100}}.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Sets{Reps: []int32{8}, Weights: []int32{100}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// The columns are NOT NULL, so undoing the last set leaves empty slices
+	got, err = Sets{Reps: []int32{8}, Weights: []int32{100}}.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Reps == nil || got.Weights == nil || got.Count() != 0 {
+		t.Errorf("expected empty non-nil sets, got %#v", got)
+	}
+
+	if _, err := (Sets{}).Undo(); err == nil {
+		t.Error("expected error undoing with no sets")
+	}
+}
+
+func TestMinutes(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		end  time.Time
+		want int32
+	}{
+		"an hour":         {end: start.Add(time.Hour), want: 60},
+		"rounds up":       {end: start.Add(45*time.Minute + 40*time.Second), want: 46},
+		"rounds down":     {end: start.Add(45*time.Minute + 10*time.Second), want: 45},
+		"at least one":    {end: start.Add(10 * time.Second), want: 1},
+		"clock went back": {end: start.Add(-time.Minute), want: 1},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Minutes(start, tc.end); got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestLogTimed(t *testing.T) {
+	run := Sets{Timed: true, Durations: []int32{600}, Distances: []int32{2000}, HeartRates: []int32{150}}
+
+	tests := map[string]struct {
+		sets    Sets
+		index   int
+		set     TimedSet
+		want    Sets
+		wantErr bool
+	}{
+		"first set": {
+			sets:  Sets{Timed: true},
+			index: 0, set: TimedSet{Seconds: 60},
+			want: Sets{Reps: []int32{}, Weights: []int32{}, Timed: true, Durations: []int32{60}, Distances: []int32{0}, HeartRates: []int32{0}},
+		},
+		"next set": {
+			sets:  run,
+			index: 1, set: TimedSet{Seconds: 620, Meters: 2000, HeartRate: 155},
+			want: Sets{Reps: []int32{}, Weights: []int32{}, Timed: true, Durations: []int32{600, 620}, Distances: []int32{2000, 2000}, HeartRates: []int32{150, 155}},
+		},
+		"correct logged set": {
+			sets:  run,
+			index: 0, set: TimedSet{Seconds: 590, Meters: 2000},
+			want: Sets{Reps: []int32{}, Weights: []int32{}, Timed: true, Durations: []int32{590}, Distances: []int32{2000}, HeartRates: []int32{0}},
+		},
+		"short distances": {
+			sets:  Sets{Timed: true, Durations: []int32{30}},
+			index: 1, set: TimedSet{Seconds: 30},
+			want: Sets{Reps: []int32{}, Weights: []int32{}, Timed: true, Durations: []int32{30, 30}, Distances: []int32{0, 0}, HeartRates: []int32{0, 0}},
+		},
+		"skipped set": {sets: run, index: 2, wantErr: true},
+		"rep sets":    {sets: Sets{}, index: 0, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.sets.LogTimed(tc.index, tc.set)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if !tc.wantErr && !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := run.Log(1, 10, 0); err == nil {
+		t.Error("expected error logging reps for a timed exercise")
+	}
+}
+
+func TestUndoTimed(t *testing.T) {
+	got, err := Sets{Timed: true, Durations: []int32{60, 45}, Distances: []int32{0, 0}, HeartRates: []int32{0, 0}}.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Sets{Reps: []int32{}, Weights: []int32{}, Timed: true, Durations: []int32{60}, Distances: []int32{0}, HeartRates: []int32{0}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if got.Count() != 1 {
+		t.Errorf("expected 1 set, got %d", got.Count())
+	}
+
+	// Timed sets leave reps and weights empty, never nil, as they're NOT NULL
+	got, err = Sets{Timed: true, Durations: []int32{60}}.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Reps == nil || got.Weights == nil || got.Durations == nil {
+		t.Errorf("expected non-nil slices, got %#v", got)
+	}
+	logged, err := Sets{Timed: true}.LogTimed(0, TimedSet{Seconds: 60})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if logged.Reps == nil || logged.Weights == nil {
+		t.Errorf("expected non-nil reps and weights, got %#v", logged)
+	}
+
+	if _, err := (Sets{Timed: true}).Undo(); err == nil {
+		t.Error("expected error undoing with no sets")
+	}
+}
@@ -0,0 +1,208 @@
+// Package strength derives personal records from logged sets
+package strength
+
+import "sort"
+
+// Kind identifies which personal record a Record represents.
+type Kind string
+
+const (
+	HeaviestWeight Kind = "heaviest_weight"
+	RepsAtWeight   Kind = "reps_at_weight"
+	EstimatedOneRM Kind = "estimated_1rm"
+	SessionVolume  Kind = "session_volume"
+)
+
+// Label returns a human-readable name for the record kind.
+func (k Kind) Label() string {
+	switch k {
+	case HeaviestWeight:
+		return "Heaviest Weight"
+	case RepsAtWeight:
+		return "Most Reps"
+	case EstimatedOneRM:
+		return "Estimated 1RM"
+	case SessionVolume:
+		return "Session Volume"
+	}
+	return string(k)
+}
+
+// Set is a single completed set.
+type Set struct {
+	Reps   int32
+	Weight int32
+}
+
+// Sets pairs completed reps with their weights. Sets with no reps are
+// skipped and a missing weight is treated as bodyweight (0).
+func Sets(reps, weights []int32) []Set {
+	var sets []Set
+	for i, r := range reps {
+		if r <= 0 {
+			continue
+		}
+		var w int32
+		if i < len(weights) && weights[i] > 0 {
+			w = weights[i]
+		}
+		sets = append(sets, Set{Reps: r, Weight: w})
+	}
+	return sets
+}
+
+// Formula selects how a one-rep max is estimated from a submaximal set.
+type Formula string
+
+const (
+	Epley   Formula = "epley"
+	Brzycki Formula = "brzycki"
+)
+
+// ParseFormula returns the named formula, defaulting to Epley.
+func ParseFormula(s string) Formula {
+	if Formula(s) == Brzycki {
+		return Brzycki
+	}
+	return Epley
+}
+
+// OneRM estimates a one-rep max for weight lifted for reps. A single rep
+// returns the weight lifted. Brzycki is undefined past 36 reps, where it
+// returns 0.
+func (f Formula) OneRM(weight, reps int32) float64 {
+	if weight <= 0 || reps <= 0 {
+		return 0
+	}
+	if reps == 1 {
+		return float64(weight)
+	}
+	if f == Brzycki {
+		if reps >= 37 {
+			return 0
+		}
+		return float64(weight) * 36 / float64(37-reps)
+	}
+	return float64(weight) * (1 + float64(reps)/30)
+}
+
+// OneRM estimates a one-rep max using the Epley formula, which personal
+// records are tracked with.
+func OneRM(weight, reps int32) float64 {
+	return Epley.OneRM(weight, reps)
+}
+
+// BestOneRM returns the set with the highest estimated one-rep max and that
+// estimate.
+func BestOneRM(sets []Set, f Formula) (Set, float64) {
+	var best Set
+	var bestOneRM float64
+	for _, s := range sets {
+		if e := f.OneRM(s.Weight, s.Reps); e > bestOneRM {
+			best, bestOneRM = s, e
+		}
+	}
+	return best, bestOneRM
+}
+
+// Volume returns the total weight moved (reps x weight) across sets.
+func Volume(sets []Set) int64 {
+	var total int64
+	for _, s := range sets {
+		total += int64(s.Reps) * int64(s.Weight)
+	}
+	return total
+}
+
+// Record is a personal record set in a single session. Reps and Weight
+// describe the set that earned it; for SessionVolume they are zero.
+type Record struct {
+	Kind   Kind
+	Value  float64
+	Reps   int32
+	Weight int32
+}
+
+// Bests holds the best results previously achieved for an exercise.
+type Bests struct {
+	HeaviestWeight int32
+	EstimatedOneRM float64
+	SessionVolume  int64
+	// RepsAtWeight maps a weight to the most reps completed with it.
+	RepsAtWeight map[int32]int32
+}
+
+// Add folds a session's sets into the bests.
+func (b *Bests) Add(sets []Set) {
+	if b.RepsAtWeight == nil {
+		b.RepsAtWeight = make(map[int32]int32)
+	}
+	for _, s := range sets {
+		b.HeaviestWeight = max(b.HeaviestWeight, s.Weight)
+		b.EstimatedOneRM = max(b.EstimatedOneRM, OneRM(s.Weight, s.Reps))
+		b.RepsAtWeight[s.Weight] = max(b.RepsAtWeight[s.Weight], s.Reps)
+	}
+	b.SessionVolume = max(b.SessionVolume, Volume(sets))
+}
+
+// repsAtOrAbove returns the most reps previously completed at weight or
+// anything heavier.
+func (b Bests) repsAtOrAbove(weight int32) int32 {
+	var best int32
+	for w, r := range b.RepsAtWeight {
+		if w >= weight {
+			best = max(best, r)
+		}
+	}
+	return best
+}
+
+// Detect compares a session against prior bests and returns the records it
+// sets. A rep record only counts when no heavier weight, in this session or
+// before, was lifted for as many reps.
+func Detect(sets []Set, prev Bests) []Record {
+	if len(sets) == 0 {
+		return nil
+	}
+
+	var records []Record
+
+	heaviest := sets[0]
+	for _, s := range sets[1:] {
+		if s.Weight > heaviest.Weight || (s.Weight == heaviest.Weight && s.Reps > heaviest.Reps) {
+			heaviest = s
+		}
+	}
+	if heaviest.Weight > prev.HeaviestWeight {
+		records = append(records, Record{Kind: HeaviestWeight, Value: float64(heaviest.Weight), Reps: heaviest.Reps, Weight: heaviest.Weight})
+	}
+
+	repsByWeight := make(map[int32]int32)
+	for _, s := range sets {
+		repsByWeight[s.Weight] = max(repsByWeight[s.Weight], s.Reps)
+	}
+	weights := make([]int32, 0, len(repsByWeight))
+	for w := range repsByWeight {
+		weights = append(weights, w)
+	}
+	sort.Slice(weights, func(i, j int) bool { return weights[i] > weights[j] })
+	var heavierReps int32
+	for _, w := range weights {
+		reps := repsByWeight[w]
+		if reps > heavierReps && reps > prev.repsAtOrAbove(w) {
+			records = append(records, Record{Kind: RepsAtWeight, Value: float64(reps), Reps: reps, Weight: w})
+		}
+		heavierReps = max(heavierReps, reps)
+	}
+
+	best, bestOneRM := BestOneRM(sets, Epley)
+	if bestOneRM > prev.EstimatedOneRM {
+		records = append(records, Record{Kind: EstimatedOneRM, Value: bestOneRM, Reps: best.Reps, Weight: best.Weight})
+	}
+
+	if volume := Volume(sets); volume > 0 && volume > prev.SessionVolume {
+		records = append(records, Record{Kind: SessionVolume, Value: float64(volume)})
+	}
+
+	return records
+}
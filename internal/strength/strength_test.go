@@ -0,0 +1,160 @@
+package strength
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSets(t *testing.T) {
+	tests := map[string]struct {
+		reps    []int32
+		weights []int32
+		want    []Set
+	}{
+		"paired":            {reps: []int32{10, 8}, weights: []int32{135, 155}, want: []Set{{10, 135}, {8, 155}}},
+		"missing weight":    {reps: []int32{10, 8}, weights: []int32{135}, want: []Set{{10, 135}, {8, 0}}},
+		"skips zero reps":   {reps: []int32{10, 0, 6}, weights: []int32{135, 155, 165}, want: []Set{{10, 135}, {6, 165}}},
+		"negative weight":   {reps: []int32{5}, weights: []int32{-10}, want: []Set{{5, 0}}},
+		"no completed reps": {reps: []int32{}, weights: []int32{}, want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Sets(tc.reps, tc.weights)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOneRM(t *testing.T) {
+	tests := map[string]struct {
+		weight int32
+		reps   int32
+		want   float64
+	}{
+		"single rep": {weight: 225, reps: 1, want: 225},
+		"ten reps":   {weight: 150, reps: 10, want: 200},
+		"five reps":  {weight: 300, reps: 5, want: 350},
+		"bodyweight": {weight: 0, reps: 12, want: 0},
+		"no reps":    {weight: 135, reps: 0, want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := OneRM(tc.weight, tc.reps)
+			if math.Abs(got-tc.want) > 0.001 {
+				t.Errorf("expected %.3f, got %.3f", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormulaOneRM(t *testing.T) {
+	tests := map[string]struct {
+		formula Formula
+		weight  int32
+		reps    int32
+		want    float64
+	}{
+		"epley ten reps":       {formula: Epley, weight: 150, reps: 10, want: 200},
+		"brzycki ten reps":     {formula: Brzycki, weight: 135, reps: 10, want: 180},
+		"brzycki single rep":   {formula: Brzycki, weight: 225, reps: 1, want: 225},
+		"brzycki out of range": {formula: Brzycki, weight: 100, reps: 40, want: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.formula.OneRM(tc.weight, tc.reps)
+			if math.Abs(got-tc.want) > 0.001 {
+				t.Errorf("expected %.3f, got %.3f", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseFormula(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  Formula
+	}{
+		"epley":   {input: "epley", want: Epley},
+		"brzycki": {input: "brzycki", want: Brzycki},
+		"empty":   {input: "", want: Epley},
+		"unknown": {input: "lombardi", want: Epley},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ParseFormula(tc.input); got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDetect(t *testing.T) {
+	var history Bests
+	history.Add([]Set{{10, 135}, {8, 135}, {5, 155}})
+
+	tests := map[string]struct {
+		sets  []Set
+		prev  Bests
+		kinds []Kind
+	}{
+		"first session sets every record": {
+			sets:  []Set{{10, 135}, {5, 155}},
+			prev:  Bests{},
+			kinds: []Kind{HeaviestWeight, RepsAtWeight, RepsAtWeight, EstimatedOneRM, SessionVolume},
+		},
+		"repeat of history sets nothing": {
+			sets:  []Set{{10, 135}, {8, 135}, {5, 155}},
+			prev:  history,
+			kinds: nil,
+		},
+		"heavier single": {
+			sets:  []Set{{1, 185}},
+			prev:  history,
+			kinds: []Kind{HeaviestWeight, RepsAtWeight, EstimatedOneRM},
+		},
+		"more reps at known weight": {
+			sets:  []Set{{12, 135}},
+			prev:  history,
+			kinds: []Kind{RepsAtWeight, EstimatedOneRM},
+		},
+		"reps at lighter weight are not a record": {
+			sets:  []Set{{10, 95}},
+			prev:  history,
+			kinds: nil,
+		},
+		"higher volume only": {
+			sets:  []Set{{10, 135}, {10, 135}, {10, 135}},
+			prev:  history,
+			kinds: []Kind{SessionVolume},
+		},
+		"bodyweight reps": {
+			sets:  []Set{{15, 0}},
+			prev:  Bests{RepsAtWeight: map[int32]int32{0: 12}},
+			kinds: []Kind{RepsAtWeight},
+		},
+		"no sets": {
+			sets:  nil,
+			prev:  history,
+			kinds: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var kinds []Kind
+			for _, r := range Detect(tc.sets, tc.prev) {
+				kinds = append(kinds, r.Kind)
+			}
+			if !reflect.DeepEqual(kinds, tc.kinds) {
+				t.Errorf("expected %v, got %v", tc.kinds, kinds)
+			}
+		})
+	}
+}
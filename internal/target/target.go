@@ -0,0 +1,214 @@
+// Package target measures a goal's progress toward a numeric target, such as
+// a body weight at or below a value, a lift's estimated one-rep max at or above
+// one or a number of workouts a week
+package target
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kairos4213/fithub/internal/metrics"
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+// Kind is what a target measures.
+type Kind string
+
+const (
+	// Metric follows the latest measurement of a metric type.
+	Metric Kind = "metric"
+	// OneRepMax follows the best estimated one-rep max of an exercise.
+	OneRepMax Kind = "lift_1rm"
+	// WorkoutsPerWeek counts the workouts finished in the last Week.
+	WorkoutsPerWeek Kind = "workouts_per_week"
+)
+
+const (
+	// Week is the window WorkoutsPerWeek counts over.
+	Week = 7 * 24 * time.Hour
+	// maxWorkouts is the most workouts a week a target can ask for, three a
+	// day.
+	maxWorkouts = 21
+)
+
+// Kinds lists every kind in display order.
+var Kinds = []Kind{Metric, OneRepMax, WorkoutsPerWeek}
+
+// ParseKind validates a kind. An empty value is a goal without a target.
+func ParseKind(s string) (Kind, error) {
+	k := Kind(strings.TrimSpace(s))
+	if k == "" {
+		return "", nil
+	}
+	for _, known := range Kinds {
+		if k == known {
+			return k, nil
+		}
+	}
+	return "", errors.New("target must be a metric, a lift or workouts per week")
+}
+
+// Label names the kind for display.
+func (k Kind) Label() string {
+	switch k {
+	case Metric:
+		return "Body metric"
+	case OneRepMax:
+		return "Lift 1RM"
+	case WorkoutsPerWeek:
+		return "Workouts per week"
+	}
+	return string(k)
+}
+
+// Direction is which side of the target value meets it.
+type Direction string
+
+const (
+	AtMost  Direction = "at_most"
+	AtLeast Direction = "at_least"
+)
+
+// ParseDirection validates a direction. Only metric targets can be met from
+// above; lifts and workouts are always AtLeast.
+func ParseDirection(s string, k Kind) (Direction, error) {
+	if k != Metric {
+		return AtLeast, nil
+	}
+	switch d := Direction(strings.TrimSpace(s)); d {
+	case AtMost, AtLeast:
+		return d, nil
+	}
+	return "", errors.New("direction must be at most or at least")
+}
+
+// Symbol is the comparison shown between a target's name and value.
+func (d Direction) Symbol() string {
+	if d == AtMost {
+		return "≤"
+	}
+	return "≥"
+}
+
+// Met reports whether current satisfies the target.
+func (d Direction) Met(current, target float64) bool {
+	if d == AtMost {
+		return current <= target
+	}
+	return current >= target
+}
+
+// Progress is how far a goal has come from where it started toward its
+// target.
+type Progress struct {
+	Start   float64
+	Current float64
+	Target  float64
+	// Percent is between 0 and 100 and only reaches 100 when Met.
+	Percent int
+	Met     bool
+}
+
+// Measure computes progress from start toward target. Moving away from the
+// target, or starting on the far side of it and not yet back, counts as no
+// progress.
+func Measure(start, current, target float64, d Direction) Progress {
+	p := Progress{Start: start, Current: current, Target: target}
+	if d.Met(current, target) {
+		p.Met = true
+		p.Percent = 100
+		return p
+	}
+
+	gap := target - start
+	if gap == 0 || (d == AtMost) != (gap < 0) {
+		return p
+	}
+	pct := (current - start) / gap * 100
+	// Just short of the target still reads 99% rather than rounding to done
+	p.Percent = int(math.Min(math.Max(math.Floor(pct), 0), 99))
+	return p
+}
+
+// Goal is a goal's target, what it follows and the progress made toward it.
+// Lift values are in pounds and metric values as the metric type stores them.
+type Goal struct {
+	Kind      Kind
+	Direction Direction
+	// Metric is the type a Metric target follows.
+	Metric metrics.Type
+	// Exercise names the lift a OneRepMax target follows.
+	Exercise string
+	Progress Progress
+	// Measured is false until there's a value to measure progress from.
+	Measured bool
+}
+
+// Name is what the target follows, e.g. "Body Weight" or "Bench Press 1RM".
+func (g Goal) Name() string {
+	switch g.Kind {
+	case Metric:
+		return g.Metric.Name
+	case OneRepMax:
+		return g.Exercise + " 1RM"
+	}
+	return g.Kind.Label()
+}
+
+// Format renders one of the target's values in the user's unit without the
+// unit.
+func (g Goal) Format(v float64, system units.System) string {
+	switch g.Kind {
+	case Metric:
+		return g.Metric.FormatValue(v, system)
+	case OneRepMax:
+		return strconv.FormatFloat(math.Round(system.FromLbs(v)*10)/10, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'f', 0, 64)
+}
+
+// Show renders one of the target's values with its unit, e.g. "80.0 kg" or
+// "3 workouts".
+func (g Goal) Show(v float64, system units.System) string {
+	switch g.Kind {
+	case Metric:
+		return g.Metric.Show(strconv.FormatFloat(v, 'f', 2, 64), "", system)
+	case OneRepMax:
+		return g.Format(v, system) + " " + system.Unit()
+	}
+	if v == 1 {
+		return "1 workout"
+	}
+	return g.Format(v, system) + " workouts"
+}
+
+// Label describes the target, e.g. "Body Fat ≤ 15.0%".
+func (g Goal) Label(system units.System) string {
+	return fmt.Sprintf("%s %s %s", g.Name(), g.Direction.Symbol(), g.Show(g.Progress.Target, system))
+}
+
+// ParseValue reads a target value entered in the user's unit and returns it
+// as stored.
+func (g Goal) ParseValue(v string, system units.System) (string, error) {
+	v = strings.TrimSpace(v)
+	switch g.Kind {
+	case Metric:
+		return g.Metric.Parse(v, system)
+	case OneRepMax:
+		f, err := strconv.ParseFloat(v, 64)
+		lbs := system.ToLbs(f)
+		if err != nil || math.IsNaN(f) || lbs <= 0 || lbs > metrics.MaxValue {
+			return "", errors.New("target 1rm must be a positive number")
+		}
+		return strconv.FormatFloat(lbs, 'f', 2, 64), nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 || n > maxWorkouts {
+		return "", fmt.Errorf("workouts per week must be a whole number between 1 and %d", maxWorkouts)
+	}
+	return strconv.Itoa(n), nil
+}
@@ -0,0 +1,167 @@
+package target
+
+import (
+	"testing"
+
+	"github.com/kairos4213/fithub/internal/metrics"
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+func TestParseKind(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Kind
+		wantErr bool
+	}{
+		"empty":    {input: "", want: ""},
+		"metric":   {input: "metric", want: Metric},
+		"lift":     {input: " lift_1rm ", want: OneRepMax},
+		"workouts": {input: "workouts_per_week", want: WorkoutsPerWeek},
+		"unknown":  {input: "steps", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseKind(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		kind    Kind
+		want    Direction
+		wantErr bool
+	}{
+		"metric at most":    {input: "at_most", kind: Metric, want: AtMost},
+		"metric at least":   {input: "at_least", kind: Metric, want: AtLeast},
+		"metric missing":    {input: "", kind: Metric, wantErr: true},
+		"lift ignores":      {input: "at_most", kind: OneRepMax, want: AtLeast},
+		"workouts defaults": {input: "", kind: WorkoutsPerWeek, want: AtLeast},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDirection(tc.input, tc.kind)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	tests := map[string]struct {
+		start, current, target float64
+		dir                    Direction
+		wantPercent            int
+		wantMet                bool
+	}{
+		"losing halfway":       {start: 200, current: 190, target: 180, dir: AtMost, wantPercent: 50},
+		"losing reached":       {start: 200, current: 180, target: 180, dir: AtMost, wantPercent: 100, wantMet: true},
+		"losing past":          {start: 200, current: 175, target: 180, dir: AtMost, wantPercent: 100, wantMet: true},
+		"losing went up":       {start: 200, current: 205, target: 180, dir: AtMost, wantPercent: 0},
+		"started under target": {start: 170, current: 185, target: 180, dir: AtMost, wantPercent: 0},
+		"gaining quarter":      {start: 165, current: 170, target: 185, dir: AtLeast, wantPercent: 25},
+		"gaining almost":       {start: 0, current: 184.9, target: 185, dir: AtLeast, wantPercent: 99},
+		"workouts none":        {start: 0, current: 0, target: 3, dir: AtLeast, wantPercent: 0},
+		"workouts met":         {start: 0, current: 4, target: 3, dir: AtLeast, wantPercent: 100, wantMet: true},
+		"start at target":      {start: 180, current: 181, target: 180, dir: AtMost, wantPercent: 0},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Measure(tc.start, tc.current, tc.target, tc.dir)
+			if got.Percent != tc.wantPercent {
+				t.Errorf("expected %d%%, got %d%%", tc.wantPercent, got.Percent)
+			}
+			if got.Met != tc.wantMet {
+				t.Errorf("expected met %v, got %v", tc.wantMet, got.Met)
+			}
+		})
+	}
+}
+
+func TestGoalLabel(t *testing.T) {
+	bodyWeight := metrics.Type{Name: "Body Weight", Kind: metrics.Mass, Unit: "lbs", Decimals: 1}
+	bodyFat := metrics.Type{Name: "Body Fat", Kind: metrics.Percent, Unit: "%", Decimals: 1}
+
+	tests := map[string]struct {
+		goal   Goal
+		system units.System
+		want   string
+	}{
+		"body weight": {
+			goal:   Goal{Kind: Metric, Direction: AtMost, Metric: bodyWeight, Progress: Progress{Target: 180}},
+			system: units.Imperial,
+			want:   "Body Weight ≤ 180.00 lbs",
+		},
+		"body fat": {
+			goal:   Goal{Kind: Metric, Direction: AtMost, Metric: bodyFat, Progress: Progress{Target: 15}},
+			system: units.Metric,
+			want:   "Body Fat ≤ 15.0%",
+		},
+		"lift in kg": {
+			goal:   Goal{Kind: OneRepMax, Direction: AtLeast, Exercise: "Bench Press", Progress: Progress{Target: 220.46}},
+			system: units.Metric,
+			want:   "Bench Press 1RM ≥ 100 kg",
+		},
+		"workouts": {
+			goal:   Goal{Kind: WorkoutsPerWeek, Direction: AtLeast, Progress: Progress{Target: 3}},
+			system: units.Imperial,
+			want:   "Workouts per week ≥ 3 workouts",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.goal.Label(tc.system); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGoalParseValue(t *testing.T) {
+	bodyWeight := metrics.Type{Name: "Body Weight", Kind: metrics.Mass, Unit: "lbs", Decimals: 1, Max: metrics.MaxValue}
+
+	tests := map[string]struct {
+		goal    Goal
+		system  units.System
+		input   string
+		want    string
+		wantErr bool
+	}{
+		"metric":            {goal: Goal{Kind: Metric, Metric: bodyWeight}, system: units.Imperial, input: "180", want: "180"},
+		"metric in kg":      {goal: Goal{Kind: Metric, Metric: bodyWeight}, system: units.Metric, input: "80", want: "176.37"},
+		"metric not number": {goal: Goal{Kind: Metric, Metric: bodyWeight}, system: units.Imperial, input: "abc", wantErr: true},
+		"lift":              {goal: Goal{Kind: OneRepMax}, system: units.Imperial, input: " 185 ", want: "185.00"},
+		"lift in kg":        {goal: Goal{Kind: OneRepMax}, system: units.Metric, input: "100", want: "220.46"},
+		"lift zero":         {goal: Goal{Kind: OneRepMax}, system: units.Imperial, input: "0", wantErr: true},
+		"workouts":          {goal: Goal{Kind: WorkoutsPerWeek}, system: units.Imperial, input: "4", want: "4"},
+		"workouts fraction": {goal: Goal{Kind: WorkoutsPerWeek}, system: units.Imperial, input: "2.5", wantErr: true},
+		"workouts too many": {goal: Goal{Kind: WorkoutsPerWeek}, system: units.Imperial, input: "22", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.goal.ParseValue(tc.input, tc.system)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -2,8 +2,26 @@ package templates
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/kairos4213/fithub/internal/chart"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/effort"
+	"github.com/kairos4213/fithub/internal/goalstatus"
+	"github.com/kairos4213/fithub/internal/grouping"
+	"github.com/kairos4213/fithub/internal/metrics"
+	"github.com/kairos4213/fithub/internal/overload"
+	"github.com/kairos4213/fithub/internal/progression"
+	"github.com/kairos4213/fithub/internal/strength"
+	"github.com/kairos4213/fithub/internal/target"
+	"github.com/kairos4213/fithub/internal/tracking"
+	"github.com/kairos4213/fithub/internal/trend"
+	"github.com/kairos4213/fithub/internal/units"
 )
 
 // jsonVals safely marshals a map to a JSON string for use in hx-vals attributes.
@@ -23,3 +41,589 @@ func dateCompletedValue(workout database.Workout) string {
 	}
 	return ""
 }
+
+func recordLabel(pr database.PersonalRecord) string {
+	return strength.Kind(pr.RecordType).Label()
+}
+
+// recordDetail describes the set behind a record, e.g. "185 lbs x 3".
+func recordDetail(pr database.PersonalRecord, system units.System) string {
+	unit := system.Unit()
+	switch strength.Kind(pr.RecordType) {
+	case strength.EstimatedOneRM:
+		return fmt.Sprintf("%s %s (%s %s x %d)", system.Format(pr.Value, 1), unit, system.FormatWeight(pr.WeightLbs), unit, pr.Reps)
+	case strength.SessionVolume:
+		return fmt.Sprintf("%s %s", system.Format(pr.Value, 0), unit)
+	}
+	if pr.WeightLbs == 0 {
+		return fmt.Sprintf("%d reps at bodyweight", pr.Reps)
+	}
+	return fmt.Sprintf("%s %s x %d", system.FormatWeight(pr.WeightLbs), unit, pr.Reps)
+}
+
+// suggestionReason describes why an exercise's plan was suggested, in the
+// user's current unit system. It's empty when nothing was suggested.
+func suggestionReason(we database.WorkoutsExercise, system units.System) string {
+	if !we.Suggestion.Valid {
+		return ""
+	}
+	var basis overload.Basis
+	if err := json.Unmarshal(we.Suggestion.RawMessage, &basis); err != nil {
+		return ""
+	}
+	return basis.Reason(system)
+}
+
+// weightStep is the step of weight inputs. Metric lifters load half
+// kilograms, imperial lifters whole pounds.
+func weightStep(system units.System) string {
+	if system == units.Metric {
+		return "0.5"
+	}
+	return "1"
+}
+
+// currentRecords picks the standing record of each kind from a history
+// ordered newest first. Rep records are per weight, so the most recent one
+// is shown rather than the largest.
+func currentRecords(records []database.PersonalRecord) []database.PersonalRecord {
+	kinds := []strength.Kind{strength.HeaviestWeight, strength.EstimatedOneRM, strength.SessionVolume, strength.RepsAtWeight}
+	var current []database.PersonalRecord
+	for _, kind := range kinds {
+		var best *database.PersonalRecord
+		for i, pr := range records {
+			if strength.Kind(pr.RecordType) != kind {
+				continue
+			}
+			if best == nil || (kind != strength.RepsAtWeight && pr.Value > best.Value) {
+				best = &records[i]
+			}
+		}
+		if best != nil {
+			current = append(current, *best)
+		}
+	}
+	return current
+}
+
+// ruleSummary describes a week's progression, with added weight in the
+// user's unit, e.g. "+2.5 kg".
+func ruleSummary(rule progression.Rule, system units.System) string {
+	if rule.Kind == progression.AddWeight {
+		return fmt.Sprintf("+%s %s", system.FormatWeight(rule.Amount), system.Unit())
+	}
+	return rule.String()
+}
+
+// weekAmount prefills a week's progression amount, converting added weight
+// to the user's unit.
+func weekAmount(week database.ProgramWeek, system units.System) string {
+	if progression.Kind(week.Progression) == progression.AddWeight {
+		return system.FormatWeight(week.Amount)
+	}
+	return fmt.Sprintf("%d", week.Amount)
+}
+
+// setsSummary renders sets as "135x10, 135x8".
+func setsSummary(sets []strength.Set, system units.System) string {
+	parts := make([]string, len(sets))
+	for i, s := range sets {
+		parts[i] = fmt.Sprintf("%sx%d", system.FormatWeight(s.Weight), s.Reps)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sessionSetCount is the number of set rows shown in a live session: every
+// planned set, plus one more for an extra set once the plan is done.
+func sessionSetCount(we database.WorkoutsExercise) int {
+	return max(len(we.RepsPerSetPlanned), len(we.RepsPerSetCompleted)+1)
+}
+
+// sessionSetDefaults prefills set i of a live session from the plan, or from
+// the last logged set once the plan runs out.
+func sessionSetDefaults(we database.WorkoutsExercise, i int) (int32, int32) {
+	if i < len(we.RepsPerSetPlanned) {
+		var weight int32
+		if i < len(we.WeightsPlannedLbs) {
+			weight = we.WeightsPlannedLbs[i]
+		}
+		return we.RepsPerSetPlanned[i], weight
+	}
+	if n := len(we.RepsPerSetCompleted); n > 0 {
+		var weight int32
+		if n <= len(we.WeightsCompletedLbs) {
+			weight = we.WeightsCompletedLbs[n-1]
+		}
+		return we.RepsPerSetCompleted[n-1], weight
+	}
+	return 1, 0
+}
+
+// loggedSet describes completed set i, e.g. "8 x 135 lbs".
+func loggedSet(we database.WorkoutsExercise, i int, system units.System) string {
+	var weight int32
+	if i < len(we.WeightsCompletedLbs) {
+		weight = we.WeightsCompletedLbs[i]
+	}
+	if weight == 0 {
+		return fmt.Sprintf("%d reps", we.RepsPerSetCompleted[i])
+	}
+	return fmt.Sprintf("%d x %s %s", we.RepsPerSetCompleted[i], system.FormatWeight(weight), system.Unit())
+}
+
+// trackingType is how a workout exercise's sets are measured.
+func trackingType(row database.WorkoutAndExercisesRow) tracking.Type {
+	return tracking.Type(row.Exercise.TrackingType)
+}
+
+// completedSetCount is the number of logged sets of a workout exercise.
+func completedSetCount(row database.WorkoutAndExercisesRow) int {
+	if trackingType(row).Timed() {
+		return len(row.WorkoutsExercise.DurationsCompletedSeconds)
+	}
+	return len(row.WorkoutsExercise.RepsPerSetCompleted)
+}
+
+// timedInput is a timed exercise's sets as edited in the workout exercise
+// form. Unset values are blank rather than zero.
+type timedInput struct {
+	PlannedDurations    []string `json:"plannedDurations"`
+	PlannedDistances    []string `json:"plannedDistances"`
+	CompletedDurations  []string `json:"completedDurations"`
+	CompletedDistances  []string `json:"completedDistances"`
+	CompletedHeartRates []string `json:"completedHeartRates"`
+}
+
+// timedInputs marshals a timed exercise's planned and completed sets for the
+// edit form.
+func timedInputs(we database.WorkoutsExercise, system units.System) string {
+	blank := func(v int32, format func(int32) string) string {
+		if v == 0 {
+			return ""
+		}
+		return format(v)
+	}
+	bpm := func(v int32) string { return strconv.Itoa(int(v)) }
+
+	in := timedInput{
+		PlannedDurations:    make([]string, len(we.DurationsPlannedSeconds)),
+		PlannedDistances:    make([]string, len(we.DurationsPlannedSeconds)),
+		CompletedDurations:  make([]string, len(we.DurationsCompletedSeconds)),
+		CompletedDistances:  make([]string, len(we.DurationsCompletedSeconds)),
+		CompletedHeartRates: make([]string, len(we.DurationsCompletedSeconds)),
+	}
+	for i, d := range we.DurationsPlannedSeconds {
+		in.PlannedDurations[i] = blank(d, tracking.FormatDuration)
+		in.PlannedDistances[i] = blank(tracking.At(we.DistancesPlannedMeters, i), system.FormatDistance)
+	}
+	for i, d := range we.DurationsCompletedSeconds {
+		in.CompletedDurations[i] = blank(d, tracking.FormatDuration)
+		in.CompletedDistances[i] = blank(tracking.At(we.DistancesCompletedMeters, i), system.FormatDistance)
+		in.CompletedHeartRates[i] = blank(tracking.At(we.HeartRatesCompleted, i), bpm)
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// durationCell renders a set's duration, or a dash when none was set.
+func durationCell(seconds int32) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return tracking.FormatDuration(seconds)
+}
+
+// distanceCell renders a set's distance without its unit, or a dash when none
+// was set.
+func distanceCell(meters int32, system units.System) string {
+	if meters == 0 {
+		return "-"
+	}
+	return system.FormatDistance(meters)
+}
+
+// heartRateCell renders a set's average heart rate, or a dash when none was
+// recorded.
+func heartRateCell(bpm int32) string {
+	if bpm == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d bpm", bpm)
+}
+
+// paceCell renders the pace of a set, or a dash when it can't be worked out.
+func paceCell(seconds, meters int32, system units.System) string {
+	if pace := tracking.Pace(seconds, meters, system); pace != "" {
+		return pace
+	}
+	return "-"
+}
+
+// timedSessionSetCount is the number of timed set rows to show in a live
+// session: every planned set plus one more once the plan is done.
+func timedSessionSetCount(we database.WorkoutsExercise) int {
+	return max(len(we.DurationsPlannedSeconds), len(we.DurationsCompletedSeconds)+1)
+}
+
+// timedSetDefaults prefills timed set i of a live session from the plan, or
+// from the last logged set once the plan runs out.
+func timedSetDefaults(we database.WorkoutsExercise, i int) (int32, int32) {
+	if i < len(we.DurationsPlannedSeconds) {
+		return we.DurationsPlannedSeconds[i], tracking.At(we.DistancesPlannedMeters, i)
+	}
+	if n := len(we.DurationsCompletedSeconds); n > 0 {
+		return we.DurationsCompletedSeconds[n-1], tracking.At(we.DistancesCompletedMeters, n-1)
+	}
+	return 0, 0
+}
+
+// durationValue prefills a duration input, leaving it blank when unset.
+func durationValue(seconds int32) string {
+	if seconds == 0 {
+		return ""
+	}
+	return tracking.FormatDuration(seconds)
+}
+
+// distanceValue prefills a distance input, leaving it blank when unset.
+func distanceValue(meters int32, system units.System) string {
+	if meters == 0 {
+		return ""
+	}
+	return system.FormatDistance(meters)
+}
+
+// timedSetSummary describes a timed set, e.g. "5 km in 25:00 (5:00 /km), 152
+// bpm" or "1:30".
+func timedSetSummary(seconds, meters, bpm int32, system units.System) string {
+	summary := tracking.FormatDuration(seconds)
+	if meters > 0 {
+		summary = fmt.Sprintf("%s %s in %s", system.FormatDistance(meters), system.DistanceUnit(), summary)
+		if pace := tracking.Pace(seconds, meters, system); pace != "" {
+			summary += " (" + pace + ")"
+		}
+	}
+	if bpm > 0 {
+		summary += fmt.Sprintf(", %d bpm", bpm)
+	}
+	return summary
+}
+
+// loggedTimedSet describes completed timed set i.
+func loggedTimedSet(we database.WorkoutsExercise, i int, system units.System) string {
+	return timedSetSummary(we.DurationsCompletedSeconds[i], tracking.At(we.DistancesCompletedMeters, i), tracking.At(we.HeartRatesCompleted, i), system)
+}
+
+// exerciseBlocks arranges a workout's exercises into ungrouped cards and
+// group blocks.
+func exerciseBlocks(rows []database.WorkoutAndExercisesRow) []grouping.Block[database.WorkoutAndExercisesRow] {
+	return grouping.Layout(rows, func(row database.WorkoutAndExercisesRow) uuid.NullUUID {
+		return row.WorkoutsExercise.GroupID
+	})
+}
+
+func findGroup(groups []database.WorkoutExerciseGroup, id uuid.NullUUID) (database.WorkoutExerciseGroup, bool) {
+	if !id.Valid {
+		return database.WorkoutExerciseGroup{}, false
+	}
+	for _, g := range groups {
+		if g.ID == id.UUID {
+			return g, true
+		}
+	}
+	return database.WorkoutExerciseGroup{}, false
+}
+
+// groupSummary describes a group's rounds and rest, e.g. "3 rounds, 90s rest".
+func groupSummary(g database.WorkoutExerciseGroup) string {
+	rounds := fmt.Sprintf("%d rounds", g.Rounds)
+	if g.Rounds == 1 {
+		rounds = "1 round"
+	}
+	if g.RestSeconds == 0 {
+		return rounds + ", no rest"
+	}
+	return fmt.Sprintf("%s, %ds rest", rounds, g.RestSeconds)
+}
+
+// groupable reports whether an exercise can be picked for group, i.e. it is
+// ungrouped or already one of group's exercises.
+func groupable(we database.WorkoutsExercise, group database.WorkoutExerciseGroup) bool {
+	return !we.GroupID.Valid || we.GroupID.UUID == group.ID
+}
+
+func hasEffort(efforts []effort.Set) bool {
+	for _, e := range efforts {
+		if !e.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// effortInput is one completed set's effort as edited in the workout
+// exercise form.
+type effortInput struct {
+	RPE   string `json:"rpe"`
+	RIR   string `json:"rir"`
+	Tempo string `json:"tempo"`
+	Notes string `json:"notes"`
+}
+
+// effortInputs marshals the effort of n completed sets for the edit form.
+func effortInputs(efforts []effort.Set, n int) string {
+	inputs := make([]effortInput, n)
+	for i := range inputs {
+		e := effort.At(efforts, i)
+		if e.RPE > 0 {
+			inputs[i].RPE = strconv.FormatFloat(e.RPE, 'f', -1, 64)
+		}
+		if e.RIR != effort.NoRIR {
+			inputs[i].RIR = strconv.Itoa(int(e.RIR))
+		}
+		inputs[i].Tempo = e.Tempo
+		inputs[i].Notes = e.Notes
+	}
+	b, err := json.Marshal(inputs)
+	if err != nil {
+		return "[]"
+	}
+	return string(b)
+}
+
+// metricsEmptyMessage is shown in place of a metric's entries until one is
+// logged.
+func metricsEmptyMessage(t metrics.Type) string {
+	return fmt.Sprintf("No %s entries yet.", strings.ToLower(t.Name))
+}
+
+// metricLabel capitalizes a metric value's name for a form label, e.g.
+// "Diastolic".
+func metricLabel(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// measurementStep is the input step matching the decimals a metric keeps.
+func measurementStep(t metrics.Type) string {
+	switch t.Decimals {
+	case 0:
+		return "1"
+	case 1:
+		return "0.1"
+	}
+	return "0.01"
+}
+
+// secondaryValue prefills the second input of a paired measurement.
+func secondaryValue(t metrics.Type, m database.Measurement, system units.System) string {
+	if !m.SecondaryValue.Valid {
+		return ""
+	}
+	return t.Format(m.SecondaryValue.String, system)
+}
+
+// isoTime renders a timestamp for the browser to show in its own time zone.
+func isoTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// weeklyChange shows how fast a metric's average is moving, e.g. "-0.5 kg".
+func weeklyChange(t metrics.Type, s trend.Summary, system units.System) string {
+	if !s.HasRate {
+		return "—"
+	}
+	return t.Show(t.FormatChange(s.WeeklyRate, system), "", system)
+}
+
+// rangeLabel names the window a trend range covers.
+func rangeLabel(r trend.Range) string {
+	if r.Days == 0 {
+		return "All time"
+	}
+	return fmt.Sprintf("Last %d days", r.Days)
+}
+
+// projectionSummary describes when the average will reach a target.
+func projectionSummary(t metrics.Type, p trend.Projection, system units.System) string {
+	target := t.Show(t.FormatValue(p.Target, system), "", system)
+	switch {
+	case p.Reached:
+		return fmt.Sprintf("You're at your target of %s.", target)
+	case p.Reachable:
+		return fmt.Sprintf("At your current rate you'll reach %s around %s.", target, p.At.Format("Jan 2, 2006"))
+	}
+	return fmt.Sprintf("Your trend isn't heading toward %s yet.", target)
+}
+
+// axisLabel formats a value on a chart's y axis.
+func axisLabel(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// metricChart plots a metric's entries with their moving average, in the
+// user's unit.
+func metricChart(t metrics.Type, measurements []database.Measurement, s trend.Summary, system units.System) chart.Chart {
+	entries := chart.Series{Name: "Entries", Markers: true}
+	for _, m := range measurements {
+		v, err := strconv.ParseFloat(m.Value, 64)
+		if err != nil {
+			continue
+		}
+		entries.Points = append(entries.Points, chart.Point{
+			At:    m.MeasuredAt,
+			Value: t.Display(v, system),
+			Label: fmt.Sprintf("%s: %s", m.MeasuredAt.Format("Jan 02 2006"), t.Show(m.Value, m.SecondaryValue.String, system)),
+		})
+	}
+	average := chart.Series{Name: "Moving average"}
+	for _, a := range s.Averages {
+		average.Points = append(average.Points, chart.Point{At: a.At, Value: t.Display(a.Value, system)})
+	}
+
+	spec := chart.Spec{
+		ID:     "metric-chart",
+		Title:  fmt.Sprintf("%s over time (%s)", t.Name, t.DisplayUnit(system)),
+		Format: axisLabel,
+	}
+	if n := len(entries.Points); n > 0 {
+		spec.Desc = fmt.Sprintf("%d entries from %s to %s.", n,
+			entries.Points[0].At.Format("Jan 02 2006"), entries.Points[n-1].At.Format("Jan 02 2006"))
+	}
+	return chart.NewLine(spec, entries, average)
+}
+
+// oneRMChart plots the estimated one-rep max of each session.
+func oneRMChart(sessions []ExerciseSession, system units.System) chart.Chart {
+	series := chart.Series{Name: "Estimated 1RM", Markers: true}
+	for _, s := range sessions {
+		series.Points = append(series.Points, chart.Point{
+			At:    s.Date,
+			Value: system.FromLbs(s.OneRM),
+			Label: fmt.Sprintf("%s: %s %s", s.Date.Format("Jan 02 2006"), system.Format(s.OneRM, 1), system.Unit()),
+		})
+	}
+	return chart.NewLine(chart.Spec{
+		ID:     "one-rm-chart",
+		Title:  fmt.Sprintf("Estimated one-rep max by session (%s)", system.Unit()),
+		Desc:   fmt.Sprintf("%d sessions.", len(sessions)),
+		Format: axisLabel,
+	}, series)
+}
+
+// volumeChart plots the volume lifted in each session.
+func volumeChart(sessions []ExerciseSession, system units.System) chart.Chart {
+	points := make([]chart.Point, len(sessions))
+	for i, s := range sessions {
+		points[i] = chart.Point{
+			At:    s.Date,
+			Value: system.FromLbs(float64(s.Volume)),
+			Label: fmt.Sprintf("%s: %s %s", s.Date.Format("Jan 02 2006"), system.Format(float64(s.Volume), 0), system.Unit()),
+		}
+	}
+	return chart.NewBar(chart.Spec{
+		ID:     "volume-chart",
+		Title:  fmt.Sprintf("Volume by session (%s)", system.Unit()),
+		Desc:   fmt.Sprintf("%d sessions.", len(sessions)),
+		Format: axisLabel,
+	}, points)
+}
+
+// goalsCompletedChart plots how many goals were completed each month.
+func goalsCompletedChart(goals []database.Goal) chart.Chart {
+	counts := map[time.Time]int{}
+	var months []time.Time
+	for _, g := range goals {
+		if !g.CompletionDate.Valid {
+			continue
+		}
+		d := g.CompletionDate.Time
+		month := time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if counts[month] == 0 {
+			months = append(months, month)
+		}
+		counts[month]++
+	}
+	slices.SortFunc(months, func(a, b time.Time) int { return a.Compare(b) })
+
+	points := make([]chart.Point, len(months))
+	for i, m := range months {
+		points[i] = chart.Point{
+			At:    m,
+			Value: float64(counts[m]),
+			Label: fmt.Sprintf("%s: %d completed", m.Format("Jan 2006"), counts[m]),
+		}
+	}
+	return chart.NewBar(chart.Spec{
+		ID:    "goals-chart",
+		Title: "Goals completed per month",
+		Desc:  fmt.Sprintf("%d months with completed goals.", len(months)),
+		Format: func(v float64) string {
+			// Only whole goals are labelled
+			if v != float64(int(v)) {
+				return ""
+			}
+			return strconv.Itoa(int(v))
+		},
+	}, points)
+}
+
+// goalOverdue reports whether an in-progress goal's date has passed.
+func goalOverdue(goal database.Goal) bool {
+	return goalstatus.Overdue(goalstatus.Status(goal.Status), goal.GoalDate, time.Now())
+}
+
+// goalsEmptyMessage is shown on a goals tab with nothing in it.
+func goalsEmptyMessage(view goalstatus.View) string {
+	switch view {
+	case goalstatus.OverdueView:
+		return "No overdue goals. Nice work!"
+	case goalstatus.View(goalstatus.Paused):
+		return "No paused goals."
+	case goalstatus.View(goalstatus.Completed):
+		return "No completed goals yet."
+	case goalstatus.View(goalstatus.Abandoned):
+		return "No abandoned goals."
+	}
+	return "No goals in progress. Create one above!"
+}
+
+// goalTargetStatus describes where the user stands on a goal's target, e.g.
+// "Now 184.20 lbs" or "2 workouts this week".
+func goalTargetStatus(t target.Goal, system units.System) string {
+	switch {
+	case t.Progress.Met:
+		return "Target met"
+	case !t.Measured:
+		return "Nothing logged yet"
+	case t.Kind == target.WorkoutsPerWeek:
+		return t.Show(t.Progress.Current, system) + " this week"
+	}
+	return "Now " + t.Show(t.Progress.Current, system)
+}
+
+// checkinsShown is how many of a goal's check-ins show before the rest are
+// asked for.
+const checkinsShown = 3
+
+// milestonesDone counts a goal's finished milestones, e.g. "2/5".
+func milestonesDone(milestones []database.GoalMilestone) string {
+	done := 0
+	for _, m := range milestones {
+		if m.CompletedAt.Valid {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d/%d", done, len(milestones))
+}
+
+// milestoneOverdue reports whether a milestone's target date passed without
+// it being finished.
+func milestoneOverdue(m database.GoalMilestone) bool {
+	return !m.CompletedAt.Valid && m.TargetDate.Before(time.Now().UTC().Truncate(24*time.Hour))
+}
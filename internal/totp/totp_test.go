@@ -0,0 +1,139 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The SHA1 secret from RFC 6238 appendix B, "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// Last six digits of the RFC 6238 test vectors
+	tests := map[string]struct {
+		unix int64
+		want string
+	}{
+		"59":          {unix: 59, want: "287082"},
+		"1111111109":  {unix: 1111111109, want: "081804"},
+		"1111111111":  {unix: 1111111111, want: "050471"},
+		"1234567890":  {unix: 1234567890, want: "005924"},
+		"2000000000":  {unix: 2000000000, want: "279037"},
+		"20000000000": {unix: 20000000000, want: "353130"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Code(rfcSecret, Step(time.Unix(tc.unix, 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(s int64) string {
+		c, err := Code(rfcSecret, s)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return c
+	}
+
+	tests := map[string]struct {
+		code     string
+		lastStep int64
+		wantStep int64
+		wantOK   bool
+	}{
+		"current":           {code: code(step), wantStep: step, wantOK: true},
+		"previous step":     {code: code(step - 1), wantStep: step - 1, wantOK: true},
+		"next step":         {code: code(step + 1), wantStep: step + 1, wantOK: true},
+		"spaced":            {code: code(step)[:3] + " " + code(step)[3:], wantStep: step, wantOK: true},
+		"too old":           {code: code(step - 2)},
+		"already used":      {code: code(step), lastStep: step},
+		"wrong":             {code: "000000"},
+		"wrong length":      {code: "12345"},
+		"used earlier step": {code: code(step + 1), lastStep: step, wantStep: step + 1, wantOK: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotStep, ok := Validate(rfcSecret, tc.code, now, tc.lastStep)
+			if ok != tc.wantOK {
+				t.Fatalf("expected ok %v, got %v", tc.wantOK, ok)
+			}
+			if gotStep != tc.wantStep {
+				t.Errorf("expected step %d, got %d", tc.wantStep, gotStep)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("expected a 32 character secret, got %q", secret)
+	}
+	if _, err := Code(secret, 1); err != nil {
+		t.Errorf("expected a usable secret, got %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	got := URI("FitHub", "jo@example.com", rfcSecret)
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Scheme != "otpauth" || u.Host != "totp" {
+		t.Errorf("expected an otpauth totp URI, got %s", got)
+	}
+	if u.Path != "/FitHub:jo@example.com" {
+		t.Errorf("expected the issuer and account label, got %s", u.Path)
+	}
+	if u.Query().Get("secret") != rfcSecret || u.Query().Get("issuer") != "FitHub" {
+		t.Errorf("expected secret and issuer params, got %s", u.RawQuery)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != RecoveryCodes {
+		t.Fatalf("expected %d codes, got %d", RecoveryCodes, len(codes))
+	}
+
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if len(c) != 9 || c[4] != '-' {
+			t.Errorf("expected a code like abcd-efgh, got %q", c)
+		}
+		if seen[c] {
+			t.Errorf("expected unique codes, got %q twice", c)
+		}
+		seen[c] = true
+	}
+
+	c := codes[0]
+	loose := strings.ToUpper(strings.ReplaceAll(c, "-", " "))
+	if HashRecoveryCode(loose) != HashRecoveryCode(c) {
+		t.Errorf("expected %q to hash the same as %q", loose, c)
+	}
+	if HashRecoveryCode(codes[1]) == HashRecoveryCode(c) {
+		t.Error("expected different codes to hash differently")
+	}
+}
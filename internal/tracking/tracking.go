@@ -0,0 +1,177 @@
+// Package tracking describes what is logged for each set of an exercise:
+// reps and weight for lifts, or time, distance and heart rate for cardio and
+// timed holds
+package tracking
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+// Type is how an exercise's sets are measured.
+type Type string
+
+const (
+	WeightReps    Type = "weight_reps"
+	Time          Type = "time"
+	DistanceTime  Type = "distance_time"
+	TimeHeartRate Type = "time_heart_rate"
+)
+
+// Types lists every tracking type in display order.
+var Types = []Type{WeightReps, Time, DistanceTime, TimeHeartRate}
+
+const (
+	// MaxDuration is the longest set that can be logged, 24 hours.
+	MaxDuration  = 24 * 60 * 60
+	minHeartRate = 30
+	maxHeartRate = 250
+)
+
+// ParseType validates a tracking type. An empty value is WeightReps, which is
+// how every exercise was tracked before the type existed.
+func ParseType(s string) (Type, error) {
+	t := Type(strings.TrimSpace(s))
+	if t == "" {
+		return WeightReps, nil
+	}
+	for _, known := range Types {
+		if t == known {
+			return t, nil
+		}
+	}
+	return "", fmt.Errorf("unknown tracking type %q", s)
+}
+
+// Label names the tracking type for forms and badges.
+func (t Type) Label() string {
+	switch t {
+	case Time:
+		return "Time"
+	case DistanceTime:
+		return "Distance & time"
+	case TimeHeartRate:
+		return "Time & heart rate"
+	}
+	return "Reps & weight"
+}
+
+// Timed reports whether sets are logged by duration instead of reps and
+// weight.
+func (t Type) Timed() bool {
+	return t == Time || t == DistanceTime || t == TimeHeartRate
+}
+
+// Distance reports whether sets record a distance.
+func (t Type) Distance() bool {
+	return t == DistanceTime
+}
+
+// HeartRate reports whether sets record an average heart rate.
+func (t Type) HeartRate() bool {
+	return t == TimeHeartRate
+}
+
+// FormatDuration renders seconds as "m:ss", or "h:mm:ss" from an hour up.
+func FormatDuration(seconds int32) string {
+	if seconds < 0 {
+		seconds = 0
+	}
+	h, m, s := seconds/3600, seconds%3600/60, seconds%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+// ParseDuration reads a duration as plain seconds ("90"), "m:ss" or
+// "h:mm:ss". Blank input is zero.
+func ParseDuration(v string) (int32, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	parts := strings.Split(v, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("duration %q must be seconds, m:ss or h:mm:ss", v)
+	}
+	total := 0
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("duration %q must be seconds, m:ss or h:mm:ss", v)
+		}
+		// Minutes and seconds after the first part are limited to a clock face
+		if i > 0 && n > 59 {
+			return 0, fmt.Errorf("duration %q must be seconds, m:ss or h:mm:ss", v)
+		}
+		total = total*60 + n
+	}
+	if total > MaxDuration {
+		return 0, errors.New("duration cannot be longer than 24 hours")
+	}
+	return int32(total), nil
+}
+
+// ParseDurations reads a list of durations.
+func ParseDurations(vs []string) ([]int32, error) {
+	out := make([]int32, len(vs))
+	for i, v := range vs {
+		d, err := ParseDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
+
+// ParseHeartRate reads an average heart rate in beats per minute. Blank input
+// is zero, meaning it wasn't recorded.
+func ParseHeartRate(v string) (int32, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < minHeartRate || n > maxHeartRate {
+		return 0, fmt.Errorf("heart rate must be between %d and %d bpm", minHeartRate, maxHeartRate)
+	}
+	return int32(n), nil
+}
+
+// ParseHeartRates reads a list of average heart rates.
+func ParseHeartRates(vs []string) ([]int32, error) {
+	out := make([]int32, len(vs))
+	for i, v := range vs {
+		hr, err := ParseHeartRate(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = hr
+	}
+	return out, nil
+}
+
+// Pace renders the time taken per kilometre or mile, e.g. "5:12 /km". It is
+// empty when either the time or the distance is missing.
+func Pace(seconds, meters int32, system units.System) string {
+	if seconds <= 0 || meters <= 0 {
+		return ""
+	}
+	perUnit := float64(seconds) / system.FromMeters(float64(meters))
+	return FormatDuration(int32(math.Round(perUnit))) + " /" + system.DistanceUnit()
+}
+
+// At returns the value for set i, or zero when the set has none.
+func At(values []int32, i int) int32 {
+	if i < 0 || i >= len(values) {
+		return 0
+	}
+	return values[i]
+}
@@ -0,0 +1,130 @@
+package tracking
+
+import (
+	"testing"
+
+	"github.com/kairos4213/fithub/internal/units"
+)
+
+func TestParseType(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Type
+		wantErr bool
+	}{
+		"empty":    {input: "", want: WeightReps},
+		"time":     {input: "time", want: Time},
+		"distance": {input: " distance_time ", want: DistanceTime},
+		"unknown":  {input: "laps", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseType(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    int32
+		wantErr bool
+	}{
+		"blank":         {input: "", want: 0},
+		"seconds":       {input: "90", want: 90},
+		"minutes":       {input: "1:30", want: 90},
+		"hours":         {input: "1:02:03", want: 3723},
+		"long minutes":  {input: "75:00", want: 4500},
+		"bad seconds":   {input: "1:75", wantErr: true},
+		"too many":      {input: "1:2:3:4", wantErr: true},
+		"negative":      {input: "-5", wantErr: true},
+		"not a number":  {input: "long", wantErr: true},
+		"over 24 hours": {input: "25:00:00", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseDuration(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[string]struct {
+		seconds int32
+		want    string
+	}{
+		"zero":    {seconds: 0, want: "0:00"},
+		"seconds": {seconds: 45, want: "0:45"},
+		"minutes": {seconds: 330, want: "5:30"},
+		"hours":   {seconds: 3723, want: "1:02:03"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := FormatDuration(tc.seconds); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseHeartRate(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    int32
+		wantErr bool
+	}{
+		"blank":    {input: "", want: 0},
+		"resting":  {input: "62", want: 62},
+		"too low":  {input: "10", wantErr: true},
+		"too high": {input: "300", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseHeartRate(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPace(t *testing.T) {
+	tests := map[string]struct {
+		seconds int32
+		meters  int32
+		system  units.System
+		want    string
+	}{
+		"5k":          {seconds: 1500, meters: 5000, system: units.Metric, want: "5:00 /km"},
+		"mile":        {seconds: 480, meters: 1609, system: units.Imperial, want: "8:00 /mi"},
+		"no distance": {seconds: 600, meters: 0, system: units.Metric, want: ""},
+		"no time":     {seconds: 0, meters: 1000, system: units.Metric, want: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := Pace(tc.seconds, tc.meters, tc.system); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
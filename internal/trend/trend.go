@@ -0,0 +1,187 @@
+// Package trend summarizes a series of measurements: a smoothed moving
+// average, the weekly rate of change, the range over recent windows and a
+// projection of when a target will be reached
+package trend
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	day  = 24 * time.Hour
+	week = 7 * day
+	// Smoothing is how much of the gap between the average and a new entry
+	// one day later the average closes, as in the Hacker's Diet weight trend.
+	Smoothing = 0.1
+	// RateWindow is how far back the weekly rate of change looks.
+	RateWindow = 4 * week
+	// maxProjection caps projections so a near-flat trend doesn't promise a
+	// date decades away.
+	maxProjection = 2 * 365 * day
+	// flatRate is the weekly rate below which a trend counts as flat, so the
+	// rounding noise of a constant series isn't projected.
+	flatRate = 1e-9
+)
+
+// Windows are the periods ranges are reported over, in days. Zero covers every
+// entry.
+var Windows = []int{7, 30, 90, 0}
+
+// Point is one measurement.
+type Point struct {
+	At    time.Time
+	Value float64
+}
+
+// Range is the lowest and highest value measured in a window.
+type Range struct {
+	Days  int
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// Projection estimates when the average will reach a target. Reachable is
+// false when the trend is flat or heading away from it.
+type Projection struct {
+	Target    float64
+	Reached   bool
+	Reachable bool
+	At        time.Time
+}
+
+// Summary is the trend of a series of measurements.
+type Summary struct {
+	Latest  float64
+	Average float64
+	// Averages is the moving average at each point, oldest first.
+	Averages []Point
+	// WeeklyRate is the average's change per week over RateWindow. HasRate is
+	// false without two entries a day apart in the window.
+	WeeklyRate float64
+	HasRate    bool
+	Ranges     []Range
+}
+
+// Summarize computes the trend of points, which needn't be sorted. now bounds
+// the windows. An empty series returns the zero Summary.
+func Summarize(points []Point, now time.Time) Summary {
+	if len(points) == 0 {
+		return Summary{}
+	}
+	points = sorted(points)
+
+	averages := Smooth(points)
+	s := Summary{
+		Latest:   points[len(points)-1].Value,
+		Average:  averages[len(averages)-1].Value,
+		Averages: averages,
+	}
+	s.WeeklyRate, s.HasRate = weeklyRate(averages, now)
+	for _, days := range Windows {
+		if r, ok := rangeOver(points, days, now); ok {
+			s.Ranges = append(s.Ranges, r)
+		}
+	}
+	return s
+}
+
+// Smooth returns the exponentially-weighted moving average at each of the
+// sorted points. Entries further apart move the average further, so a gap of
+// several days counts for as much as daily entries over the same span.
+func Smooth(points []Point) []Point {
+	out := make([]Point, len(points))
+	for i, p := range points {
+		if i == 0 {
+			out[i] = p
+			continue
+		}
+		days := p.At.Sub(points[i-1].At).Hours() / 24
+		weight := 1 - math.Pow(1-Smoothing, math.Max(days, 0))
+		prev := out[i-1].Value
+		out[i] = Point{At: p.At, Value: prev + weight*(p.Value-prev)}
+	}
+	return out
+}
+
+// Project estimates when the average will reach target at the weekly rate.
+func (s Summary) Project(target float64, now time.Time) Projection {
+	p := Projection{Target: target}
+	if len(s.Averages) == 0 {
+		return p
+	}
+	gap := target - s.Average
+	if gap == 0 || s.Latest == target {
+		p.Reached = true
+		return p
+	}
+	if !s.HasRate || math.Abs(s.WeeklyRate) < flatRate || math.Signbit(gap) != math.Signbit(s.WeeklyRate) {
+		return p
+	}
+
+	// Compared in weeks before converting, as a slow enough rate overflows a
+	// Duration
+	weeks := gap / s.WeeklyRate
+	if weeks > float64(maxProjection/week) {
+		return p
+	}
+	p.Reachable = true
+	p.At = now.Add(time.Duration(weeks * float64(week)))
+	return p
+}
+
+// weeklyRate is the least-squares slope of the averages within RateWindow of
+// now, per week.
+func weeklyRate(averages []Point, now time.Time) (float64, bool) {
+	since := now.Add(-RateWindow)
+	var n, sumX, sumY, sumXY, sumXX float64
+	var first, last time.Time
+	for _, p := range averages {
+		if p.At.Before(since) {
+			continue
+		}
+		if n == 0 {
+			first = p.At
+		}
+		last = p.At
+		x := p.At.Sub(since).Hours() / 24 / 7
+		n++
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumXX += x * x
+	}
+	// Entries spread over less than a day can't give a meaningful rate
+	if n < 2 || last.Sub(first) < day {
+		return 0, false
+	}
+	denom := n*sumXX - sumX*sumX
+	return (n*sumXY - sumX*sumY) / denom, true
+}
+
+// rangeOver finds the lowest and highest value within days of now.
+func rangeOver(points []Point, days int, now time.Time) (Range, bool) {
+	r := Range{Days: days}
+	since := now.Add(-time.Duration(days) * day)
+	for _, p := range points {
+		if days > 0 && p.At.Before(since) {
+			continue
+		}
+		if r.Count == 0 || p.Value < r.Min {
+			r.Min = p.Value
+		}
+		if r.Count == 0 || p.Value > r.Max {
+			r.Max = p.Value
+		}
+		r.Count++
+	}
+	return r, r.Count > 0
+}
+
+func sorted(points []Point) []Point {
+	out := append([]Point(nil), points...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
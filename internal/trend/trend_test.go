@@ -0,0 +1,173 @@
+package trend
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+// daily returns one point a day starting at start.
+func daily(values ...float64) []Point {
+	points := make([]Point, len(values))
+	for i, v := range values {
+		points[i] = Point{At: start.Add(time.Duration(i) * day), Value: v}
+	}
+	return points
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+func TestSmooth(t *testing.T) {
+	tests := map[string]struct {
+		points []Point
+		want   []float64
+	}{
+		"single":   {points: daily(180), want: []float64{180}},
+		"daily":    {points: daily(180, 190, 190), want: []float64{180, 181, 181.9}},
+		"gap":      {points: []Point{{At: start, Value: 180}, {At: start.Add(2 * day), Value: 190}}, want: []float64{180, 181.9}},
+		"same day": {points: []Point{{At: start, Value: 180}, {At: start, Value: 190}}, want: []float64{180, 180}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Smooth(tc.points)
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %d points, got %d", len(tc.want), len(got))
+			}
+			for i, p := range got {
+				if !near(p.Value, tc.want[i]) {
+					t.Errorf("point %d: expected %.2f, got %.2f", i, tc.want[i], p.Value)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	// Losing a pound a day for three weeks
+	losing := make([]float64, 21)
+	for i := range losing {
+		losing[i] = 200 - float64(i)
+	}
+
+	tests := map[string]struct {
+		points      []Point
+		now         time.Time
+		wantLatest  float64
+		wantHasRate bool
+		wantRate    float64
+		wantRanges  []Range
+	}{
+		"empty": {now: start},
+		"single": {
+			points:     daily(180),
+			now:        start,
+			wantLatest: 180,
+			wantRanges: []Range{{Days: 7, Min: 180, Max: 180, Count: 1}, {Days: 30, Min: 180, Max: 180, Count: 1}, {Days: 90, Min: 180, Max: 180, Count: 1}, {Days: 0, Min: 180, Max: 180, Count: 1}},
+		},
+		"flat": {
+			points:      daily(180, 180, 180),
+			now:         start.Add(2 * day),
+			wantLatest:  180,
+			wantHasRate: true,
+			wantRanges:  []Range{{Days: 7, Min: 180, Max: 180, Count: 3}, {Days: 30, Min: 180, Max: 180, Count: 3}, {Days: 90, Min: 180, Max: 180, Count: 3}, {Days: 0, Min: 180, Max: 180, Count: 3}},
+		},
+		"losing": {
+			points:      daily(losing...),
+			now:         start.Add(20 * day),
+			wantLatest:  180,
+			wantHasRate: true,
+			wantRate:    -4.39,
+			wantRanges:  []Range{{Days: 7, Min: 180, Max: 187, Count: 8}, {Days: 30, Min: 180, Max: 200, Count: 21}, {Days: 90, Min: 180, Max: 200, Count: 21}, {Days: 0, Min: 180, Max: 200, Count: 21}},
+		},
+		"stale": {
+			points:     daily(180, 181),
+			now:        start.Add(60 * day),
+			wantLatest: 181,
+			wantRanges: []Range{{Days: 90, Min: 180, Max: 181, Count: 2}, {Days: 0, Min: 180, Max: 181, Count: 2}},
+		},
+		"unsorted": {
+			points:      []Point{{At: start.Add(day), Value: 182}, {At: start, Value: 180}},
+			now:         start.Add(day),
+			wantLatest:  182,
+			wantHasRate: true,
+			wantRate:    1.4,
+			wantRanges:  []Range{{Days: 7, Min: 180, Max: 182, Count: 2}, {Days: 30, Min: 180, Max: 182, Count: 2}, {Days: 90, Min: 180, Max: 182, Count: 2}, {Days: 0, Min: 180, Max: 182, Count: 2}},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := Summarize(tc.points, tc.now)
+			if got.Latest != tc.wantLatest {
+				t.Errorf("expected latest %.2f, got %.2f", tc.wantLatest, got.Latest)
+			}
+			if got.HasRate != tc.wantHasRate {
+				t.Fatalf("expected has rate %v, got %v", tc.wantHasRate, got.HasRate)
+			}
+			if !near(got.WeeklyRate, tc.wantRate) {
+				t.Errorf("expected rate %.2f, got %.2f", tc.wantRate, got.WeeklyRate)
+			}
+			if len(got.Ranges) != len(tc.wantRanges) {
+				t.Fatalf("expected ranges %+v, got %+v", tc.wantRanges, got.Ranges)
+			}
+			for i, r := range got.Ranges {
+				if r != tc.wantRanges[i] {
+					t.Errorf("expected range %+v, got %+v", tc.wantRanges[i], r)
+				}
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	now := start.Add(7 * day)
+	losing := Summary{Latest: 181, Average: 182, Averages: daily(182), WeeklyRate: -1, HasRate: true}
+
+	tests := map[string]struct {
+		summary       Summary
+		target        float64
+		wantReached   bool
+		wantReachable bool
+		wantAt        time.Time
+	}{
+		"toward":     {summary: losing, target: 180, wantReachable: true, wantAt: now.Add(2 * week)},
+		"away":       {summary: losing, target: 190},
+		"reached":    {summary: losing, target: 181, wantReached: true},
+		"no rate":    {summary: Summary{Latest: 181, Average: 182, Averages: daily(182)}, target: 180},
+		"too far":    {summary: losing, target: 50},
+		"no points":  {summary: Summary{}, target: 180},
+		"noise rate": {summary: Summary{Latest: 180.3, Average: 180.3, Averages: daily(180.3), WeeklyRate: -1.2e-13, HasRate: true}, target: 175},
+		"slow rate":  {summary: Summary{Latest: 182, Average: 182, Averages: daily(182), WeeklyRate: -1e-6, HasRate: true}, target: 50},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := tc.summary.Project(tc.target, now)
+			if got.Reached != tc.wantReached || got.Reachable != tc.wantReachable {
+				t.Fatalf("expected reached %v reachable %v, got %+v", tc.wantReached, tc.wantReachable, got)
+			}
+			if !got.At.Equal(tc.wantAt) {
+				t.Errorf("expected %v, got %v", tc.wantAt, got.At)
+			}
+		})
+	}
+}
+
+func TestProjectConstantSeries(t *testing.T) {
+	values := make([]float64, 25)
+	for i := range values {
+		values[i] = 180.3
+	}
+	points := daily(values...)
+	now := points[len(points)-1].At
+
+	got := Summarize(points, now).Project(175, now)
+	if got.Reached || got.Reachable {
+		t.Errorf("expected a flat series to project nothing, got %+v", got)
+	}
+}
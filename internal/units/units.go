@@ -0,0 +1,282 @@
+// Package units converts weights between the pounds they're stored in and the
+// unit system a user has chosen
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// System is a user's preferred unit system. Weights are always stored in
+// pounds and only converted for display and input.
+type System string
+
+const (
+	Imperial System = "imperial"
+	Metric   System = "metric"
+)
+
+// Systems lists every unit system in display order.
+var Systems = []System{Imperial, Metric}
+
+const kgPerLb = 0.45359237
+
+// ParseSystem validates a unit system. An empty value is imperial, which is
+// what every user had before the preference existed.
+func ParseSystem(s string) (System, error) {
+	switch System(strings.TrimSpace(s)) {
+	case "", Imperial:
+		return Imperial, nil
+	case Metric:
+		return Metric, nil
+	}
+	return "", fmt.Errorf("unknown unit system %q", s)
+}
+
+// Label names the unit system for settings forms.
+func (s System) Label() string {
+	if s == Metric {
+		return "Metric (kg)"
+	}
+	return "Imperial (lbs)"
+}
+
+// Unit is the abbreviation weights are shown with, "lbs" or "kg".
+func (s System) Unit() string {
+	if s == Metric {
+		return "kg"
+	}
+	return "lbs"
+}
+
+// FromLbs converts pounds into the system's unit without rounding.
+func (s System) FromLbs(lbs float64) float64 {
+	if s == Metric {
+		return lbs * kgPerLb
+	}
+	return lbs
+}
+
+// ToLbs converts a weight in the system's unit into pounds without rounding.
+func (s System) ToLbs(v float64) float64 {
+	if s == Metric {
+		return v / kgPerLb
+	}
+	return v
+}
+
+// Weight converts a stored lifting weight for display. Kilograms are rounded
+// to the nearest half, which whole pounds are fine enough to round trip.
+func (s System) Weight(lbs int32) float64 {
+	if s == Metric {
+		return math.Round(s.FromLbs(float64(lbs))*2) / 2
+	}
+	return float64(lbs)
+}
+
+// Weights converts a list of stored lifting weights for display.
+func (s System) Weights(lbs []int32) []float64 {
+	out := make([]float64, len(lbs))
+	for i, w := range lbs {
+		out[i] = s.Weight(w)
+	}
+	return out
+}
+
+// FormatWeight renders a stored lifting weight without its unit, e.g. "135"
+// or "61.5".
+func (s System) FormatWeight(lbs int32) string {
+	return strconv.FormatFloat(s.Weight(lbs), 'f', -1, 64)
+}
+
+// Format renders a derived weight such as a volume or estimated 1RM in the
+// system's unit with the given number of decimals.
+func (s System) Format(lbs float64, decimals int) string {
+	return strconv.FormatFloat(s.FromLbs(lbs), 'f', decimals, 64)
+}
+
+// ParseWeight reads a lifting weight entered in the system's unit and returns
+// it in whole pounds. Blank input is zero.
+func (s System) ParseWeight(v string) (int32, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("weight %q is not a number", v)
+	}
+	if f < 0 {
+		return 0, errors.New("weight cannot be negative")
+	}
+	lbs := math.Round(s.ToLbs(f))
+	if lbs > math.MaxInt32 {
+		return 0, errors.New("weight is too large")
+	}
+	return int32(lbs), nil
+}
+
+// ParseWeights reads a list of lifting weights entered in the system's unit.
+func (s System) ParseWeights(vs []string) ([]int32, error) {
+	out := make([]int32, len(vs))
+	for i, v := range vs {
+		w, err := s.ParseWeight(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = w
+	}
+	return out, nil
+}
+
+// FormatMass renders a body mass measurement stored as a decimal string of
+// pounds. Anything that isn't a number is shown as is.
+func (s System) FormatMass(lbs string) string {
+	if s != Metric {
+		return lbs
+	}
+	f, err := strconv.ParseFloat(lbs, 64)
+	if err != nil {
+		return lbs
+	}
+	return strconv.FormatFloat(s.FromLbs(f), 'f', 1, 64)
+}
+
+// ParseMass converts a body mass entered in the system's unit into the
+// decimal string of pounds it's stored as.
+func (s System) ParseMass(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("measurement %q is not a number", v)
+	}
+	if s != Metric {
+		return v, nil
+	}
+	return strconv.FormatFloat(s.ToLbs(f), 'f', 2, 64), nil
+}
+
+const cmPerInch = 2.54
+
+// LengthUnit is the abbreviation body measurements such as a waist are shown
+// with, "in" or "cm".
+func (s System) LengthUnit() string {
+	if s == Metric {
+		return "cm"
+	}
+	return "in"
+}
+
+// FromInches converts inches into the system's length unit without rounding.
+func (s System) FromInches(inches float64) float64 {
+	if s == Metric {
+		return inches * cmPerInch
+	}
+	return inches
+}
+
+// FormatLength renders a body measurement stored as a decimal string of
+// inches. Anything that isn't a number is shown as is.
+func (s System) FormatLength(inches string) string {
+	if s != Metric {
+		return inches
+	}
+	f, err := strconv.ParseFloat(inches, 64)
+	if err != nil {
+		return inches
+	}
+	return strconv.FormatFloat(s.FromInches(f), 'f', 1, 64)
+}
+
+// ParseLength converts a body measurement entered in the system's unit into
+// the decimal string of inches it's stored as.
+func (s System) ParseLength(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("measurement %q is not a number", v)
+	}
+	if s != Metric {
+		return v, nil
+	}
+	return strconv.FormatFloat(f/cmPerInch, 'f', 2, 64), nil
+}
+
+const metersPerMile = 1609.344
+
+// DistanceUnit is the abbreviation distances are shown with, "mi" or "km".
+func (s System) DistanceUnit() string {
+	if s == Metric {
+		return "km"
+	}
+	return "mi"
+}
+
+// FromMeters converts meters into kilometres or miles without rounding.
+func (s System) FromMeters(m float64) float64 {
+	if s == Metric {
+		return m / 1000
+	}
+	return m / metersPerMile
+}
+
+// Distance converts a stored distance for display, rounded to two decimals.
+func (s System) Distance(meters int32) float64 {
+	return math.Round(s.FromMeters(float64(meters))*100) / 100
+}
+
+// Distances converts a list of stored distances for display.
+func (s System) Distances(meters []int32) []float64 {
+	out := make([]float64, len(meters))
+	for i, m := range meters {
+		out[i] = s.Distance(m)
+	}
+	return out
+}
+
+// FormatDistance renders a stored distance in kilometres or miles with up to
+// two decimals, e.g. "5" or "3.11".
+func (s System) FormatDistance(meters int32) string {
+	return strconv.FormatFloat(s.Distance(meters), 'f', -1, 64)
+}
+
+// ParseDistance reads a distance entered in kilometres or miles and returns
+// it in whole meters. Blank input is zero.
+func (s System) ParseDistance(v string) (int32, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("distance %q is not a number", v)
+	}
+	if f < 0 {
+		return 0, errors.New("distance cannot be negative")
+	}
+	perUnit := metersPerMile
+	if s == Metric {
+		perUnit = 1000
+	}
+	m := math.Round(f * perUnit)
+	if m > math.MaxInt32 {
+		return 0, errors.New("distance is too large")
+	}
+	return int32(m), nil
+}
+
+// ParseDistances reads a list of distances entered in kilometres or miles.
+func (s System) ParseDistances(vs []string) ([]int32, error) {
+	out := make([]int32, len(vs))
+	for i, v := range vs {
+		d, err := s.ParseDistance(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = d
+	}
+	return out, nil
+}
@@ -0,0 +1,197 @@
+package units
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestParseSystem(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    System
+		wantErr bool
+	}{
+		"empty":    {input: "", want: Imperial},
+		"imperial": {input: "imperial", want: Imperial},
+		"metric":   {input: " metric ", want: Metric},
+		"unknown":  {input: "stone", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseSystem(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatWeight(t *testing.T) {
+	tests := map[string]struct {
+		system System
+		lbs    int32
+		want   string
+	}{
+		"imperial":         {system: Imperial, lbs: 135, want: "135"},
+		"metric whole":     {system: Metric, lbs: 220, want: "100"},
+		"metric half":      {system: Metric, lbs: 135, want: "61"},
+		"metric rounds up": {system: Metric, lbs: 45, want: "20.5"},
+		"zero":             {system: Metric, lbs: 0, want: "0"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.system.FormatWeight(tc.lbs); got != tc.want {
+				t.Errorf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseWeight(t *testing.T) {
+	tests := map[string]struct {
+		system  System
+		input   string
+		want    int32
+		wantErr bool
+	}{
+		"blank":         {system: Imperial, input: "", want: 0},
+		"imperial":      {system: Imperial, input: "135", want: 135},
+		"imperial half": {system: Imperial, input: "2.5", want: 3},
+		"metric":        {system: Metric, input: "100", want: 220},
+		"metric half":   {system: Metric, input: "61.5", want: 136},
+		"negative":      {system: Metric, input: "-5", wantErr: true},
+		"not a number":  {system: Imperial, input: "heavy", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.system.ParseWeight(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+// Every half kilogram a lifter can enter must come back unchanged after being
+// stored in whole pounds.
+func TestWeightRoundTrip(t *testing.T) {
+	for half := 0; half <= 1000; half++ {
+		kg := float64(half) / 2
+		lbs, err := Metric.ParseWeight(strconv.FormatFloat(kg, 'f', 1, 64))
+		if err != nil {
+			t.Fatalf("%v kg: %v", kg, err)
+		}
+		if got := Metric.Weight(lbs); got != kg {
+			t.Fatalf("%v kg stored as %d lbs shows as %v kg", kg, lbs, got)
+		}
+	}
+}
+
+func TestMass(t *testing.T) {
+	tests := map[string]struct {
+		system    System
+		input     string
+		wantLbs   string
+		wantShown string
+		wantErr   bool
+	}{
+		"imperial":     {system: Imperial, input: "180.5", wantLbs: "180.5", wantShown: "180.5"},
+		"metric":       {system: Metric, input: "80", wantLbs: "176.37", wantShown: "80.0"},
+		"metric tenth": {system: Metric, input: "72.3", wantLbs: "159.39", wantShown: "72.3"},
+		"not a number": {system: Metric, input: "light", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			lbs, err := tc.system.ParseMass(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if lbs != tc.wantLbs {
+				t.Errorf("expected stored %q, got %q", tc.wantLbs, lbs)
+			}
+			if got := tc.system.FormatMass(lbs); got != tc.wantShown {
+				t.Errorf("expected shown %q, got %q", tc.wantShown, got)
+			}
+		})
+	}
+}
+
+func TestLength(t *testing.T) {
+	tests := map[string]struct {
+		system    System
+		input     string
+		wantIn    string
+		wantShown string
+		wantErr   bool
+	}{
+		"imperial":     {system: Imperial, input: "32.5", wantIn: "32.5", wantShown: "32.5"},
+		"metric":       {system: Metric, input: "81", wantIn: "31.89", wantShown: "81.0"},
+		"not a number": {system: Metric, input: "wide", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			inches, err := tc.system.ParseLength(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if inches != tc.wantIn {
+				t.Errorf("expected stored %q, got %q", tc.wantIn, inches)
+			}
+			if got := tc.system.FormatLength(inches); got != tc.wantShown {
+				t.Errorf("expected shown %q, got %q", tc.wantShown, got)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	tests := map[string]struct {
+		system     System
+		input      string
+		wantMeters int32
+		wantShown  string
+		wantErr    bool
+	}{
+		"metric":       {system: Metric, input: "5", wantMeters: 5000, wantShown: "5"},
+		"metric tenth": {system: Metric, input: "10.5", wantMeters: 10500, wantShown: "10.5"},
+		"imperial":     {system: Imperial, input: "3.1", wantMeters: 4989, wantShown: "3.1"},
+		"blank":        {system: Imperial, input: "", wantMeters: 0, wantShown: "0"},
+		"negative":     {system: Metric, input: "-1", wantErr: true},
+		"not a number": {system: Metric, input: "far", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			meters, err := tc.system.ParseDistance(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if meters != tc.wantMeters {
+				t.Errorf("expected %d m, got %d m", tc.wantMeters, meters)
+			}
+			if got := tc.system.FormatDistance(meters); got != tc.wantShown {
+				t.Errorf("expected shown %q, got %q", tc.wantShown, got)
+			}
+		})
+	}
+}
@@ -46,3 +46,15 @@ func SetRefreshCookie(w http.ResponseWriter, refreshToken string) {
 		MaxAge:   60 * 60 * 24 * 60, // 60 days
 	})
 }
+
+func SetChallengeCookie(w http.ResponseWriter, challenge string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "login_challenge",
+		Value:    challenge,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteDefaultMode,
+		MaxAge:   60 * 5, // 5 minutes
+	})
+}
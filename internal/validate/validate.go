@@ -3,6 +3,7 @@ package validate
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -71,6 +72,21 @@ func Numeric(value, field string) Check {
 	}
 }
 
+// URL checks that a non-empty string is an absolute http or https URL.
+// Empty values pass so optional fields can be validated unconditionally.
+func URL(value, field string) Check {
+	return func() *FieldError {
+		if value == "" {
+			return nil
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &FieldError{Field: field, Message: fmt.Sprintf("%s must be a valid URL", field)}
+		}
+		return nil
+	}
+}
+
 // TODO: BeforeToday()
 
 // BeforeToday checks if date is before today to prevent adding upcoming workouts w/past dates
@@ -109,6 +109,33 @@ func TestNumeric(t *testing.T) {
 	}
 }
 
+func TestURL(t *testing.T) {
+	tests := map[string]struct {
+		value   string
+		wantErr bool
+	}{
+		"https":         {value: "https://www.youtube.com/embed/abc123", wantErr: false},
+		"http":          {value: "http://example.com/video", wantErr: false},
+		"empty string":  {value: "", wantErr: false},
+		"no scheme":     {value: "www.youtube.com/embed/abc123", wantErr: true},
+		"javascript":    {value: "javascript:alert(1)", wantErr: true},
+		"missing host":  {value: "https://", wantErr: true},
+		"other scheme":  {value: "ftp://example.com/video", wantErr: true},
+		"not a url":     {value: "not a url", wantErr: true},
+		"relative path": {value: "/static/video.mp4", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			check := URL(tc.value, "video url")
+			err := check()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, got: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestFields(t *testing.T) {
 	t.Run("all valid", func(t *testing.T) {
 		errs := Fields(
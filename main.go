@@ -5,16 +5,26 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/joho/godotenv"
 	"github.com/kairos4213/fithub/internal/config"
 	"github.com/kairos4213/fithub/internal/database"
+	"github.com/kairos4213/fithub/internal/mail"
 	"github.com/kairos4213/fithub/internal/server"
+	"github.com/kairos4213/fithub/internal/session"
 	_ "github.com/lib/pq"
 )
 
+// oidcKey matches provider keys, which name them in /auth/{provider} routes
+var oidcKey = regexp.MustCompile(`^[a-z0-9-]+$`)
+
 func main() {
 	if err := godotenv.Load(".env"); err != nil {
 		log.Println("No .env file found, reading env vars from environment")
@@ -70,15 +80,108 @@ func main() {
 	googleClientSecret := os.Getenv("GOOGLE_CLIENT_SECRET")
 	if googleClientID != "" && googleClientSecret != "" {
 		oauthProviders["google"] = config.OAuthProvider{
+			Name:         "Google",
 			ClientID:     googleClientID,
 			ClientSecret: googleClientSecret,
 			RedirectURL:  baseURL + "/auth/google/callback",
+			Issuer:       "https://accounts.google.com",
 		}
 	} else {
 		log.Println("WARNING: GOOGLE_CLIENT_ID or GOOGLE_CLIENT_SECRET not set; Google OAuth disabled")
 	}
+	githubClientID := os.Getenv("GITHUB_CLIENT_ID")
+	githubClientSecret := os.Getenv("GITHUB_CLIENT_SECRET")
+	if githubClientID != "" && githubClientSecret != "" {
+		oauthProviders["github"] = config.OAuthProvider{
+			Name:         "GitHub",
+			ClientID:     githubClientID,
+			ClientSecret: githubClientSecret,
+			RedirectURL:  baseURL + "/auth/github/callback",
+		}
+	}
+	// Other OpenID Connect providers are listed in OIDC_PROVIDERS, each with
+	// its settings in OIDC_<KEY>_* vars
+	for key := range strings.SplitSeq(os.Getenv("OIDC_PROVIDERS"), ",") {
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			continue
+		}
+		if !oidcKey.MatchString(key) {
+			log.Fatalf("Invalid OIDC_PROVIDERS key %q: use lowercase letters, digits and dashes", key)
+		}
+		if _, exists := oauthProviders[key]; exists {
+			log.Fatalf("OIDC provider %q is configured twice", key)
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(key, "-", "_")) + "_"
+		provider := config.OAuthProvider{
+			Name:         os.Getenv(prefix + "NAME"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  baseURL + "/auth/" + key + "/callback",
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+		}
+		if provider.ClientID == "" || provider.ClientSecret == "" || provider.Issuer == "" {
+			log.Fatalf("%sISSUER, %sCLIENT_ID and %sCLIENT_SECRET must be set", prefix, prefix, prefix)
+		}
+		if provider.Name == "" {
+			provider.Name = key
+		}
+		oauthProviders[key] = provider
+	}
+
+	restTimer, err := session.ParseRest(os.Getenv("REST_TIMER_SECONDS"))
+	if err != nil {
+		log.Fatalf("Invalid REST_TIMER_SECONDS: %v", err)
+	}
+
+	mailFrom := os.Getenv("MAIL_FROM")
+	if mailFrom == "" {
+		mailFrom = "FitHub <noreply@localhost>"
+	}
+	var mailer mail.Mailer
+	if smtpHost := os.Getenv("SMTP_HOST"); smtpHost != "" {
+		smtpPort := os.Getenv("SMTP_PORT")
+		if smtpPort == "" {
+			smtpPort = "587"
+		}
+		mailer = mail.SMTP{
+			Host:     smtpHost,
+			Port:     smtpPort,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     mailFrom,
+		}
+	} else {
+		if env == "production" {
+			log.Fatal("SMTP_HOST must be set in production")
+		}
+		log.Println("WARNING: SMTP_HOST not set; mail goes to the outbox instead of being sent")
+		mailer = &mail.Outbox{Dir: os.Getenv("MAIL_OUTBOX_DIR"), From: mailFrom, Logger: logger}
+	}
+
+	requireVerifiedEmail := false
+	if v := os.Getenv("REQUIRE_EMAIL_VERIFICATION"); v != "" {
+		requireVerifiedEmail, err = strconv.ParseBool(v)
+		if err != nil {
+			log.Fatalf("Invalid REQUIRE_EMAIL_VERIFICATION: %v", err)
+		}
+	}
+
+	// Passkeys are bound to the host BASE_URL is served from
+	rpURL, err := url.Parse(baseURL)
+	if err != nil {
+		log.Fatalf("Invalid BASE_URL: %v", err)
+	}
+	webAuthn, err := webauthn.New(&webauthn.Config{
+		RPID:          rpURL.Hostname(),
+		RPDisplayName: "FitHub",
+		RPOrigins:     []string{rpURL.Scheme + "://" + rpURL.Host},
+	})
+	if err != nil {
+		log.Fatalf("Invalid passkey config: %v", err)
+	}
 
-	cfg := config.New(dbQueries, db, logger, tokenSecret, oauthProviders)
+	cfg := config.New(dbQueries, db, logger, tokenSecret, oauthProviders, restTimer, mailer, baseURL, requireVerifiedEmail, webAuthn)
 
 	srv := server.New(port, filePathRoot, cfg, db)
 	srv.Start()
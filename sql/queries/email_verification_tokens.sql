@@ -0,0 +1,25 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (token, user_id, email, created_at, expires_at)
+VALUES ($1, $2, $3, NOW(), $4);
+
+-- name: UseEmailVerificationToken :one
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING user_id, email;
+
+-- name: GetPendingEmail :one
+SELECT evt.email FROM email_verification_tokens AS evt
+INNER JOIN users AS u ON evt.user_id = u.id
+WHERE
+    evt.user_id = $1
+    AND evt.used_at IS NULL
+    AND evt.expires_at > NOW()
+    AND evt.email <> u.email
+ORDER BY evt.created_at DESC
+LIMIT 1;
+
+-- name: ExpireUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE user_id = $1 AND used_at IS NULL;
@@ -6,7 +6,9 @@ INSERT INTO exercises (
     name,
     description,
     primary_muscle_group,
-    secondary_muscle_group
+    secondary_muscle_group,
+    video_url,
+    tracking_type
 ) VALUES (
     gen_random_uuid(),
     now(),
@@ -14,7 +16,9 @@ INSERT INTO exercises (
     $1,
     $2,
     $3,
-    $4
+    $4,
+    $5,
+    $6
 ) RETURNING *;
 
 -- name: UpdateExercise :one
@@ -24,8 +28,10 @@ SET
     name = $1,
     description = $2,
     primary_muscle_group = $3,
-    secondary_muscle_group = $4
-WHERE id = $5
+    secondary_muscle_group = $4,
+    video_url = $5,
+    tracking_type = $6
+WHERE id = $7 AND user_id IS NULL
 RETURNING *;
 
 -- name: DeleteExercise :exec
@@ -37,29 +43,51 @@ SELECT * FROM exercises;
 
 -- name: GetExerciseByName :one
 SELECT * FROM exercises
-WHERE name = $1;
+WHERE
+    name = sqlc.arg(name)
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::uuid)
+ORDER BY user_id NULLS LAST
+LIMIT 1;
 
 -- name: GetExerciseByID :one
 SELECT * FROM exercises
 WHERE id = $1;
 
+-- name: GetAvailableExerciseByID :one
+SELECT * FROM exercises
+WHERE
+    id = sqlc.arg(id)
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::uuid);
+
 -- name: GetExerciseByKeyword :many
 SELECT * FROM exercises
 WHERE
     concat(name, ' ', primary_muscle_group, ' ', secondary_muscle_group)
-    ILIKE '%' || sqlc.arg(word)::text || '%';
+    ILIKE '%' || sqlc.arg(word)::text || '%'
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::uuid);
 
 -- name: GetExercisesByPrimaryMG :many
 SELECT * FROM exercises
-WHERE primary_muscle_group = $1;
+WHERE
+    primary_muscle_group = sqlc.arg(primary_muscle_group)
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::uuid)
+ORDER BY name;
 
 -- name: GetExercisesBySecondaryMG :many
 SELECT * FROM exercises
-WHERE secondary_muscle_group = $1;
+WHERE
+    secondary_muscle_group = sqlc.arg(secondary_muscle_group)
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::uuid)
+ORDER BY name;
 
 -- name: GetRandomExercisesByMuscleGroup :many
 SELECT * FROM exercises
-WHERE primary_muscle_group = $1
+WHERE primary_muscle_group = $1 AND retired_at IS NULL AND user_id IS NULL
 ORDER BY RANDOM()
 LIMIT $2;
 
@@ -67,22 +95,92 @@ LIMIT $2;
 SELECT * FROM exercises
 WHERE primary_muscle_group = $1
   AND id != ALL(@exclude_ids::uuid[])
+  AND retired_at IS NULL
+  AND user_id IS NULL
 ORDER BY RANDOM()
 LIMIT 1;
 
 -- name: GetMuscleGroupsWithCount :many
 SELECT primary_muscle_group, COUNT(*)::int AS exercise_count
 FROM exercises
-WHERE primary_muscle_group IS NOT NULL
+WHERE
+    primary_muscle_group IS NOT NULL
+    AND retired_at IS NULL
+    AND (user_id IS NULL OR user_id = $1)
 GROUP BY primary_muscle_group
 ORDER BY primary_muscle_group;
 
 -- name: GetAllMuscleGroups :many
 SELECT DISTINCT muscle_group
 FROM (
-    SELECT primary_muscle_group AS muscle_group FROM exercises
+    SELECT primary_muscle_group AS muscle_group FROM exercises WHERE user_id IS NULL
     UNION
-    SELECT secondary_muscle_group AS muscle_group FROM exercises
+    SELECT secondary_muscle_group AS muscle_group FROM exercises WHERE user_id IS NULL
 ) AS all_groups
 WHERE muscle_group IS NOT NULL
 ORDER BY muscle_group;
+
+-- name: GetCatalogExercises :many
+SELECT * FROM exercises
+WHERE
+    concat(name, ' ', primary_muscle_group, ' ', secondary_muscle_group)
+    ILIKE '%' || sqlc.arg(word)::text || '%'
+    AND user_id IS NULL
+ORDER BY retired_at IS NOT NULL, name;
+
+-- name: RetireExercise :one
+UPDATE exercises
+SET retired_at = now(), updated_at = now()
+WHERE id = $1 AND user_id IS NULL
+RETURNING *;
+
+-- name: RestoreExercise :one
+UPDATE exercises
+SET retired_at = NULL, updated_at = now()
+WHERE id = $1 AND user_id IS NULL
+RETURNING *;
+
+-- name: CreateCustomExercise :one
+INSERT INTO exercises (
+    id,
+    created_at,
+    updated_at,
+    name,
+    description,
+    primary_muscle_group,
+    secondary_muscle_group,
+    user_id,
+    tracking_type
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetUserCustomExercises :many
+SELECT * FROM exercises
+WHERE user_id = $1 AND retired_at IS NULL
+ORDER BY name;
+
+-- name: UpdateCustomExercise :one
+UPDATE exercises
+SET
+    updated_at = now(),
+    name = $1,
+    description = $2,
+    primary_muscle_group = $3,
+    secondary_muscle_group = $4,
+    tracking_type = $5
+WHERE id = $6 AND user_id = $7 AND retired_at IS NULL
+RETURNING *;
+
+-- name: RetireCustomExercise :exec
+UPDATE exercises
+SET retired_at = now(), updated_at = now()
+WHERE id = $1 AND user_id = $2;
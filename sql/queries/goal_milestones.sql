@@ -0,0 +1,103 @@
+-- name: CreateGoalMilestone :one
+INSERT INTO goal_milestones (
+    id,
+    goal_id,
+    user_id,
+    name,
+    target_date,
+    sort_order,
+    created_at,
+    updated_at
+)
+SELECT
+    gen_random_uuid(),
+    goals.id,
+    goals.user_id,
+    sqlc.arg(name)::TEXT,
+    sqlc.arg(target_date)::DATE,
+    (
+        SELECT coalesce(max(sort_order), 0) + 1 FROM goal_milestones
+        WHERE goal_id = sqlc.arg(goal_id)
+    ),
+    now(),
+    now()
+FROM goals
+WHERE goals.id = sqlc.arg(goal_id) AND goals.user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: GetGoalMilestones :many
+SELECT * FROM goal_milestones
+WHERE goal_id = $1 AND user_id = $2
+ORDER BY sort_order;
+
+-- name: GetUserGoalMilestones :many
+SELECT * FROM goal_milestones
+WHERE user_id = $1
+ORDER BY goal_id, sort_order;
+
+-- name: UpdateGoalMilestone :one
+UPDATE goal_milestones
+SET
+    updated_at = now(),
+    name = $1,
+    target_date = $2,
+    -- Keeps when an already finished milestone was finished
+    completed_at = CASE
+        WHEN sqlc.narg('completed_at')::TIMESTAMP IS NULL THEN NULL
+        ELSE coalesce(completed_at, sqlc.narg('completed_at'))
+    END
+WHERE id = $3 AND goal_id = $4 AND user_id = $5
+RETURNING *;
+
+-- name: SetGoalMilestoneCompleted :one
+UPDATE goal_milestones
+SET
+    updated_at = now(),
+    completed_at = $1
+WHERE id = $2 AND goal_id = $3 AND user_id = $4
+RETURNING *;
+
+-- name: UpdateGoalMilestoneSortOrder :exec
+UPDATE goal_milestones
+SET
+    updated_at = now(),
+    sort_order = $1
+WHERE id = $2 AND goal_id = $3 AND user_id = $4;
+
+-- name: DeleteGoalMilestone :exec
+DELETE FROM goal_milestones
+WHERE id = $1 AND goal_id = $2 AND user_id = $3;
+
+-- name: CreateGoalCheckin :one
+INSERT INTO goal_checkins (
+    id,
+    goal_id,
+    user_id,
+    checked_in_on,
+    note,
+    created_at
+)
+SELECT
+    gen_random_uuid(),
+    goals.id,
+    goals.user_id,
+    sqlc.arg(checked_in_on)::DATE,
+    sqlc.arg(note)::TEXT,
+    now()
+FROM goals
+WHERE goals.id = sqlc.arg(goal_id) AND goals.user_id = sqlc.arg(user_id)
+RETURNING *;
+
+-- name: GetGoalCheckins :many
+SELECT * FROM goal_checkins
+WHERE goal_id = $1 AND user_id = $2
+ORDER BY checked_in_on DESC, created_at DESC;
+
+-- name: GetUserGoalCheckins :many
+SELECT * FROM goal_checkins
+WHERE user_id = $1
+ORDER BY goal_id, checked_in_on DESC, created_at DESC;
+
+-- name: DeleteGoalCheckin :exec
+DELETE FROM goal_checkins
+WHERE id = $1 AND goal_id = $2 AND user_id = $3;
@@ -7,8 +7,29 @@ INSERT INTO goals (
     description,
     goal_date,
     notes,
-    user_id
-) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, $5)
+    user_id,
+    target_kind,
+    metric_type_id,
+    exercise_id,
+    target_direction,
+    target_value,
+    start_value
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11
+)
 RETURNING *;
 
 -- name: GetAllUserGoals :many
@@ -24,26 +45,31 @@ SET
     goal_date = $3,
     completion_date = $4,
     notes = $5,
-    status = $6
+    status = $6,
+    target_value = coalesce(sqlc.narg('target_value'), target_value),
+    -- A goal keeps when it was paused or abandoned until it leaves that status
+    paused_at = CASE
+        WHEN $6 <> 'paused' THEN NULL
+        WHEN status = 'paused' THEN paused_at
+        ELSE now()
+    END,
+    abandoned_at = CASE
+        WHEN $6 <> 'abandoned' THEN NULL
+        WHEN status = 'abandoned' THEN abandoned_at
+        ELSE now()
+    END,
+    status_reason = sqlc.narg('status_reason')
 WHERE id = $7 AND user_id = $8
 RETURNING *;
 
+-- name: GetGoal :one
+SELECT * FROM goals
+WHERE id = $1 AND user_id = $2;
+
 -- name: DeleteGoal :exec
 DELETE FROM goals
 WHERE id = $1 AND user_id = $2;
 
--- name: DeleteInProgressGoal :one
-WITH deleted AS (
-    DELETE FROM goals WHERE goals.id = $1 AND goals.user_id = $2 RETURNING goals.user_id
-)
-SELECT COUNT(*) FROM goals WHERE goals.user_id = $2 AND goals.status = 'in_progress';
-
--- name: DeleteCompletedGoal :one
-WITH deleted AS (
-    DELETE FROM goals WHERE goals.id = $1 AND goals.user_id = $2 RETURNING goals.user_id
-)
-SELECT COUNT(*) FROM goals WHERE goals.user_id = $2 AND goals.status = 'completed';
-
 -- name: DeleteAllUserGoals :exec
 DELETE FROM goals
 WHERE user_id = $1;
@@ -57,3 +83,41 @@ ORDER BY goal_date ASC;
 SELECT * FROM goals
 WHERE user_id = $1 AND status = 'completed'
 ORDER BY completion_date DESC;
+
+-- name: GetPausedGoals :many
+SELECT * FROM goals
+WHERE user_id = $1 AND status = 'paused'
+ORDER BY paused_at DESC;
+
+-- name: GetAbandonedGoals :many
+SELECT * FROM goals
+WHERE user_id = $1 AND status = 'abandoned'
+ORDER BY abandoned_at DESC;
+
+-- name: GetOverdueGoals :many
+SELECT * FROM goals
+WHERE user_id = $1 AND status = 'in_progress' AND goal_date < sqlc.arg(today)::DATE
+ORDER BY goal_date ASC;
+
+-- name: GetTrackedGoals :many
+SELECT * FROM goals
+WHERE user_id = $1 AND status = 'in_progress' AND target_kind IS NOT NULL
+ORDER BY goal_date ASC;
+
+-- name: SetGoalStartValue :exec
+UPDATE goals
+SET start_value = $1
+WHERE id = $2 AND user_id = $3 AND start_value IS NULL;
+
+-- name: CompleteGoal :exec
+UPDATE goals
+SET
+    updated_at = now(),
+    completion_date = $1,
+    status = 'completed'
+WHERE id = $2 AND user_id = $3 AND status = 'in_progress';
+
+-- name: ReassignGoalExercises :exec
+UPDATE goals
+SET exercise_id = sqlc.arg(target_id)
+WHERE exercise_id = sqlc.arg(source_id);
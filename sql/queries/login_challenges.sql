@@ -0,0 +1,20 @@
+-- name: CreateLoginChallenge :exec
+INSERT INTO login_challenges (token, user_id, created_at, expires_at)
+VALUES ($1, $2, NOW(), $3);
+
+-- name: ClaimLoginChallengeAttempt :one
+UPDATE login_challenges
+SET attempts = attempts + 1
+WHERE token = $1 AND used_at IS NULL AND expires_at > NOW() AND attempts < $2
+RETURNING user_id;
+
+-- name: UseLoginChallenge :one
+UPDATE login_challenges
+SET used_at = NOW()
+WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
+RETURNING user_id;
+
+-- name: ExpireUserLoginChallenges :exec
+UPDATE login_challenges
+SET used_at = NOW()
+WHERE user_id = $1 AND used_at IS NULL;
@@ -1,95 +1,84 @@
--- name: AddBodyWeight :one
-INSERT INTO body_weights (
-    id,
-    created_at,
-    updated_at,
-    user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
-RETURNING *;
+-- name: GetMetricTypes :many
+SELECT * FROM metric_types
+WHERE user_id IS NULL OR user_id = $1
+ORDER BY user_id NULLS FIRST, sort_order, name;
+
+-- name: GetMetricTypeBySlug :one
+SELECT * FROM metric_types
+WHERE slug = $1 AND (user_id IS NULL OR user_id = $2);
 
--- name: AddMuscleMass :one
-INSERT INTO muscle_masses (
+-- name: CreateMetricType :one
+INSERT INTO metric_types (
     id,
-    created_at,
-    updated_at,
     user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
+    slug,
+    name,
+    kind,
+    unit,
+    decimals,
+    min_value,
+    max_value,
+    created_at,
+    updated_at
+) VALUES (gen_random_uuid(), $1, $2, $3, 'custom', $4, $5, $6, $7, now(), now())
 RETURNING *;
 
--- name: AddBodyFatPerc :one
-INSERT INTO body_fat_percents (
+-- name: DeleteMetricType :exec
+DELETE FROM metric_types
+WHERE slug = $1 AND user_id = $2;
+
+-- name: AddMeasurement :one
+INSERT INTO measurements (
     id,
     created_at,
     updated_at,
     user_id,
-    measurement
-) VALUES (gen_random_uuid(), now(), now(), $1, $2)
+    metric_type_id,
+    value,
+    secondary_value,
+    measured_at
+) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, coalesce(sqlc.narg('measured_at'), now()))
 RETURNING *;
 
--- name: GetAllBodyWeights :many
-SELECT * FROM body_weights
-WHERE user_id = $1;
+-- name: GetMeasurements :many
+SELECT * FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2
+ORDER BY measured_at, created_at;
 
--- name: GetAllMuscleMasses :many
-SELECT * FROM muscle_masses
-WHERE user_id = $1;
+-- name: GetAllMeasurements :many
+SELECT * FROM measurements
+WHERE user_id = $1
+ORDER BY measured_at, created_at;
 
--- name: GetAllBodyFatPercs :many
-SELECT * FROM body_fat_percents
-WHERE user_id = $1;
+-- name: GetLatestMeasurementValue :one
+SELECT value FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2
+ORDER BY measured_at DESC, created_at DESC
+LIMIT 1;
 
--- name: UpdateBodyWeight :one
-UPDATE body_weights
-SET
-    measurement = $1,
-    updated_at = now()
-WHERE id = $2 AND user_id = $3
-RETURNING *;
+-- name: GetMetricTypeByID :one
+SELECT * FROM metric_types
+WHERE id = $1;
 
--- name: UpdateMuscleMass :one
-UPDATE muscle_masses
+-- name: UpdateMeasurement :one
+UPDATE measurements
 SET
-    measurement = $1,
+    value = $1,
+    secondary_value = $2,
+    measured_at = coalesce(sqlc.narg('measured_at'), measured_at),
     updated_at = now()
-WHERE id = $2 AND user_id = $3
+WHERE id = $3 AND metric_type_id = $4 AND user_id = $5
 RETURNING *;
 
--- name: UpdateBodyFatPerc :one
-UPDATE body_fat_percents
-SET
-    measurement = $1,
-    updated_at = now()
-WHERE id = $2 AND user_id = $3
-RETURNING *;
-
--- name: DeleteBodyWeight :one
-WITH deleted AS (
-    DELETE FROM body_weights WHERE body_weights.id = $1 AND body_weights.user_id = $2 RETURNING body_weights.user_id
-)
-SELECT COUNT(*) FROM body_weights WHERE body_weights.user_id = $2;
-
--- name: DeleteMuscleMass :one
-WITH deleted AS (
-    DELETE FROM muscle_masses WHERE muscle_masses.id = $1 AND muscle_masses.user_id = $2 RETURNING muscle_masses.user_id
-)
-SELECT COUNT(*) FROM muscle_masses WHERE muscle_masses.user_id = $2;
-
--- name: DeleteBodyFatPerc :one
+-- name: DeleteMeasurement :one
 WITH deleted AS (
-    DELETE FROM body_fat_percents WHERE body_fat_percents.id = $1 AND body_fat_percents.user_id = $2 RETURNING body_fat_percents.user_id
+    DELETE FROM measurements
+    WHERE measurements.id = $1 AND measurements.metric_type_id = $2 AND measurements.user_id = $3
+    RETURNING measurements.user_id
 )
-SELECT COUNT(*) FROM body_fat_percents WHERE body_fat_percents.user_id = $2;
-
--- name: DeleteAllBodyWeights :exec
-DELETE FROM body_weights
-WHERE user_id = $1;
-
--- name: DeleteAllMuscleMasses :exec
-DELETE FROM muscle_masses
-WHERE user_id = $1;
+SELECT COUNT(*) FROM measurements
+WHERE measurements.metric_type_id = $2 AND measurements.user_id = $3;
 
--- name: DeleteAllBodyFatPercs :exec
-DELETE FROM body_fat_percents
-WHERE user_id = $1;
+-- name: DeleteAllMeasurements :exec
+DELETE FROM measurements
+WHERE user_id = $1 AND metric_type_id = $2;
@@ -0,0 +1,28 @@
+-- name: CreatePasskey :one
+INSERT INTO passkeys (user_id, credential_id, name, credential, created_at, updated_at)
+VALUES ($1, $2, $3, $4, NOW(), NOW())
+RETURNING *;
+
+-- name: GetUserPasskeys :many
+SELECT * FROM passkeys
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: UsePasskey :exec
+UPDATE passkeys
+SET credential = $2, last_used_at = NOW(), updated_at = NOW()
+WHERE credential_id = $1;
+
+-- name: RenamePasskey :one
+UPDATE passkeys
+SET name = $3, updated_at = NOW()
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeletePasskey :exec
+DELETE FROM passkeys
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteUserPasskeys :exec
+DELETE FROM passkeys
+WHERE user_id = $1;
@@ -0,0 +1,56 @@
+-- name: CreatePersonalRecord :one
+INSERT INTO personal_records (
+    id,
+    user_id,
+    exercise_id,
+    workout_exercise_id,
+    record_type,
+    value,
+    reps,
+    weight_lbs,
+    achieved_at,
+    created_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    now()
+) RETURNING *;
+
+-- name: DeleteWorkoutExerciseRecords :exec
+DELETE FROM personal_records
+WHERE workout_exercise_id = $1;
+
+-- name: GetExerciseRecords :many
+SELECT * FROM personal_records
+WHERE user_id = $1 AND exercise_id = $2
+ORDER BY achieved_at DESC, created_at DESC;
+
+-- name: GetBestEstimatedOneRM :one
+SELECT coalesce(max(value), 0)::DOUBLE PRECISION AS best
+FROM personal_records
+WHERE user_id = $1 AND exercise_id = $2 AND record_type = 'estimated_1rm';
+
+-- name: GetRecordedLifts :many
+SELECT DISTINCT e.name FROM personal_records AS pr
+JOIN exercises AS e
+    ON pr.exercise_id = e.id
+WHERE pr.user_id = $1 AND pr.record_type = 'estimated_1rm'
+ORDER BY e.name;
+
+-- name: GetWorkoutRecords :many
+SELECT pr.* FROM personal_records AS pr
+JOIN workouts_exercises AS we
+    ON pr.workout_exercise_id = we.id
+WHERE we.workout_id = $1 AND pr.user_id = $2;
+
+-- name: ReassignPersonalRecords :exec
+UPDATE personal_records
+SET exercise_id = sqlc.arg(target_id)
+WHERE exercise_id = sqlc.arg(source_id);
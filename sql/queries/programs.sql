@@ -0,0 +1,157 @@
+-- name: CreateProgram :one
+INSERT INTO programs (
+    id,
+    user_id,
+    name,
+    description,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    now(),
+    now()
+) RETURNING *;
+
+-- name: GetUserPrograms :many
+SELECT * FROM programs
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: GetProgram :one
+SELECT * FROM programs
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteProgram :exec
+DELETE FROM programs
+WHERE id = $1 AND user_id = $2;
+
+-- name: CreateProgramWeek :one
+INSERT INTO program_weeks (
+    id,
+    program_id,
+    week_number,
+    progression,
+    amount
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4
+) RETURNING *;
+
+-- name: GetProgramWeeks :many
+SELECT * FROM program_weeks
+WHERE program_id = $1
+ORDER BY week_number;
+
+-- name: UpdateProgramWeek :one
+UPDATE program_weeks
+SET
+    progression = $1,
+    amount = $2
+WHERE program_id = $3 AND week_number = $4
+RETURNING *;
+
+-- name: DeleteProgramWeek :exec
+DELETE FROM program_weeks
+WHERE program_id = $1 AND week_number = $2;
+
+-- name: CreateProgramDay :one
+INSERT INTO program_days (
+    id,
+    program_id,
+    day_number,
+    title,
+    template_id,
+    duration_minutes,
+    created_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    now()
+) RETURNING *;
+
+-- name: GetProgramDays :many
+SELECT * FROM program_days
+WHERE program_id = $1
+ORDER BY day_number, created_at;
+
+-- name: DeleteProgramDay :exec
+DELETE FROM program_days
+WHERE id = $1 AND program_id = $2;
+
+-- name: AddProgramDayExercise :one
+INSERT INTO program_day_exercises (
+    id,
+    program_day_id,
+    exercise_id,
+    reps_per_set,
+    weights_lbs,
+    sort_order,
+    created_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    (
+        SELECT coalesce(max(sort_order), 0) + 1 FROM program_day_exercises
+        WHERE program_day_id = $1
+    ),
+    now()
+) RETURNING *;
+
+-- name: GetProgramDayExercises :many
+SELECT
+    pde.id,
+    pde.program_day_id,
+    pde.exercise_id,
+    pde.reps_per_set,
+    pde.weights_lbs,
+    e.name AS exercise_name
+FROM program_day_exercises AS pde
+INNER JOIN program_days AS pd ON pde.program_day_id = pd.id
+INNER JOIN exercises AS e ON pde.exercise_id = e.id
+WHERE pd.program_id = $1
+ORDER BY pde.sort_order;
+
+-- name: DeleteProgramDayExercise :exec
+DELETE FROM program_day_exercises
+WHERE program_day_exercises.id = $1
+    AND program_day_id IN (
+        SELECT program_days.id FROM program_days WHERE program_days.program_id = $2
+    );
+
+-- name: ReassignProgramDayExercises :exec
+UPDATE program_day_exercises
+SET exercise_id = sqlc.arg(target_id)
+WHERE exercise_id = sqlc.arg(source_id);
+
+-- name: CreateProgramEnrollment :one
+INSERT INTO program_enrollments (
+    id,
+    program_id,
+    user_id,
+    start_date,
+    created_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    now()
+) RETURNING *;
+
+-- name: GetProgramEnrollments :many
+SELECT * FROM program_enrollments
+WHERE program_id = $1 AND user_id = $2
+ORDER BY start_date DESC;
@@ -23,4 +23,10 @@ INNER JOIN refresh_tokens AS rt ON u.id = rt.user_id
 WHERE
     rt.token = $1
     AND rt.revoked_at IS NULL
-    AND rt.expires_at > NOW();
+    AND rt.expires_at > NOW()
+    AND u.disabled_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = NOW(), updated_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,43 @@
+-- name: UpsertTOTPFactor :exec
+INSERT INTO totp_factors (user_id, secret, created_at, updated_at)
+VALUES ($1, $2, NOW(), NOW())
+ON CONFLICT (user_id) DO UPDATE
+SET secret = excluded.secret, confirmed_at = NULL, last_used_step = 0, updated_at = NOW()
+WHERE totp_factors.confirmed_at IS NULL;
+
+-- name: GetTOTPFactor :one
+SELECT * FROM totp_factors
+WHERE user_id = $1;
+
+-- name: ConfirmTOTPFactor :exec
+UPDATE totp_factors
+SET confirmed_at = NOW(), last_used_step = $2, updated_at = NOW()
+WHERE user_id = $1 AND confirmed_at IS NULL;
+
+-- name: UseTOTPStep :one
+UPDATE totp_factors
+SET last_used_step = $2, updated_at = NOW()
+WHERE user_id = $1 AND last_used_step < $2
+RETURNING user_id;
+
+-- name: DeleteTOTPFactor :exec
+DELETE FROM totp_factors
+WHERE user_id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO totp_recovery_codes (user_id, code_hash, created_at)
+VALUES ($1, $2, NOW());
+
+-- name: UseRecoveryCode :one
+UPDATE totp_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+RETURNING id;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT count(*) FROM totp_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM totp_recovery_codes
+WHERE user_id = $1;
@@ -36,10 +36,64 @@ INSERT INTO users (
     first_name,
     last_name,
     email,
-    profile_image
-) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4)
+    profile_image,
+    email_verified_at
+) VALUES (gen_random_uuid(), now(), now(), $1, $2, $3, $4, now())
+RETURNING *;
+
+-- name: VerifyUserEmail :one
+UPDATE users
+SET email = $2, email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: ClaimUnverifiedUser :one
+UPDATE users
+SET hashed_password = NULL, email_verified_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND email_verified_at IS NULL
 RETURNING *;
 
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1;
+
+-- name: GetUserCounts :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE is_admin) AS admins,
+    COUNT(*) FILTER (WHERE disabled_at IS NOT NULL) AS disabled,
+    COUNT(*) FILTER (WHERE created_at > NOW() - INTERVAL '30 days') AS recent
+FROM users;
+
+-- name: GetRecentUsers :many
+SELECT * FROM users
+ORDER BY created_at DESC
+LIMIT $1;
+
+-- name: SearchUsers :many
+SELECT * FROM users
+WHERE
+    email ILIKE '%' || sqlc.arg(query)::text || '%' ESCAPE '\'
+    OR first_name ILIKE '%' || sqlc.arg(query)::text || '%' ESCAPE '\'
+    OR last_name ILIKE '%' || sqlc.arg(query)::text || '%' ESCAPE '\'
+ORDER BY created_at DESC
+LIMIT sqlc.arg(max_results);
+
+-- name: DisableUser :exec
+UPDATE users
+SET disabled_at = NOW(), updated_at = NOW()
+WHERE id = $1;
+
+-- name: EnableUser :exec
+UPDATE users
+SET disabled_at = NULL, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetUserSettings :one
+SELECT preferences FROM users
+WHERE id = $1;
+
+-- name: UpdateUserSettings :exec
+UPDATE users
+SET preferences = $1, updated_at = NOW()
+WHERE id = $2;
@@ -0,0 +1,12 @@
+-- name: CreateWebAuthnSession :exec
+INSERT INTO webauthn_sessions (token, user_id, ceremony, data, created_at, expires_at)
+VALUES ($1, $2, $3, $4, NOW(), $5);
+
+-- name: UseWebAuthnSession :one
+DELETE FROM webauthn_sessions
+WHERE token = $1 AND ceremony = $2 AND expires_at > NOW()
+RETURNING user_id, data;
+
+-- name: DeleteExpiredWebAuthnSessions :exec
+DELETE FROM webauthn_sessions
+WHERE expires_at <= NOW();
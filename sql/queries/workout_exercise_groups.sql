@@ -0,0 +1,79 @@
+-- name: CreateWorkoutExerciseGroup :one
+INSERT INTO workout_exercise_groups (
+    id,
+    workout_id,
+    kind,
+    label,
+    rounds,
+    rest_seconds,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    now(),
+    now()
+)
+RETURNING *;
+
+-- name: GetWorkoutExerciseGroups :many
+SELECT * FROM workout_exercise_groups
+WHERE workout_id = $1
+ORDER BY label;
+
+-- name: UpdateWorkoutExerciseGroup :one
+UPDATE workout_exercise_groups
+SET
+    updated_at = now(),
+    kind = $1,
+    label = $2,
+    rounds = $3,
+    rest_seconds = $4
+WHERE id = $5 AND workout_id = $6
+RETURNING *;
+
+-- name: DeleteWorkoutExerciseGroup :exec
+DELETE FROM workout_exercise_groups
+WHERE id = $1 AND workout_id = $2;
+
+-- name: SetWorkoutExercisesGroup :exec
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    group_id = sqlc.arg(group_id)::uuid
+WHERE workout_id = sqlc.arg(workout_id)
+    AND id = ANY(sqlc.arg(ids)::uuid []);
+
+-- name: ClearWorkoutExerciseGroup :exec
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    group_id = NULL
+WHERE group_id = $1 AND workout_id = $2;
+
+-- name: CopyWorkoutExerciseGroups :exec
+INSERT INTO workout_exercise_groups (
+    id,
+    workout_id,
+    kind,
+    label,
+    rounds,
+    rest_seconds,
+    created_at,
+    updated_at
+)
+SELECT
+    gen_random_uuid(),
+    sqlc.arg(target_id)::uuid,
+    kind,
+    label,
+    rounds,
+    rest_seconds,
+    now(),
+    now()
+FROM workout_exercise_groups
+WHERE workout_id = sqlc.arg(source_id);
@@ -0,0 +1,24 @@
+-- name: CreateWorkoutSeries :one
+INSERT INTO workout_series (
+    id,
+    user_id,
+    weekdays,
+    interval_days,
+    weeks,
+    start_date,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    now(),
+    now()
+) RETURNING *;
+
+-- name: GetWorkoutSeries :one
+SELECT * FROM workout_series
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,58 @@
+-- name: UpsertWorkoutSetDetail :exec
+INSERT INTO workout_set_details (
+    workout_exercise_id,
+    set_number,
+    rpe,
+    rir,
+    tempo,
+    notes,
+    created_at,
+    updated_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    now(),
+    now()
+)
+ON CONFLICT (workout_exercise_id, set_number) DO UPDATE
+SET
+    rpe = excluded.rpe,
+    rir = excluded.rir,
+    tempo = excluded.tempo,
+    notes = excluded.notes,
+    updated_at = now();
+
+-- name: DeleteWorkoutSetDetail :exec
+DELETE FROM workout_set_details
+WHERE workout_exercise_id = $1 AND set_number = $2;
+
+-- name: TrimWorkoutSetDetails :exec
+DELETE FROM workout_set_details
+WHERE workout_exercise_id = $1 AND set_number > $2;
+
+-- name: GetWorkoutExerciseSetDetails :many
+SELECT * FROM workout_set_details
+WHERE workout_exercise_id = $1
+ORDER BY set_number;
+
+-- name: GetWorkoutSetDetails :many
+SELECT d.* FROM workout_set_details AS d
+JOIN workouts_exercises AS we
+    ON d.workout_exercise_id = we.id
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE we.workout_id = $1 AND w.user_id = $2
+ORDER BY d.workout_exercise_id, d.set_number;
+
+-- name: GetExerciseSetDetails :many
+SELECT d.* FROM workout_set_details AS d
+JOIN workouts_exercises AS we
+    ON d.workout_exercise_id = we.id
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1 AND we.exercise_id = $2
+ORDER BY d.workout_exercise_id, d.set_number;
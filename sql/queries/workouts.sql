@@ -68,3 +68,88 @@ SELECT COUNT(*) FROM workouts WHERE workouts.user_id = $2 AND workouts.date_comp
 -- name: DeleteAllUserWorkouts :exec
 DELETE FROM workouts
 WHERE user_id = $1;
+
+-- name: CountUserWorkouts :one
+SELECT COUNT(*) FROM workouts
+WHERE user_id = $1;
+
+-- name: CountWorkoutsCompletedSince :one
+SELECT COUNT(*) FROM workouts
+WHERE user_id = $1 AND date_completed >= $2;
+
+-- name: GetCompletedWorkoutsPerDay :many
+SELECT
+    date_completed::date AS day,
+    COUNT(*) AS total
+FROM workouts
+WHERE date_completed >= $1
+GROUP BY day
+ORDER BY day;
+
+-- name: CreateSeriesWorkout :one
+INSERT INTO workouts (
+    id,
+    created_at,
+    updated_at,
+    user_id,
+    title,
+    description,
+    duration_minutes,
+    planned_date,
+    series_id
+) VALUES (
+    gen_random_uuid(),
+    now(),
+    now(),
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: SetWorkoutSeries :exec
+UPDATE workouts
+SET
+    updated_at = now(),
+    series_id = $1
+WHERE id = $2 AND user_id = $3;
+
+-- name: UpdateFutureSeriesWorkouts :exec
+UPDATE workouts
+SET
+    updated_at = now(),
+    title = $1,
+    description = $2,
+    duration_minutes = $3
+WHERE series_id = $4
+    AND user_id = $5
+    AND planned_date >= $6
+    AND date_completed IS NULL;
+
+-- name: DeleteFutureSeriesWorkouts :exec
+DELETE FROM workouts
+WHERE series_id = $1
+    AND user_id = $2
+    AND planned_date >= $3
+    AND date_completed IS NULL;
+
+-- name: StartWorkout :one
+UPDATE workouts
+SET
+    updated_at = now(),
+    started_at = coalesce(started_at, $1)
+WHERE id = $2 AND user_id = $3 AND date_completed IS NULL
+RETURNING *;
+
+-- name: FinishWorkout :one
+UPDATE workouts
+SET
+    updated_at = now(),
+    date_completed = $1,
+    duration_minutes = $2
+WHERE id = $3 AND user_id = $4
+    AND started_at IS NOT NULL
+    AND date_completed IS NULL
+RETURNING *;
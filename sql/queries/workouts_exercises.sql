@@ -11,7 +11,13 @@ INSERT INTO workouts_exercises (
     weights_completed_lbs,
     updated_at,
     created_at,
-    sort_order
+    sort_order,
+    suggestion,
+    durations_planned_seconds,
+    durations_completed_seconds,
+    distances_planned_meters,
+    distances_completed_meters,
+    heart_rates_completed
 ) VALUES (
     gen_random_uuid(),
     $1,
@@ -27,7 +33,13 @@ INSERT INTO workouts_exercises (
     (
         SELECT coalesce(max(sort_order), 0) + 1 FROM workouts_exercises
         WHERE workout_id = $1
-    )
+    ),
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14
 ) RETURNING *;
 
 -- name: WorkoutAndExercises :many
@@ -51,7 +63,33 @@ SET
     sets_completed = $3,
     reps_per_set_completed = $4,
     weights_planned_lbs = $5,
-    weights_completed_lbs = $6
+    weights_completed_lbs = $6,
+    durations_planned_seconds = $7,
+    durations_completed_seconds = $8,
+    distances_planned_meters = $9,
+    distances_completed_meters = $10,
+    heart_rates_completed = $11
+WHERE workouts_exercises.id = $12
+    AND workouts_exercises.workout_id = $13
+    AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $13 AND workouts.user_id = $14)
+RETURNING *;
+
+-- name: GetWorkoutExercise :one
+SELECT we.* FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE we.id = $1 AND we.workout_id = $2 AND w.user_id = $3;
+
+-- name: UpdateCompletedSets :one
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    sets_completed = $1,
+    reps_per_set_completed = $2,
+    weights_completed_lbs = $3,
+    durations_completed_seconds = $4,
+    distances_completed_meters = $5,
+    heart_rates_completed = $6
 WHERE workouts_exercises.id = $7
     AND workouts_exercises.workout_id = $8
     AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $8 AND workouts.user_id = $9)
@@ -71,3 +109,98 @@ DELETE FROM workouts_exercises
 WHERE workouts_exercises.id = $1
     AND workouts_exercises.workout_id = $2
     AND EXISTS (SELECT 1 FROM workouts WHERE workouts.id = $2 AND workouts.user_id = $3);
+
+-- name: CountExerciseUsage :one
+SELECT COUNT(*) FROM workouts_exercises
+WHERE exercise_id = $1;
+
+-- name: ReassignWorkoutExercises :exec
+UPDATE workouts_exercises
+SET
+    updated_at = now(),
+    exercise_id = sqlc.arg(target_id)
+WHERE exercise_id = sqlc.arg(source_id);
+
+-- name: GetCompletedExerciseSets :many
+SELECT we.reps_per_set_completed, we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.id <> $3
+    AND we.sets_completed > 0;
+
+-- name: GetExerciseHistory :many
+SELECT
+    we.id,
+    we.workout_id,
+    w.title,
+    coalesce(w.date_completed, w.planned_date)::timestamp AS session_date,
+    we.reps_per_set_completed,
+    we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.sets_completed > 0
+ORDER BY session_date, we.created_at;
+
+-- name: GetLastExerciseSession :one
+SELECT
+    w.title,
+    coalesce(w.date_completed, w.planned_date)::timestamp AS session_date,
+    we.reps_per_set_planned,
+    we.weights_planned_lbs,
+    we.reps_per_set_completed,
+    we.weights_completed_lbs
+FROM workouts_exercises AS we
+JOIN workouts AS w
+    ON we.workout_id = w.id
+WHERE w.user_id = $1
+    AND we.exercise_id = $2
+    AND we.sets_completed > 0
+ORDER BY session_date DESC, we.created_at DESC
+LIMIT 1;
+
+-- name: CopyWorkoutExercises :exec
+INSERT INTO workouts_exercises (
+    id,
+    workout_id,
+    exercise_id,
+    sets_planned,
+    reps_per_set_planned,
+    sets_completed,
+    reps_per_set_completed,
+    weights_planned_lbs,
+    weights_completed_lbs,
+    updated_at,
+    created_at,
+    sort_order,
+    group_id,
+    durations_planned_seconds,
+    distances_planned_meters
+)
+SELECT
+    gen_random_uuid(),
+    sqlc.arg(target_id)::uuid,
+    we.exercise_id,
+    we.sets_planned,
+    we.reps_per_set_planned,
+    0,
+    '{}',
+    we.weights_planned_lbs,
+    '{}',
+    now(),
+    now(),
+    we.sort_order,
+    tg.id,
+    we.durations_planned_seconds,
+    we.distances_planned_meters
+FROM workouts_exercises AS we
+LEFT JOIN workout_exercise_groups AS sg
+    ON we.group_id = sg.id
+LEFT JOIN workout_exercise_groups AS tg
+    ON tg.workout_id = sqlc.arg(target_id)::uuid AND sg.label = tg.label
+WHERE we.workout_id = sqlc.arg(source_id);
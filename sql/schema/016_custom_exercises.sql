@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE exercises ADD COLUMN user_id UUID REFERENCES users (id) ON DELETE CASCADE;
+
+CREATE INDEX idx_exercises_user_id ON exercises (user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_exercises_user_id;
+DELETE FROM exercises WHERE user_id IS NOT NULL;
+ALTER TABLE exercises DROP COLUMN user_id;
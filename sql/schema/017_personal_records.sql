@@ -0,0 +1,21 @@
+-- +goose Up
+CREATE TABLE personal_records (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises (id) ON DELETE CASCADE,
+    workout_exercise_id UUID NOT NULL REFERENCES workouts_exercises (id) ON DELETE CASCADE,
+    record_type TEXT NOT NULL CHECK (
+        record_type IN ('heaviest_weight', 'reps_at_weight', 'estimated_1rm', 'session_volume')
+    ),
+    value DOUBLE PRECISION NOT NULL,
+    reps INTEGER NOT NULL,
+    weight_lbs INTEGER NOT NULL,
+    achieved_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_personal_records_user_exercise ON personal_records (user_id, exercise_id);
+CREATE INDEX idx_personal_records_workout_exercise_id ON personal_records (workout_exercise_id);
+
+-- +goose Down
+DROP TABLE personal_records;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE workout_series (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    weekdays INTEGER [] NOT NULL DEFAULT '{}',
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    weeks INTEGER NOT NULL,
+    start_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_workout_series_user_id ON workout_series (user_id);
+
+ALTER TABLE workouts ADD COLUMN series_id UUID REFERENCES workout_series (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_workouts_series_id ON workouts (series_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workouts_series_id;
+ALTER TABLE workouts DROP COLUMN series_id;
+DROP TABLE workout_series;
@@ -0,0 +1,63 @@
+-- +goose Up
+CREATE TABLE programs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_programs_user_id ON programs (user_id);
+
+CREATE TABLE program_weeks (
+    id UUID PRIMARY KEY,
+    program_id UUID NOT NULL REFERENCES programs (id) ON DELETE CASCADE,
+    week_number INTEGER NOT NULL,
+    progression TEXT NOT NULL DEFAULT 'none' CHECK (
+        progression IN ('none', 'add_weight', 'add_reps', 'deload')
+    ),
+    amount INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (program_id, week_number)
+);
+
+CREATE TABLE program_days (
+    id UUID PRIMARY KEY,
+    program_id UUID NOT NULL REFERENCES programs (id) ON DELETE CASCADE,
+    day_number INTEGER NOT NULL CHECK (day_number BETWEEN 1 AND 7),
+    title TEXT NOT NULL,
+    template_id UUID REFERENCES workout_templates (id) ON DELETE SET NULL,
+    duration_minutes INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_program_days_program_id ON program_days (program_id);
+
+CREATE TABLE program_day_exercises (
+    id UUID PRIMARY KEY,
+    program_day_id UUID NOT NULL REFERENCES program_days (id) ON DELETE CASCADE,
+    exercise_id UUID NOT NULL REFERENCES exercises (id) ON DELETE CASCADE,
+    reps_per_set INTEGER [] NOT NULL,
+    weights_lbs INTEGER [] NOT NULL,
+    sort_order INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_program_day_exercises_program_day_id ON program_day_exercises (program_day_id);
+
+CREATE TABLE program_enrollments (
+    id UUID PRIMARY KEY,
+    program_id UUID NOT NULL REFERENCES programs (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    start_date TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_program_enrollments_program_user ON program_enrollments (program_id, user_id);
+
+-- +goose Down
+DROP TABLE program_enrollments;
+DROP TABLE program_day_exercises;
+DROP TABLE program_days;
+DROP TABLE program_weeks;
+DROP TABLE programs;
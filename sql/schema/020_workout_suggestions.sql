@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workouts_exercises ADD COLUMN suggestion JSONB;
+
+-- +goose Down
+ALTER TABLE workouts_exercises DROP COLUMN suggestion;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE workouts ADD COLUMN started_at TIMESTAMP;
+
+-- +goose Down
+ALTER TABLE workouts DROP COLUMN started_at;
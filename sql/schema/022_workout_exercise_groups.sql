@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE workout_exercise_groups (
+    id UUID PRIMARY KEY,
+    workout_id UUID NOT NULL REFERENCES workouts (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL DEFAULT 'superset' CHECK (
+        kind IN ('superset', 'circuit')
+    ),
+    label TEXT NOT NULL,
+    rounds INTEGER NOT NULL DEFAULT 1 CHECK (rounds BETWEEN 1 AND 20),
+    rest_seconds INTEGER NOT NULL DEFAULT 0 CHECK (rest_seconds BETWEEN 0 AND 600),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE (workout_id, label)
+);
+
+ALTER TABLE workouts_exercises
+ADD COLUMN group_id UUID REFERENCES workout_exercise_groups (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_workouts_exercises_group_id ON workouts_exercises (group_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_workouts_exercises_group_id;
+ALTER TABLE workouts_exercises DROP COLUMN group_id;
+DROP TABLE workout_exercise_groups;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE workout_set_details (
+    workout_exercise_id UUID NOT NULL REFERENCES workouts_exercises (id) ON DELETE CASCADE,
+    set_number INTEGER NOT NULL CHECK (set_number > 0),
+    rpe DOUBLE PRECISION CHECK (rpe BETWEEN 1 AND 10),
+    rir INTEGER CHECK (rir BETWEEN 0 AND 10),
+    tempo TEXT,
+    notes TEXT,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (workout_exercise_id, set_number)
+);
+
+-- +goose Down
+DROP TABLE workout_set_details;
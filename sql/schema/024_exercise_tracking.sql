@@ -0,0 +1,46 @@
+-- +goose Up
+ALTER TABLE exercises
+ADD COLUMN tracking_type TEXT NOT NULL DEFAULT 'weight_reps' CHECK (
+    tracking_type IN ('weight_reps', 'time', 'distance_time', 'time_heart_rate')
+);
+
+ALTER TABLE workouts_exercises
+ADD COLUMN durations_planned_seconds INTEGER [] NOT NULL DEFAULT '{}',
+ADD COLUMN durations_completed_seconds INTEGER [] NOT NULL DEFAULT '{}',
+ADD COLUMN distances_planned_meters INTEGER [] NOT NULL DEFAULT '{}',
+ADD COLUMN distances_completed_meters INTEGER [] NOT NULL DEFAULT '{}',
+ADD COLUMN heart_rates_completed INTEGER [] NOT NULL DEFAULT '{}';
+
+-- +goose statementbegin
+UPDATE exercises SET tracking_type = 'time'
+WHERE user_id IS NULL
+    AND name IN ('plank', 'side plank', 'wall sit', 'hollow body hold', 'battle ropes');
+
+UPDATE exercises SET tracking_type = 'distance_time'
+WHERE user_id IS NULL AND name = 'rowing machine';
+
+INSERT INTO exercises (id, name, description, primary_muscle_group, secondary_muscle_group, created_at, updated_at, tracking_type) VALUES
+(gen_random_uuid(), 'running', 'run at a steady, conversational pace on a road, track or treadmill. land softly under your hips with a slight forward lean and relaxed shoulders. log the distance and time to follow your pace over the weeks.', 'cardiovascular', 'quadriceps', now(), now(), 'distance_time'),
+
+(gen_random_uuid(), 'cycling', 'ride outdoors or on a stationary bike with the seat set so your knee is slightly bent at the bottom of each pedal stroke. keep your upper body relaxed and cadence steady. log the distance and time of the ride.', 'cardiovascular', 'quadriceps', now(), now(), 'distance_time'),
+
+(gen_random_uuid(), 'bike intervals', 'on a stationary or air bike, alternate hard efforts with easy recovery spinning. push close to your maximum for the work interval, then pedal lightly until your breathing settles. log each interval with its time and average heart rate.', 'cardiovascular', 'quadriceps', now(), now(), 'time_heart_rate'),
+
+(gen_random_uuid(), 'elliptical', 'stand tall on the elliptical with a light grip on the handles. push and pull through a smooth, full stride while keeping your heels down. this low-impact machine builds aerobic fitness with little stress on the joints. log the time and average heart rate.', 'cardiovascular', null, now(), now(), 'time_heart_rate'),
+
+(gen_random_uuid(), 'jump rope', 'hold the handles at hip height with elbows close to your sides. turn the rope with your wrists and make small, quick hops on the balls of your feet. keep a steady rhythm and log how long you skip.', 'cardiovascular', 'calves', now(), now(), 'time');
+-- +goose statementend
+
+-- +goose Down
+DELETE FROM exercises
+WHERE user_id IS NULL
+    AND name IN ('running', 'cycling', 'bike intervals', 'elliptical', 'jump rope');
+
+ALTER TABLE workouts_exercises
+DROP COLUMN durations_planned_seconds,
+DROP COLUMN durations_completed_seconds,
+DROP COLUMN distances_planned_meters,
+DROP COLUMN distances_completed_meters,
+DROP COLUMN heart_rates_completed;
+
+ALTER TABLE exercises DROP COLUMN tracking_type;
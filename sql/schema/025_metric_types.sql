@@ -0,0 +1,118 @@
+-- +goose Up
+CREATE TABLE metric_types (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+    slug TEXT NOT NULL,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (
+        kind IN ('mass', 'length', 'percent', 'heart_rate', 'blood_pressure', 'custom')
+    ),
+    unit TEXT NOT NULL DEFAULT '',
+    decimals INTEGER NOT NULL DEFAULT 2 CHECK (decimals BETWEEN 0 AND 2),
+    min_value NUMERIC(10, 2) NOT NULL DEFAULT 0,
+    max_value NUMERIC(10, 2) NOT NULL DEFAULT 99999999.99,
+    secondary_name TEXT,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    CHECK (min_value < max_value)
+);
+
+-- Built-in types have no owner; custom slugs are unique per user
+CREATE UNIQUE INDEX idx_metric_types_builtin_slug ON metric_types (slug) WHERE user_id IS NULL;
+CREATE UNIQUE INDEX idx_metric_types_user_slug ON metric_types (user_id, slug) WHERE user_id IS NOT NULL;
+
+CREATE TABLE measurements (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    metric_type_id UUID NOT NULL REFERENCES metric_types (id) ON DELETE CASCADE,
+    value NUMERIC(10, 2) NOT NULL,
+    secondary_value NUMERIC(10, 2),
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_measurements_user_type ON measurements (user_id, metric_type_id, created_at);
+
+-- +goose statementbegin
+-- Mass is stored in pounds and length in inches, as body weight always was
+INSERT INTO metric_types (id, slug, name, kind, unit, decimals, min_value, max_value, secondary_name, sort_order, created_at, updated_at) VALUES
+(gen_random_uuid(), 'body_weight', 'Body Weight', 'mass', '', 2, 20, 999, null, 1, now(), now()),
+(gen_random_uuid(), 'muscle_mass', 'Muscle Mass', 'mass', '', 2, 10, 999, null, 2, now(), now()),
+(gen_random_uuid(), 'body_fat', 'Body Fat', 'percent', '%', 2, 1, 75, null, 3, now(), now()),
+(gen_random_uuid(), 'waist', 'Waist', 'length', '', 1, 10, 100, null, 4, now(), now()),
+(gen_random_uuid(), 'chest', 'Chest', 'length', '', 1, 10, 100, null, 5, now(), now()),
+(gen_random_uuid(), 'arm', 'Arm', 'length', '', 1, 4, 40, null, 6, now(), now()),
+(gen_random_uuid(), 'resting_heart_rate', 'Resting Heart Rate', 'heart_rate', 'bpm', 0, 25, 200, null, 7, now(), now()),
+(gen_random_uuid(), 'blood_pressure', 'Blood Pressure', 'blood_pressure', 'mmHg', 0, 30, 250, 'diastolic', 8, now(), now());
+
+INSERT INTO measurements (id, user_id, metric_type_id, value, created_at, updated_at)
+SELECT bw.id, bw.user_id, mt.id, bw.measurement, bw.created_at, bw.updated_at
+FROM body_weights bw, metric_types mt
+WHERE mt.slug = 'body_weight' AND mt.user_id IS NULL;
+
+INSERT INTO measurements (id, user_id, metric_type_id, value, created_at, updated_at)
+SELECT mm.id, mm.user_id, mt.id, mm.measurement, mm.created_at, mm.updated_at
+FROM muscle_masses mm, metric_types mt
+WHERE mt.slug = 'muscle_mass' AND mt.user_id IS NULL;
+
+INSERT INTO measurements (id, user_id, metric_type_id, value, created_at, updated_at)
+SELECT bf.id, bf.user_id, mt.id, bf.measurement, bf.created_at, bf.updated_at
+FROM body_fat_percents bf, metric_types mt
+WHERE mt.slug = 'body_fat' AND mt.user_id IS NULL;
+-- +goose statementend
+
+DROP TABLE body_weights, muscle_masses, body_fat_percents;
+
+-- +goose Down
+CREATE TABLE body_weights (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    measurement NUMERIC(5, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE muscle_masses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    measurement NUMERIC(5, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE body_fat_percents (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    measurement NUMERIC(4, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_body_weights_user_id ON body_weights (user_id);
+CREATE INDEX idx_muscle_masses_user_id ON muscle_masses (user_id);
+CREATE INDEX idx_body_fat_percents_user_id ON body_fat_percents (user_id);
+
+-- +goose statementbegin
+-- Only the three original metrics survive the rollback
+INSERT INTO body_weights (id, user_id, measurement, created_at, updated_at)
+SELECT m.id, m.user_id, m.value, m.created_at, m.updated_at
+FROM measurements m
+JOIN metric_types mt ON mt.id = m.metric_type_id
+WHERE mt.slug = 'body_weight' AND mt.user_id IS NULL;
+
+INSERT INTO muscle_masses (id, user_id, measurement, created_at, updated_at)
+SELECT m.id, m.user_id, m.value, m.created_at, m.updated_at
+FROM measurements m
+JOIN metric_types mt ON mt.id = m.metric_type_id
+WHERE mt.slug = 'muscle_mass' AND mt.user_id IS NULL;
+
+INSERT INTO body_fat_percents (id, user_id, measurement, created_at, updated_at)
+SELECT m.id, m.user_id, m.value, m.created_at, m.updated_at
+FROM measurements m
+JOIN metric_types mt ON mt.id = m.metric_type_id
+WHERE mt.slug = 'body_fat' AND mt.user_id IS NULL;
+-- +goose statementend
+
+DROP TABLE measurements;
+DROP TABLE metric_types;
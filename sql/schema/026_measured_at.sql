@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE measurements
+ADD COLUMN measured_at TIMESTAMPTZ;
+
+-- created_at was written by now() in the session time zone, which the cast
+-- assumes as well
+UPDATE measurements SET measured_at = created_at::TIMESTAMPTZ;
+
+ALTER TABLE measurements
+ALTER COLUMN measured_at SET NOT NULL;
+
+DROP INDEX idx_measurements_user_type;
+CREATE INDEX idx_measurements_user_type ON measurements (user_id, metric_type_id, measured_at);
+
+-- +goose Down
+DROP INDEX idx_measurements_user_type;
+CREATE INDEX idx_measurements_user_type ON measurements (user_id, metric_type_id, created_at);
+
+ALTER TABLE measurements
+DROP COLUMN measured_at;
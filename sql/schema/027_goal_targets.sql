@@ -0,0 +1,30 @@
+-- +goose Up
+ALTER TABLE goals
+ADD COLUMN target_kind VARCHAR(20),
+ADD COLUMN metric_type_id UUID REFERENCES metric_types (id) ON DELETE SET NULL,
+ADD COLUMN exercise_id UUID REFERENCES exercises (id) ON DELETE SET NULL,
+ADD COLUMN target_direction VARCHAR(8),
+ADD COLUMN target_value NUMERIC(10, 2),
+ADD COLUMN start_value NUMERIC(10, 2),
+ADD CONSTRAINT goals_target_kind_check
+CHECK (target_kind IN ('metric', 'lift_1rm', 'workouts_per_week')),
+ADD CONSTRAINT goals_target_direction_check
+CHECK (target_direction IN ('at_most', 'at_least')),
+-- A goal either has a whole target or none of one
+ADD CONSTRAINT goals_target_complete_check
+CHECK (
+    (target_kind IS NULL) = (target_direction IS NULL)
+    AND (target_kind IS NULL) = (target_value IS NULL)
+);
+
+-- +goose Down
+ALTER TABLE goals
+DROP CONSTRAINT goals_target_complete_check,
+DROP CONSTRAINT goals_target_direction_check,
+DROP CONSTRAINT goals_target_kind_check,
+DROP COLUMN start_value,
+DROP COLUMN target_value,
+DROP COLUMN target_direction,
+DROP COLUMN exercise_id,
+DROP COLUMN metric_type_id,
+DROP COLUMN target_kind;
@@ -0,0 +1,29 @@
+-- +goose Up
+CREATE TABLE goal_milestones (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES goals (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    target_date DATE NOT NULL,
+    completed_at TIMESTAMP,
+    sort_order INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_goal_milestones_goal ON goal_milestones (goal_id, sort_order);
+
+CREATE TABLE goal_checkins (
+    id UUID PRIMARY KEY,
+    goal_id UUID NOT NULL REFERENCES goals (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    checked_in_on DATE NOT NULL,
+    note VARCHAR(500) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_goal_checkins_goal ON goal_checkins (goal_id, checked_in_on);
+
+-- +goose Down
+DROP TABLE goal_checkins;
+DROP TABLE goal_milestones;
@@ -0,0 +1,19 @@
+-- +goose Up
+ALTER TABLE goals
+DROP CONSTRAINT goals_status_check,
+ADD CONSTRAINT goals_status_check
+CHECK (status IN ('in_progress', 'completed', 'paused', 'abandoned')),
+ADD COLUMN paused_at TIMESTAMP,
+ADD COLUMN abandoned_at TIMESTAMP,
+ADD COLUMN status_reason VARCHAR(500);
+
+-- +goose Down
+UPDATE goals SET status = 'in_progress' WHERE status IN ('paused', 'abandoned');
+
+ALTER TABLE goals
+DROP COLUMN status_reason,
+DROP COLUMN abandoned_at,
+DROP COLUMN paused_at,
+DROP CONSTRAINT goals_status_check,
+ADD CONSTRAINT goals_status_check
+CHECK (status IN ('in_progress', 'completed'));
@@ -0,0 +1,31 @@
+-- +goose Up
+-- credential holds the verified WebAuthn credential record, including its
+-- public key and signature counter
+CREATE TABLE passkeys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    credential_id BYTEA NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    credential JSONB NOT NULL,
+    last_used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_passkeys_user_id ON passkeys (user_id);
+
+-- The challenge of a registration or sign in between its begin and finish
+-- requests. Sign ins have no user until the passkey names one. Only a sha256
+-- hash of each token is stored
+CREATE TABLE webauthn_sessions (
+    token TEXT PRIMARY KEY,
+    user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+    ceremony VARCHAR(20) NOT NULL CHECK (ceremony IN ('registration', 'login')),
+    data JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT now(),
+    expires_at TIMESTAMP NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS webauthn_sessions;
+DROP TABLE IF EXISTS passkeys;
@@ -0,0 +1,39 @@
+/**
+ * Converts an ISO 8601 timestamp into the value of a datetime-local input in
+ * the browser's time zone.
+ * @param {string} iso - The timestamp, e.g. "2025-03-09T12:30:00Z".
+ * @returns {string} e.g. "2025-03-09T07:30", or "" when iso is empty.
+ */
+function localInputValue(iso) {
+  if (!iso) return "";
+  const d = new Date(iso);
+  const local = new Date(d.getTime() - d.getTimezoneOffset() * 60000);
+  return local.toISOString().slice(0, 16);
+}
+
+/**
+ * Converts a datetime-local input value into an ISO 8601 timestamp, keeping
+ * the browser's time zone offset for that date.
+ * @param {string} value - The input value, e.g. "2025-03-09T07:30".
+ * @returns {string} The UTC timestamp, or "" when value is empty.
+ */
+function isoFromLocalInput(value) {
+  if (!value) return "";
+  return new Date(value).toISOString();
+}
+
+/**
+ * Formats an ISO 8601 timestamp for display in the browser's time zone.
+ * @param {string} iso - The timestamp to format.
+ * @returns {string} e.g. "Sun, Mar 09, 2025, 7:30 AM".
+ */
+function formatLocalTime(iso) {
+  return new Date(iso).toLocaleString(undefined, {
+    weekday: "short",
+    month: "short",
+    day: "2-digit",
+    year: "numeric",
+    hour: "numeric",
+    minute: "2-digit",
+  });
+}
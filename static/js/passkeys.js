@@ -0,0 +1,146 @@
+/**
+ * Decodes a base64url string, as the server sends binary fields, into bytes.
+ * @param {string} value - The base64url string.
+ * @returns {ArrayBuffer}
+ */
+function base64urlToBuffer(value) {
+  const base64 = value.replace(/-/g, "+").replace(/_/g, "/");
+  const padded = base64 + "=".repeat((4 - (base64.length % 4)) % 4);
+  const binary = atob(padded);
+  const bytes = new Uint8Array(binary.length);
+  for (let i = 0; i < binary.length; i++) {
+    bytes[i] = binary.charCodeAt(i);
+  }
+  return bytes.buffer;
+}
+
+/**
+ * Encodes bytes as an unpadded base64url string for sending to the server.
+ * @param {ArrayBuffer} buffer - The bytes to encode.
+ * @returns {string}
+ */
+function bufferToBase64url(buffer) {
+  let binary = "";
+  for (const b of new Uint8Array(buffer)) {
+    binary += String.fromCharCode(b);
+  }
+  return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "");
+}
+
+/**
+ * Posts JSON to the passkey API and returns the parsed response, throwing the
+ * server's error message when it doesn't succeed.
+ * @param {string} url - The endpoint.
+ * @param {object} [body] - The request body.
+ * @returns {Promise<object|null>}
+ */
+async function passkeyRequest(url, body) {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(body || {}),
+  });
+  const data = res.status === 204 ? null : await res.json();
+  if (!res.ok) {
+    throw new Error((data && data.error) || "Something went wrong. Please try again.");
+  }
+  return data;
+}
+
+/**
+ * Shows a passkey error in the given element, or clears it.
+ * @param {string} errorID - The ID of the error element.
+ * @param {string} [message] - The message, or nothing to clear it.
+ */
+function showPasskeyError(errorID, message) {
+  const el = document.getElementById(errorID);
+  if (!el) return;
+  el.className = message ? "text-error text-sm" : "hidden";
+  el.textContent = message || "";
+}
+
+/**
+ * Creates a passkey on this device and saves it to the signed in user's
+ * account, then reloads the page to list it.
+ * @param {string} nameID - The ID of the input holding the passkey's name.
+ * @param {string} errorID - The ID of the element errors are shown in.
+ */
+async function registerPasskey(nameID, errorID) {
+  showPasskeyError(errorID);
+  if (!window.PublicKeyCredential) {
+    showPasskeyError(errorID, "This browser doesn't support passkeys.");
+    return;
+  }
+  try {
+    const begin = await passkeyRequest("/api/v1/passkeys/register/begin");
+    const options = begin.options.publicKey;
+    options.challenge = base64urlToBuffer(options.challenge);
+    options.user.id = base64urlToBuffer(options.user.id);
+    for (const c of options.excludeCredentials || []) {
+      c.id = base64urlToBuffer(c.id);
+    }
+
+    const cred = await navigator.credentials.create({ publicKey: options });
+    const nameInput = document.getElementById(nameID);
+    await passkeyRequest("/api/v1/passkeys/register/finish", {
+      session_token: begin.session_token,
+      name: nameInput ? nameInput.value.trim() : "",
+      credential: {
+        id: cred.id,
+        rawId: bufferToBase64url(cred.rawId),
+        type: cred.type,
+        response: {
+          clientDataJSON: bufferToBase64url(cred.response.clientDataJSON),
+          attestationObject: bufferToBase64url(cred.response.attestationObject),
+          transports: cred.response.getTransports ? cred.response.getTransports() : [],
+        },
+      },
+    });
+    window.location.reload();
+  } catch (err) {
+    // The user closed the browser's passkey prompt
+    if (err.name === "NotAllowedError") return;
+    showPasskeyError(errorID, err.message);
+  }
+}
+
+/**
+ * Signs in with a passkey saved on this device, then goes where the server
+ * sends logged in users, e.g. the admin console for admins.
+ * @param {string} errorID - The ID of the element errors are shown in.
+ */
+async function loginWithPasskey(errorID) {
+  showPasskeyError(errorID);
+  if (!window.PublicKeyCredential) {
+    showPasskeyError(errorID, "This browser doesn't support passkeys.");
+    return;
+  }
+  try {
+    const begin = await passkeyRequest("/api/v1/passkeys/login/begin");
+    const options = begin.options.publicKey;
+    options.challenge = base64urlToBuffer(options.challenge);
+    for (const c of options.allowCredentials || []) {
+      c.id = base64urlToBuffer(c.id);
+    }
+
+    const cred = await navigator.credentials.get({ publicKey: options });
+    const login = await passkeyRequest("/api/v1/passkeys/login/finish", {
+      session_token: begin.session_token,
+      credential: {
+        id: cred.id,
+        rawId: bufferToBase64url(cred.rawId),
+        type: cred.type,
+        response: {
+          clientDataJSON: bufferToBase64url(cred.response.clientDataJSON),
+          authenticatorData: bufferToBase64url(cred.response.authenticatorData),
+          signature: bufferToBase64url(cred.response.signature),
+          userHandle: cred.response.userHandle ? bufferToBase64url(cred.response.userHandle) : "",
+        },
+      },
+    });
+    window.location.assign(login.redirect || "/");
+  } catch (err) {
+    if (err.name === "NotAllowedError") return;
+    showPasskeyError(errorID, err.message);
+  }
+}
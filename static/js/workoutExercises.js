@@ -26,6 +26,8 @@ function workoutExerciseRow(
   completedSets = 0,
   completedReps = [0],
   completedWeights = [0],
+  completedEffort = [],
+  timed = {},
 ) {
   return {
     editingExercise: false,
@@ -35,6 +37,12 @@ function workoutExerciseRow(
     completedSets: completedSets,
     completedReps: completedReps,
     completedWeights: completedWeights,
+    completedEffort: completedEffort,
+    plannedDurations: timed.plannedDurations || [],
+    plannedDistances: timed.plannedDistances || [],
+    completedDurations: timed.completedDurations || [],
+    completedDistances: timed.completedDistances || [],
+    completedHeartRates: timed.completedHeartRates || [],
     updateArrays(array, n) {
       n = Number(n) || 0;
       if (n < 0) {
@@ -57,6 +65,9 @@ function workoutExerciseRow(
             this.plannedWeights.push(0);
           }
         }
+
+        resizeTimed(this.plannedDurations, n);
+        resizeTimed(this.plannedDistances, n);
       } else {
         if (this.completedReps.length > n) {
           this.completedReps.splice(n);
@@ -73,11 +84,35 @@ function workoutExerciseRow(
             this.completedWeights.push(0);
           }
         }
+
+        if (this.completedEffort.length > n) {
+          this.completedEffort.splice(n);
+        } else {
+          for (let i = this.completedEffort.length; i < n; i++) {
+            this.completedEffort.push({ rpe: "", rir: "", tempo: "", notes: "" });
+          }
+        }
+
+        resizeTimed(this.completedDurations, n);
+        resizeTimed(this.completedDistances, n);
+        resizeTimed(this.completedHeartRates, n);
       }
     },
   };
 }
 
+// resizeTimed grows or shrinks a timed set field to n sets. Timed values
+// start blank so unset durations, distances and heart rates stay unset.
+function resizeTimed(array, n) {
+  if (array.length > n) {
+    array.splice(n);
+  } else {
+    for (let i = array.length; i < n; i++) {
+      array.push("");
+    }
+  }
+}
+
 function workoutExerciseFooter() {
   return {
     open: false,
@@ -108,3 +143,50 @@ function workoutExerciseFooter() {
     },
   };
 }
+
+function sessionClock(startedAt) {
+  return {
+    elapsed: "0:00",
+    init() {
+      this.tick();
+      setInterval(() => this.tick(), 1000);
+    },
+    tick() {
+      const secs = Math.max(0, Math.floor(Date.now() / 1000) - startedAt);
+      const h = Math.floor(secs / 3600);
+      const m = Math.floor((secs % 3600) / 60);
+      const s = String(secs % 60).padStart(2, "0");
+      this.elapsed = h > 0 ? `${h}:${String(m).padStart(2, "0")}:${s}` : `${m}:${s}`;
+    },
+  };
+}
+
+function restTimer() {
+  return {
+    remaining: 0,
+    timer: null,
+    start(seconds) {
+      this.stop();
+      this.remaining = seconds;
+      if (seconds <= 0) {
+        return;
+      }
+      this.timer = setInterval(() => {
+        this.remaining--;
+        if (this.remaining <= 0) {
+          this.stop();
+        }
+      }, 1000);
+    },
+    stop() {
+      clearInterval(this.timer);
+      this.timer = null;
+      this.remaining = 0;
+    },
+    label() {
+      const m = Math.floor(this.remaining / 60);
+      const s = String(this.remaining % 60).padStart(2, "0");
+      return `${m}:${s}`;
+    },
+  };
+}